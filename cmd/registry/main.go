@@ -17,6 +17,7 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/importer"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 )
 
 // Version info for the MCP Registry application
@@ -55,18 +56,34 @@ func main() {
 
 	// Initialize configuration
 	cfg := config.NewConfig()
+	if err := cfg.Validate(); err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return
+	}
 
-	// Create a context with timeout for PostgreSQL connection
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	poolOptions := database.PoolOptions{
+		MaxConns:            cfg.DBMaxConns,
+		MinConns:            cfg.DBMinConns,
+		MaxConnIdleTime:     cfg.DBMaxConnIdleTime,
+		MaxConnLifetime:     cfg.DBMaxConnLifetime,
+		ReadReplicaURI:      cfg.DBReadReplicaURI,
+		ConnectAttempts:     cfg.DBConnectAttempts,
+		ConnectRetryBackoff: cfg.DBConnectRetryBackoff,
+	}
+
+	// Create a context with timeout for PostgreSQL connection, allowing each connection attempt 10 seconds
+	ctx, cancel := context.WithTimeout(context.Background(), poolOptions.ConnectTimeout(10*time.Second))
 	defer cancel()
 
 	// Connect to PostgreSQL
-	db, err = database.NewPostgreSQL(ctx, cfg.DatabaseURL)
+	pg, err := database.NewPostgreSQL(ctx, cfg.DatabaseURL, poolOptions)
 	if err != nil {
 		log.Printf("Failed to connect to PostgreSQL: %v", err)
 		return
 	}
 
+	db = database.WithTracing(pg)
+
 	// Store the PostgreSQL instance for later cleanup
 	defer func() {
 		if err := db.Close(); err != nil {
@@ -84,7 +101,7 @@ func main() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
 
-		importerService := importer.NewService(registryService)
+		importerService := importer.NewServiceWithOptions(registryService, importer.Options{BulkLoad: cfg.SeedBulkLoad})
 		if err := importerService.ImportFromPath(ctx, cfg.SeedFrom); err != nil {
 			log.Printf("Failed to import seed data: %v", err)
 		}
@@ -102,6 +119,39 @@ func main() {
 		}
 	}()
 
+	shutdownTracing, err := telemetry.InitTracing(cfg.TracingEndpoint, cfg.Version)
+	if err != nil {
+		log.Printf("Failed to initialize tracing: %v", err)
+		return
+	}
+
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("Failed to shutdown tracing: %v", err)
+		}
+	}()
+
+	if err := metrics.RegisterDBPoolMetrics(func() telemetry.DBPoolStats {
+		stat := pg.PoolStats()
+		return telemetry.DBPoolStats{
+			Acquired: int64(stat.AcquiredConns()),
+			Idle:     int64(stat.IdleConns()),
+			Total:    int64(stat.TotalConns()),
+		}
+	}); err != nil {
+		log.Printf("Failed to register database pool metrics: %v", err)
+	}
+
+	if err := metrics.RegisterRegistryBreakerMetrics(func() map[string]bool {
+		states := make(map[string]bool)
+		for _, registryType := range validators.RegistryTypes() {
+			states[registryType] = validators.DefaultRegistryBreaker.State(cfg, registryType) != validators.BreakerClosed
+		}
+		return states
+	}); err != nil {
+		log.Printf("Failed to register registry breaker metrics: %v", err)
+	}
+
 	// Initialize HTTP server
 	server := api.NewServer(cfg, registryService, metrics)
 
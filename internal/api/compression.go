@@ -0,0 +1,149 @@
+package api
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// defaultCompressionMinBytes is the smallest response body compressed when the config leaves the threshold unset
+const defaultCompressionMinBytes = 1024
+
+// CompressionMiddleware gzips GET responses for clients that accept it, once the body reaches minBytes. Smaller
+// bodies are sent as they are, since compressing them saves little and costs a round of CPU on both ends.
+// Responses that already carry a Content-Encoding are left alone, and a strong ETag is weakened when the body is
+// compressed, as the compressed bytes are a different representation of the resource.
+func CompressionMiddleware(minBytes int) func(http.Handler) http.Handler {
+	if minBytes <= 0 {
+		minBytes = defaultCompressionMinBytes
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// The body depends on Accept-Encoding, so caches must key on it whether or not this client gets gzip
+			w.Header().Add("Vary", "Accept-Encoding")
+			if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressResponseWriter{ResponseWriter: w, minBytes: minBytes, status: http.StatusOK}
+			defer func() { _ = cw.Close() }()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows a gzip response
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+
+		name, value, found := strings.Cut(strings.TrimSpace(params), "=")
+		if found && strings.EqualFold(strings.TrimSpace(name), "q") {
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && q == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// compressResponseWriter buffers the start of a response until it knows whether the body reaches the compression
+// threshold, then either streams the rest through gzip or writes it out unchanged
+type compressResponseWriter struct {
+	http.ResponseWriter
+	minBytes int
+	status   int
+	buf      []byte
+	started  bool
+	gz       *gzip.Writer
+}
+
+func (w *compressResponseWriter) WriteHeader(status int) {
+	if w.started {
+		return
+	}
+	// Informational responses precede the real one
+	if status < http.StatusOK {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+	// Responses without a body have nothing to compress
+	if status == http.StatusNoContent || status == http.StatusNotModified {
+		_ = w.start(false)
+	}
+}
+
+func (w *compressResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.buf = append(w.buf, p...)
+		if len(w.buf) < w.minBytes {
+			return len(p), nil
+		}
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if w.gz != nil {
+		return w.gz.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// start writes the status line and headers, compressing the rest of the body if requested and the handler has
+// not already encoded it, then flushes the buffered start of the body
+func (w *compressResponseWriter) start(compress bool) error {
+	w.started = true
+	h := w.Header()
+	if compress && h.Get("Content-Encoding") == "" {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.gz != nil {
+		_, err := w.gz.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// Close writes out a body that stayed under the threshold, or finishes the gzip stream
+func (w *compressResponseWriter) Close() error {
+	if !w.started {
+		return w.start(false)
+	}
+	if w.gz != nil {
+		return w.gz.Close()
+	}
+	return nil
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
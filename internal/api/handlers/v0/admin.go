@@ -0,0 +1,182 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ReindexLatestInput represents the input for recomputing the latest versions of all servers
+type ReindexLatestInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+}
+
+// PurgeDeletedInput represents the input for permanently removing old deleted servers
+type PurgeDeletedInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	OlderThanDays int    `query:"older_than_days" doc:"Purge deleted versions last updated more than this many days ago" minimum:"1" required:"true"`
+}
+
+// CreateAliasInput represents the input for making an old server name resolve to a renamed server
+type CreateAliasInput struct {
+	Authorization string            `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	Body          apiv0.ServerAlias `body:""`
+}
+
+// RawServerInput represents the input for getting a server version as stored
+type RawServerInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+}
+
+// RegisterAdminEndpoints registers the admin maintenance endpoints
+func RegisterAdminEndpoints(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "reindex-latest",
+		Method:      http.MethodPost,
+		Path:        "/v0/admin/reindex-latest",
+		Summary:     "Reindex latest versions",
+		Description: "Recompute the latest version of every server and repair servers whose latest version is marked incorrectly (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ReindexLatestInput) (*Response[apiv0.ReindexLatestResult], error) {
+		// The job changes every server, so it is reserved for admins with wildcard edit permissions
+		if err := requireGlobalEdit(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		result, err := registry.ReindexLatest(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to reindex latest versions", err)
+		}
+
+		return &Response[apiv0.ReindexLatestResult]{Body: *result}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "purge-deleted",
+		Method:      http.MethodPost,
+		Path:        "/v0/admin/purge",
+		Summary:     "Purge deleted servers",
+		Description: "Permanently remove deleted server versions last updated more than the given number of days ago (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *PurgeDeletedInput) (*Response[apiv0.PurgeDeletedResult], error) {
+		// Purged versions cannot be recovered, so this is reserved for admins with wildcard edit permissions
+		if err := requireGlobalEdit(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		result, err := registry.PurgeDeleted(ctx, time.Now().AddDate(0, 0, -input.OlderThanDays))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to purge deleted servers", err)
+		}
+
+		return &Response[apiv0.PurgeDeletedResult]{Body: *result}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-alias",
+		Method:        http.MethodPost,
+		Path:          "/v0/admin/aliases",
+		Summary:       "Create server alias",
+		Description:   "Make an old server name resolve to the server it was renamed to, e.g. after a namespace rename (admin only).",
+		Tags:          []string{"admin"},
+		DefaultStatus: http.StatusCreated,
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *CreateAliasInput) (*Response[apiv0.ServerAlias], error) {
+		// Aliases can redirect any server name, so they are reserved for admins with wildcard edit permissions
+		if err := requireGlobalEdit(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		alias, err := registry.CreateAlias(ctx, input.Body.Alias, input.Body.Target)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrAlreadyExists):
+				return nil, huma.Error409Conflict("Alias already exists", err)
+			case errors.Is(err, service.ErrInvalidAlias), errors.Is(err, service.ErrAliasLoop):
+				return nil, huma.Error400BadRequest("Invalid alias", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to create alias", err)
+		}
+
+		return &Response[apiv0.ServerAlias]{Body: *alias}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-raw-server",
+		Method:      http.MethodGet,
+		Path:        "/v0/admin/servers/{serverName}/versions/{version}/raw",
+		Summary:     "Get stored server version",
+		Description: "Get a server version exactly as stored, with the stored server.json value and the metadata columns returned separately, for debugging data issues (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RawServerInput) (*Response[apiv0.RawServer], error) {
+		// The stored value includes versions hidden from everyone else, so this is reserved for admins
+		if err := requireGlobalEdit(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		raw, err := registry.GetRawServer(ctx, serverName, version)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to get stored server", err)
+		}
+
+		return &Response[apiv0.RawServer]{Body: *raw}, nil
+	})
+}
+
+// requireGlobalEdit validates the bearer token in authHeader and checks that it grants edit permission on every server
+func requireGlobalEdit(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) error {
+	// Extract bearer token
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+	token := authHeader[len(bearerPrefix):]
+
+	// Validate Registry JWT token
+	claims, err := jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+
+	if _, ok := jwtManager.MatchGlobalPermission(auth.PermissionActionEdit, claims.Permissions); !ok {
+		return huma.Error403Forbidden("You do not have global edit permissions")
+	}
+	return nil
+}
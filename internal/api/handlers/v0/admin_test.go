@@ -0,0 +1,282 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/jackc/pgx/v5"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReindexLatestEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	for _, version := range []string{"1.0.0", "2.0.0"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Name: "com.example/reindex-server", Description: "Reindex test server", Version: version, Packages: testPackages,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterAdminEndpoints(api, registryService, testConfig)
+
+	reindex := func(permissions []auth.Permission) *httptest.ResponseRecorder {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "someone",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/admin/reindex-latest", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("requires global edit permission", func(t *testing.T) {
+		rr := reindex([]auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"}})
+		assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+	})
+
+	t.Run("reports checked and corrected servers", func(t *testing.T) {
+		rr := reindex([]auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result apiv0.ReindexLatestResult
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, apiv0.ReindexLatestResult{ServersChecked: 1, ServersCorrected: 0}, result)
+	})
+}
+
+func TestPurgeDeletedEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+
+	testDB := database.NewTestDB(t)
+	registryService := service.NewRegistryService(testDB, testConfig)
+	for _, name := range []string{"com.example/old-deleted", "com.example/recent-deleted"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Name: name, Description: "Purge test server", Version: "1.0.0", Packages: testPackages,
+		})
+		require.NoError(t, err)
+	}
+	err = testDB.InTransaction(context.Background(), func(ctx context.Context, tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `UPDATE servers SET status = 'deleted', updated_at = NOW() - INTERVAL '90 days' WHERE server_name = 'com.example/old-deleted'`)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `UPDATE servers SET status = 'deleted' WHERE server_name = 'com.example/recent-deleted'`)
+		return err
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterAdminEndpoints(api, registryService, testConfig)
+
+	purge := func(query string, permissions []auth.Permission) *httptest.ResponseRecorder {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "someone",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/admin/purge"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+	adminPermissions := []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}}
+
+	t.Run("requires global edit permission", func(t *testing.T) {
+		rr := purge("?older_than_days=30", []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"}})
+		assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+	})
+
+	t.Run("requires a positive age", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnprocessableEntity, purge("", adminPermissions).Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, purge("?older_than_days=0", adminPermissions).Code)
+	})
+
+	t.Run("purges only old deleted versions", func(t *testing.T) {
+		rr := purge("?older_than_days=30", adminPermissions)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result apiv0.PurgeDeletedResult
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, apiv0.PurgeDeletedResult{VersionsPurged: 1}, result)
+
+		_, err := registryService.GetServerByName(context.Background(), "com.example/old-deleted")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+		_, err = registryService.GetServerByName(context.Background(), "com.example/recent-deleted")
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateAliasEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	_, err = registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Name: "io.github.new-org/server", Description: "Alias test server", Version: "1.0.0", Packages: testPackages,
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterAdminEndpoints(api, registryService, testConfig)
+
+	createAlias := func(alias, target string, permissions []auth.Permission) *httptest.ResponseRecorder {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "someone",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		body, err := json.Marshal(apiv0.ServerAlias{Alias: alias, Target: target})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/admin/aliases", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+	adminPermissions := []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}}
+
+	t.Run("requires global edit permission", func(t *testing.T) {
+		rr := createAlias("io.github.old-org/server", "io.github.new-org/server",
+			[]auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.new-org/*"}})
+		assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+	})
+
+	t.Run("creates an alias", func(t *testing.T) {
+		rr := createAlias("io.github.old-org/server", "io.github.new-org/server", adminPermissions)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var alias apiv0.ServerAlias
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &alias))
+		assert.Equal(t, "io.github.old-org/server", alias.Alias)
+		assert.Equal(t, "io.github.new-org/server", alias.Target)
+	})
+
+	t.Run("rejects a duplicate alias", func(t *testing.T) {
+		rr := createAlias("io.github.old-org/server", "io.github.new-org/server", adminPermissions)
+		assert.Equal(t, http.StatusConflict, rr.Code, rr.Body.String())
+	})
+
+	t.Run("rejects a loop", func(t *testing.T) {
+		rr := createAlias("io.github.loop-org/server", "io.github.loop-org/server", adminPermissions)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), "alias loop")
+	})
+}
+
+func TestRawServerEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	serverJSON := apiv0.ServerJSON{
+		Name:        "com.example/raw-server",
+		Description: "Raw test server",
+		Version:     "1.0.0",
+		Categories:  []string{"databases"},
+		Packages:    testPackages,
+	}
+	_, err = registryService.CreateServer(context.Background(), &serverJSON)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterAdminEndpoints(api, registryService, testConfig)
+
+	getRaw := func(version string, permissions []auth.Permission) *httptest.ResponseRecorder {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "someone",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/v0/admin/servers/"+url.PathEscape(serverJSON.Name)+"/versions/"+version+"/raw", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+	admin := []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}}
+
+	t.Run("requires global edit permission", func(t *testing.T) {
+		rr := getRaw("1.0.0", []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"}})
+		assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+	})
+
+	t.Run("returns the stored value and columns", func(t *testing.T) {
+		rr := getRaw("1.0.0", admin)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var raw apiv0.RawServer
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &raw))
+		stored, err := json.Marshal(serverJSON)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(stored), string(raw.Value))
+		assert.Equal(t, serverJSON.Name, raw.Columns.ServerName)
+		assert.Equal(t, "1.0.0", raw.Columns.Version)
+		assert.Equal(t, "active", raw.Columns.Status)
+		assert.True(t, raw.Columns.IsLatest)
+		assert.Contains(t, rr.Body.String(), `"isLatest":true`)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		rr := getRaw("9.9.9", admin)
+		assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+	})
+}
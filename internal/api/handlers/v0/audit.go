@@ -0,0 +1,84 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListAuditInput represents the input for listing audit log entries
+type ListAuditInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	ServerName    string `query:"server_name" doc:"Only list entries for this server" required:"false" example:"com.example/my-server"`
+	Cursor        string `query:"cursor" doc:"Pagination cursor" required:"false" example:"audit-cursor-123"`
+	Limit         int    `query:"limit" doc:"Number of items per page" minimum:"1" example:"50"`
+}
+
+// RegisterAuditEndpoint registers the audit log endpoint
+func RegisterAuditEndpoint(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	defaultLimit, maxLimit := registry.ListLimits()
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-audit-entries",
+		Method:      http.MethodGet,
+		Path:        "/v0/audit",
+		Summary:     "List audit log",
+		Description: "List the audit log of publishes, edits and status changes, newest first (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListAuditInput) (*Response[apiv0.AuditListResponse], error) {
+		// Extract bearer token
+		const bearerPrefix = "Bearer "
+		authHeader := input.Authorization
+		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+		}
+		token := authHeader[len(bearerPrefix):]
+
+		// Validate Registry JWT token
+		claims, err := jwtManager.ValidateToken(ctx, token)
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+		}
+
+		// The audit log covers every server, so it is reserved for admins with wildcard edit permissions
+		if _, ok := jwtManager.MatchGlobalPermission(auth.PermissionActionEdit, claims.Permissions); !ok {
+			return nil, huma.Error403Forbidden("You do not have global edit permissions")
+		}
+
+		entries, nextCursor, err := registry.ListAuditEntries(ctx, input.ServerName, input.Cursor, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid cursor", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to get audit log", err)
+		}
+
+		entryValues := make([]apiv0.AuditEntry, len(entries))
+		for i, entry := range entries {
+			entryValues[i] = *entry
+		}
+
+		return &Response[apiv0.AuditListResponse]{
+			Body: apiv0.AuditListResponse{
+				Entries: entryValues,
+				Metadata: apiv0.Metadata{
+					NextCursor: nextCursor,
+					Count:      len(entries),
+				},
+			},
+		}, nil
+	})
+	applyListLimits(api, "/v0/audit", defaultLimit, maxLimit)
+}
@@ -0,0 +1,145 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+	v0.RegisterEditEndpoints(api, registryService, testConfig)
+	v0.RegisterAuditEndpoint(api, registryService, testConfig)
+
+	publisherToken, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "publisher",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.publisher/*"},
+		},
+	})
+	require.NoError(t, err)
+	adminToken, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubOIDC,
+		AuthMethodSubject: "admin",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	serve := func(method, target, token string, body any) *httptest.ResponseRecorder {
+		var reqBody bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reqBody).Encode(body))
+		}
+		req, err := http.NewRequestWithContext(context.Background(), method, target, &reqBody)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+	listAudit := func(query string) apiv0.AuditListResponse {
+		rr := serve(http.MethodGet, "/v0/audit?"+query, adminToken, nil)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response apiv0.AuditListResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+
+	serverJSON := apiv0.ServerJSON{
+		Name:        "io.github.publisher/audited-server",
+		Description: "A server whose changes are audited",
+		Version:     "1.0.0",
+		Packages:    testPackages,
+	}
+	filter := "server_name=" + url.QueryEscape(serverJSON.Name)
+
+	t.Run("publish is audited with the publisher as actor", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/v0/publish", publisherToken, serverJSON)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		response := listAudit(filter)
+		require.Len(t, response.Entries, 1)
+		entry := response.Entries[0]
+		assert.Equal(t, "github-at:publisher", entry.Actor)
+		assert.Equal(t, service.AuditActionPublish, entry.Action)
+		assert.Equal(t, serverJSON.Name, entry.ServerName)
+		assert.Equal(t, "1.0.0", entry.Version)
+		assert.Equal(t, service.AuditResultSuccess, entry.Result)
+		assert.False(t, entry.Timestamp.IsZero())
+	})
+
+	t.Run("edit is audited with the admin as actor", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/v0/servers/"+url.PathEscape(serverJSON.Name)+"/versions/1.0.0?status=deprecated", adminToken, serverJSON)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		response := listAudit(filter)
+		require.Len(t, response.Entries, 2)
+		entry := response.Entries[0]
+		assert.Equal(t, "github-oidc:admin", entry.Actor)
+		assert.Equal(t, service.AuditActionEdit, entry.Action)
+		assert.Equal(t, "1.0.0", entry.Version)
+		assert.Equal(t, service.AuditResultSuccess, entry.Result)
+	})
+
+	t.Run("failed publish is audited as a failure", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/v0/publish", publisherToken, serverJSON)
+		require.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		response := listAudit(filter)
+		require.Len(t, response.Entries, 3)
+		assert.Equal(t, service.AuditActionPublish, response.Entries[0].Action)
+		assert.Equal(t, service.AuditResultFailure, response.Entries[0].Result)
+	})
+
+	t.Run("entries are paginated newest first", func(t *testing.T) {
+		firstPage := listAudit(filter + "&limit=2")
+		require.Len(t, firstPage.Entries, 2)
+		require.NotEmpty(t, firstPage.Metadata.NextCursor)
+
+		secondPage := listAudit(filter + "&limit=2&cursor=" + firstPage.Metadata.NextCursor)
+		require.Len(t, secondPage.Entries, 1)
+		assert.Less(t, secondPage.Entries[0].ID, firstPage.Entries[1].ID)
+		assert.Equal(t, service.AuditActionPublish, secondPage.Entries[0].Action)
+	})
+
+	t.Run("non-admins cannot read the audit log", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/v0/audit", publisherToken, nil)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
@@ -2,8 +2,11 @@ package auth
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"strings"
 	"time"
@@ -29,14 +32,22 @@ type HTTPKeyFetcher interface {
 
 // DefaultHTTPKeyFetcher uses Go's standard HTTP client
 type DefaultHTTPKeyFetcher struct {
-	client *http.Client
+	client    *http.Client
+	userAgent string
 }
 
 // NewDefaultHTTPKeyFetcher creates a new HTTP key fetcher with timeout
 func NewDefaultHTTPKeyFetcher() *DefaultHTTPKeyFetcher {
+	return NewDefaultHTTPKeyFetcherWithRootCAs(nil)
+}
+
+// NewDefaultHTTPKeyFetcherWithRootCAs creates a new HTTP key fetcher that verifies servers against rootCAs,
+// for deployments whose domains use certificates from a private CA. A nil pool uses the system roots.
+func NewDefaultHTTPKeyFetcherWithRootCAs(rootCAs *x509.CertPool) *DefaultHTTPKeyFetcher {
 	return &DefaultHTTPKeyFetcher{
 		client: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: config.NewOutboundTransport(tls.VersionTLS12, rootCAs),
 			// Disable redirects for security purposes:
 			// Prevents people doing weird things like sending us to internal endpoints at different paths
 			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
@@ -52,6 +63,22 @@ func NewDefaultHTTPKeyFetcherWithClient(client *http.Client) *DefaultHTTPKeyFetc
 	return &DefaultHTTPKeyFetcher{client: client}
 }
 
+// WithUserAgent sets the User-Agent sent when fetching keys, returning the fetcher; empty uses
+// config.DefaultOutboundUserAgent
+func (f *DefaultHTTPKeyFetcher) WithUserAgent(userAgent string) *DefaultHTTPKeyFetcher {
+	f.userAgent = userAgent
+	return f
+}
+
+// WithMinTLSVersion sets the lowest TLS version negotiated when fetching keys, returning the fetcher; zero keeps
+// TLS 1.2
+func (f *DefaultHTTPKeyFetcher) WithMinTLSVersion(version uint16) *DefaultHTTPKeyFetcher {
+	if transport, ok := f.client.Transport.(*http.Transport); ok && version != 0 {
+		transport.TLSClientConfig.MinVersion = version
+	}
+	return f
+}
+
 // FetchKey fetches the public key from the well-known HTTP endpoint
 func (f *DefaultHTTPKeyFetcher) FetchKey(ctx context.Context, domain string) (string, error) {
 	url := fmt.Sprintf("https://%s/.well-known/mcp-registry-auth", domain)
@@ -62,7 +89,11 @@ func (f *DefaultHTTPKeyFetcher) FetchKey(ctx context.Context, domain string) (st
 	}
 
 	req.Header.Set("Accept", "text/plain")
-	req.Header.Set("User-Agent", "mcp-registry/1.0")
+	userAgent := f.userAgent
+	if userAgent == "" {
+		userAgent = config.DefaultOutboundUserAgent
+	}
+	req.Header.Set("User-Agent", userAgent)
 
 	resp, err := f.client.Do(req)
 	if err != nil {
@@ -96,9 +127,21 @@ type HTTPAuthHandler struct {
 
 // NewHTTPAuthHandler creates a new HTTP authentication handler
 func NewHTTPAuthHandler(cfg *config.Config) *HTTPAuthHandler {
+	// The CA bundle is checked when the config is validated, so a failure here means an unvalidated config
+	// was passed in directly. Fall back to the system roots, which still refuse unknown CAs.
+	var rootCAs *x509.CertPool
+	if cfg.HTTPAuthCABundle != "" {
+		pool, err := config.LoadCABundle(cfg.HTTPAuthCABundle)
+		if err != nil {
+			log.Printf("Warning: ignoring HTTP auth CA bundle: %v", err)
+		} else {
+			rootCAs = pool
+		}
+	}
+
 	return &HTTPAuthHandler{
 		CoreAuthHandler: *NewCoreAuthHandler(cfg),
-		fetcher:         NewDefaultHTTPKeyFetcher(),
+		fetcher:         NewDefaultHTTPKeyFetcherWithRootCAs(rootCAs).WithUserAgent(cfg.UserAgent()).WithMinTLSVersion(cfg.MinTLSVersion()),
 	}
 }
 
@@ -5,12 +5,17 @@ import (
 	"context"
 	"crypto/ed25519"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -387,6 +392,74 @@ func TestDefaultHTTPKeyFetcher(t *testing.T) {
 	}
 }
 
+func TestDefaultHTTPKeyFetcher_CustomCA(t *testing.T) {
+	// httptest signs the server certificate with its own CA, like a private CA in an on-prem deployment
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != wellKnownPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("v=MCPv1; k=ed25519; p=key"))
+	}))
+	defer srv.Close()
+	domain := srv.Listener.Addr().String()
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600))
+
+	t.Run("trusted CA", func(t *testing.T) {
+		rootCAs, err := config.LoadCABundle(bundle)
+		require.NoError(t, err)
+
+		key, err := auth.NewDefaultHTTPKeyFetcherWithRootCAs(rootCAs).FetchKey(context.Background(), domain)
+		require.NoError(t, err)
+		assert.Equal(t, "v=MCPv1; k=ed25519; p=key", key)
+	})
+
+	t.Run("untrusted CA", func(t *testing.T) {
+		_, err := auth.NewDefaultHTTPKeyFetcher().FetchKey(context.Background(), domain)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate signed by unknown authority")
+	})
+}
+
+func TestDefaultHTTPKeyFetcher_MinTLSVersion(t *testing.T) {
+	newServer := func(maxVersion uint16) (string, *x509.CertPool) {
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("v=MCPv1; k=ed25519; p=key"))
+		}))
+		srv.TLS = &tls.Config{MinVersion: tls.VersionTLS10, MaxVersion: maxVersion}
+		srv.StartTLS()
+		t.Cleanup(srv.Close)
+
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(srv.Certificate())
+		return srv.Listener.Addr().String(), rootCAs
+	}
+
+	t.Run("TLS 1.0 server is refused", func(t *testing.T) {
+		domain, rootCAs := newServer(tls.VersionTLS10)
+		_, err := auth.NewDefaultHTTPKeyFetcherWithRootCAs(rootCAs).FetchKey(context.Background(), domain)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "protocol version")
+	})
+
+	t.Run("TLS 1.2 server is accepted by default", func(t *testing.T) {
+		domain, rootCAs := newServer(tls.VersionTLS12)
+		key, err := auth.NewDefaultHTTPKeyFetcherWithRootCAs(rootCAs).FetchKey(context.Background(), domain)
+		require.NoError(t, err)
+		assert.Equal(t, "v=MCPv1; k=ed25519; p=key", key)
+	})
+
+	t.Run("TLS 1.2 server is refused when TLS 1.3 is required", func(t *testing.T) {
+		domain, rootCAs := newServer(tls.VersionTLS12)
+		fetcher := auth.NewDefaultHTTPKeyFetcherWithRootCAs(rootCAs).WithMinTLSVersion(tls.VersionTLS13)
+		_, err := fetcher.FetchKey(context.Background(), domain)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "protocol version")
+	})
+}
+
 func TestHTTPAuthHandler_Permissions(t *testing.T) {
 	cfg := &config.Config{
 		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
@@ -737,3 +810,32 @@ func TestHTTPvsDNS_PermissionDifferences(t *testing.T) {
 		})
 	}
 }
+
+// userAgentCapture records the User-Agent of each request and answers with a key
+type userAgentCapture struct {
+	userAgents []string
+}
+
+func (c *userAgentCapture) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.userAgents = append(c.userAgents, req.Header.Get("User-Agent"))
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader("v=MCPv1; k=ed25519; p=key")),
+		Header:     http.Header{},
+		Request:    req,
+	}, nil
+}
+
+func TestDefaultHTTPKeyFetcher_UserAgent(t *testing.T) {
+	capture := &userAgentCapture{}
+	client := &http.Client{Transport: capture}
+
+	_, err := auth.NewDefaultHTTPKeyFetcherWithClient(client).FetchKey(context.Background(), testDomain)
+	require.NoError(t, err)
+
+	cfg := &config.Config{OutboundUserAgent: "example-mirror/2.0", OperatorContact: "https://example.com/contact"}
+	_, err = auth.NewDefaultHTTPKeyFetcherWithClient(client).WithUserAgent(cfg.UserAgent()).FetchKey(context.Background(), testDomain)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"mcp-registry/1.0", "example-mirror/2.0 (+https://example.com/contact)"}, capture.userAgents)
+}
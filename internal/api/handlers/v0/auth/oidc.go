@@ -262,5 +262,17 @@ func (h *OIDCHandler) buildPermissions(_ *OIDCClaims) []auth.Permission {
 		}
 	}
 
+	if h.config.OIDCReadPerms != "" {
+		for _, pattern := range strings.Split(h.config.OIDCReadPerms, ",") {
+			pattern = strings.TrimSpace(pattern)
+			if pattern != "" {
+				permissions = append(permissions, auth.Permission{
+					Action:          auth.PermissionActionRead,
+					ResourcePattern: pattern,
+				})
+			}
+		}
+	}
+
 	return permissions
 }
@@ -3,6 +3,7 @@ package v0
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -12,35 +13,58 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 // EditServerInput represents the input for editing a server
 type EditServerInput struct {
-	Authorization string           `header:"Authorization" doc:"Registry JWT token with edit permissions" required:"true"`
-	ServerName    string           `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
-	Version       string           `path:"version" doc:"URL-encoded version to edit" example:"1.0.0"`
-	Status        string           `query:"status" doc:"New status for the server (active, deprecated, deleted)" required:"false" enum:"active,deprecated,deleted"`
-	Body          apiv0.ServerJSON `body:""`
+	Authorization      string           `header:"Authorization" doc:"Registry JWT token with edit permissions" required:"true"`
+	ServerName         string           `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version            string           `path:"version" doc:"URL-encoded version to edit" example:"1.0.0"`
+	Status             string           `query:"status" doc:"New status for the server (active, deprecated, deleted)" required:"false" enum:"active,deprecated,deleted"`
+	Unlisted           string           `query:"unlisted" doc:"Hide the version from list and search results ('true') or show it again ('false')" required:"false" enum:"true,false"`
+	DeprecationMessage string           `query:"deprecation_message" doc:"Why the version is deprecated; requires status=deprecated" required:"false" maxLength:"500"`
+	SupersededBy       string           `query:"superseded_by" doc:"Name of the server replacing this one; requires status=deprecated" required:"false" example:"com.example/my-new-server"`
+	Body               apiv0.ServerJSON `body:""`
 }
 
-// RegisterEditEndpoints registers the edit endpoint
+// BulkStatusInput represents the input for setting the status of many versions of a server
+type BulkStatusInput struct {
+	Authorization string         `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	ServerName    string         `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Body          BulkStatusBody `body:""`
+}
+
+// BulkStatusBody is the requested status change
+type BulkStatusBody struct {
+	Status   string   `json:"status" doc:"New status for the versions" enum:"active,deprecated,deleted"`
+	Versions []string `json:"versions,omitempty" doc:"Versions to update; all versions are updated when omitted" required:"false"`
+}
+
+// BulkStatusResult reports how many versions changed status
+type BulkStatusResult struct {
+	Updated int `json:"updated" doc:"Number of versions whose status changed"`
+}
+
+// RegisterEditEndpoints registers the edit endpoints
 func RegisterEditEndpoints(api huma.API, registry service.RegistryService, cfg *config.Config) {
 	jwtManager := auth.NewJWTManager(cfg)
 
 	// Edit server endpoint
 	huma.Register(api, huma.Operation{
-		OperationID: "edit-server",
-		Method:      http.MethodPut,
-		Path:        "/v0/servers/{serverName}/versions/{version}",
-		Summary:     "Edit MCP server",
-		Description: "Update a specific version of an existing MCP server (admin only).",
-		Tags:        []string{"admin"},
+		OperationID:  "edit-server",
+		Method:       http.MethodPut,
+		Path:         "/v0/servers/{serverName}/versions/{version}",
+		Summary:      "Edit MCP server",
+		Description:  "Update a specific version of an existing MCP server (admin only).",
+		Tags:         []string{"admin"},
+		MaxBodyBytes: cfg.MaxPublishBodyBytes,
 		Security: []map[string][]string{
 			{"bearer": {}},
 		},
-	}, func(ctx context.Context, input *EditServerInput) (*Response[apiv0.ServerResponse], error) {
+	}, func(ctx context.Context, input *EditServerInput) (*PermissionResponse[apiv0.ServerResponse], error) {
 		// Extract bearer token
 		const bearerPrefix = "Bearer "
 		authHeader := input.Authorization
@@ -71,13 +95,14 @@ func RegisterEditEndpoints(api huma.API, registry service.RegistryService, cfg *
 		currentServer, err := registry.GetServerByNameAndVersion(ctx, serverName, version)
 		if err != nil {
 			if errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
+				return nil, huma.Error404NotFound("Server not found", err)
 			}
 			return nil, huma.Error500InternalServerError("Failed to get current server", err)
 		}
 
 		// Verify edit permissions for this server using the existing server name
-		if !jwtManager.HasPermission(currentServer.Server.Name, auth.PermissionActionEdit, claims.Permissions) {
+		matchedPermission, ok := jwtManager.MatchPermission(currentServer.Server.Name, auth.PermissionActionEdit, claims.Permissions)
+		if !ok {
 			return nil, huma.Error403Forbidden("You do not have edit permissions for this server")
 		}
 
@@ -91,37 +116,101 @@ func RegisterEditEndpoints(api huma.API, registry service.RegistryService, cfg *
 			return nil, huma.Error400BadRequest("Version in request body must match URL path parameter")
 		}
 
-		// Handle status changes with proper permission validation
+		// Update the server using the service, which enforces the allowed status transitions
+		// Future: Implement logic to allow server authors to change active <-> deprecated
+		// but only admins can set to deleted
+		opts := service.UpdateOptions{Actor: claims.Actor()}
 		if input.Status != "" {
-			newStatus := model.Status(input.Status)
-
-			// Prevent undeleting servers - once deleted, they stay deleted
-			if currentServer.Meta.Official != nil &&
-				currentServer.Meta.Official.Status == model.StatusDeleted &&
-				newStatus != model.StatusDeleted {
-				return nil, huma.Error400BadRequest("Cannot change status of deleted server. Deleted servers cannot be undeleted.")
+			opts.Status = &input.Status
+		}
+		if input.Unlisted != "" {
+			unlisted := input.Unlisted == "true"
+			opts.Unlisted = &unlisted
+		}
+		if input.DeprecationMessage != "" {
+			opts.DeprecationMessage = &input.DeprecationMessage
+		}
+		if input.SupersededBy != "" {
+			opts.SupersededBy = &input.SupersededBy
+		}
+		updatedServer, err := registry.EditServer(ctx, serverName, version, &input.Body, opts)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found", err)
+			}
+			var transitionErr *service.StatusTransitionError
+			if errors.As(err, &transitionErr) {
+				return nil, huma.Error400BadRequest(fmt.Sprintf("Cannot change status of %s server to %s", transitionErr.From, transitionErr.To), err)
+			}
+			if conflict := remoteURLConflict("Failed to edit server", err); conflict != nil {
+				return nil, conflict
 			}
+			if errors.Is(err, validators.ErrValidationQueueTimeout) {
+				return nil, huma.Error503ServiceUnavailable("Failed to edit server", err)
+			}
+			return nil, huma.Error400BadRequest("Failed to edit server", err)
+		}
 
-			// For now, only allow status changes for admins
-			// Future: Implement logic to allow server authors to change active <-> deprecated
-			// but only admins can set to deleted
+		return &PermissionResponse[apiv0.ServerResponse]{
+			MatchedPermission: matchedPermission.ResourcePattern,
+			Warnings:          publishWarnings(cfg, updatedServer.Server),
+			Body:              *updatedServer,
+		}, nil
+	})
+
+	// Bulk status endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-status",
+		Method:      http.MethodPost,
+		Path:        "/v0/servers/{serverName}/status",
+		Summary:     "Set MCP server status",
+		Description: "Set the status of all versions of a server, or a list of versions, in one transaction (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *BulkStatusInput) (*PermissionResponse[BulkStatusResult], error) {
+		// Extract bearer token
+		const bearerPrefix = "Bearer "
+		authHeader := input.Authorization
+		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
 		}
+		token := authHeader[len(bearerPrefix):]
 
-		// Update the server using the service
-		var statusPtr *string
-		if input.Status != "" {
-			statusPtr = &input.Status
+		// Validate Registry JWT token
+		claims, err := jwtManager.ValidateToken(ctx, token)
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+		}
+
+		// Bulk changes span versions and are reserved for admins with wildcard edit permissions
+		matchedPermission, ok := jwtManager.MatchGlobalPermission(auth.PermissionActionEdit, claims.Permissions)
+		if !ok {
+			return nil, huma.Error403Forbidden("You do not have global edit permissions")
+		}
+
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
 		}
-		updatedServer, err := registry.UpdateServer(ctx, serverName, version, &input.Body, statusPtr)
+
+		updated, err := registry.SetServerStatus(ctx, serverName, input.Body.Versions, model.Status(input.Body.Status), claims.Actor())
 		if err != nil {
 			if errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
+				return nil, huma.Error404NotFound("Server not found", err)
 			}
-			return nil, huma.Error400BadRequest("Failed to edit server", err)
+			var transitionErr *service.StatusTransitionError
+			if errors.As(err, &transitionErr) {
+				return nil, huma.Error400BadRequest(fmt.Sprintf("Cannot change status of %s server to %s", transitionErr.From, transitionErr.To), err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to set server status", err)
 		}
 
-		return &Response[apiv0.ServerResponse]{
-			Body: *updatedServer,
+		return &PermissionResponse[BulkStatusResult]{
+			MatchedPermission: matchedPermission.ResourcePattern,
+			Body:              BulkStatusResult{Updated: updated},
 		}, nil
 	})
 }
@@ -34,6 +34,7 @@ func TestEditServerEndpoint(t *testing.T) {
 	cfg := &config.Config{
 		JWTPrivateKey:            hex.EncodeToString(testSeed),
 		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
 	}
 
 	// Create registry service and test data
@@ -45,6 +46,7 @@ func TestEditServerEndpoint(t *testing.T) {
 			Name:        "io.github.testuser/editable-server",
 			Description: "Server that can be edited",
 			Version:     "1.0.0",
+			Packages:    testPackages,
 			Repository: model.Repository{
 				URL:    "https://github.com/testuser/editable-server",
 				Source: "github",
@@ -55,6 +57,7 @@ func TestEditServerEndpoint(t *testing.T) {
 			Name:        "io.github.otheruser/other-server",
 			Description: "Server owned by another user",
 			Version:     "1.0.0",
+			Packages:    testPackages,
 			Repository: model.Repository{
 				URL:    "https://github.com/otheruser/other-server",
 				Source: "github",
@@ -74,6 +77,7 @@ func TestEditServerEndpoint(t *testing.T) {
 		Name:        "io.github.testuser/deleted-server",
 		Description: "Server that was deleted",
 		Version:     "1.0.0",
+		Packages:    testPackages,
 		Repository: model.Repository{
 			URL:    "https://github.com/testuser/deleted-server",
 			Source: "github",
@@ -92,6 +96,7 @@ func TestEditServerEndpoint(t *testing.T) {
 		Name:        "io.github.testuser/build-metadata-server",
 		Description: "Server with build metadata version",
 		Version:     "1.0.0+20130313144700",
+		Packages:    testPackages,
 		Repository: model.Repository{
 			URL:    "https://github.com/testuser/build-metadata-server",
 			Source: "github",
@@ -128,6 +133,7 @@ func TestEditServerEndpoint(t *testing.T) {
 				Name:        "io.github.testuser/editable-server",
 				Description: "Updated server description",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 				Repository: model.Repository{
 					URL:    "https://github.com/testuser/editable-server",
 					Source: "github",
@@ -158,6 +164,7 @@ func TestEditServerEndpoint(t *testing.T) {
 				Name:        "io.github.testuser/editable-server",
 				Description: "Server with status change",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			statusParam:    "deprecated",
 			expectedStatus: http.StatusOK,
@@ -185,6 +192,7 @@ func TestEditServerEndpoint(t *testing.T) {
 				Name:        "io.github.testuser/editable-server",
 				Description: "Test server",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedError:  "Invalid Authorization header format",
@@ -198,6 +206,7 @@ func TestEditServerEndpoint(t *testing.T) {
 				Name:        "io.github.testuser/editable-server",
 				Description: "Test server",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedError:  "Invalid or expired Registry JWT token",
@@ -217,6 +226,7 @@ func TestEditServerEndpoint(t *testing.T) {
 				Name:        "io.github.testuser/editable-server",
 				Description: "Updated test server",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			expectedStatus: http.StatusForbidden,
 			expectedError:  "You do not have edit permissions",
@@ -236,6 +246,7 @@ func TestEditServerEndpoint(t *testing.T) {
 				Name:        "io.github.otheruser/other-server",
 				Description: "Updated test server",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			expectedStatus: http.StatusForbidden,
 			expectedError:  "You do not have edit permissions",
@@ -255,6 +266,7 @@ func TestEditServerEndpoint(t *testing.T) {
 				Name:        "io.github.testuser/non-existent",
 				Description: "Non-existent server",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedError:  "Server not found",
@@ -274,6 +286,7 @@ func TestEditServerEndpoint(t *testing.T) {
 				Name:        "io.github.testuser/renamed-server", // Different name
 				Description: "Trying to rename server",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  "Cannot rename server",
@@ -293,6 +306,7 @@ func TestEditServerEndpoint(t *testing.T) {
 				Name:        "io.github.testuser/editable-server",
 				Description: "Version mismatch test",
 				Version:     "2.0.0", // Different version from URL
+				Packages:    testPackages,
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  "Version in request body must match URL path parameter",
@@ -312,6 +326,7 @@ func TestEditServerEndpoint(t *testing.T) {
 				Name:        "io.github.testuser/deleted-server",
 				Description: "Trying to undelete server",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			statusParam:    "active", // Trying to change from deleted to active
 			expectedStatus: http.StatusBadRequest,
@@ -332,6 +347,7 @@ func TestEditServerEndpoint(t *testing.T) {
 				Name:        "io.github.testuser/build-metadata-server",
 				Description: "Updated server with build metadata",
 				Version:     "1.0.0+20130313144700",
+				Packages:    testPackages,
 				Repository: model.Repository{
 					URL:    "https://github.com/testuser/build-metadata-server",
 					Source: "github",
@@ -413,6 +429,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 	cfg := &config.Config{
 		JWTPrivateKey:            hex.EncodeToString(testSeed),
 		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
 	}
 
 	// Create registry service
@@ -426,6 +443,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 	}{
 		{"com.example/active-server", "1.0.0", model.StatusActive},
 		{"com.example/deprecated-server", "1.0.0", model.StatusDeprecated},
+		{"com.example/deleted-server", "1.0.0", model.StatusDeleted},
 		{"com.example/multi-version-server", "1.0.0", model.StatusActive},
 		{"com.example/multi-version-server", "2.0.0", model.StatusActive},
 	}
@@ -435,6 +453,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 			Name:        server.name,
 			Description: "Test server for editing",
 			Version:     server.version,
+			Packages:    testPackages,
 		})
 		require.NoError(t, err)
 
@@ -444,6 +463,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 				Name:        server.name,
 				Description: "Test server for editing",
 				Version:     server.version,
+				Packages:    testPackages,
 			}, stringPtr(string(server.status)))
 			require.NoError(t, err)
 		}
@@ -485,6 +505,21 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 				toStatus:       "deleted",
 				expectedStatus: http.StatusOK,
 			},
+			{
+				name:           "deleted to deprecated",
+				serverName:     "com.example/deleted-server",
+				version:        "1.0.0",
+				toStatus:       "deprecated",
+				expectedStatus: http.StatusBadRequest,
+				expectedError:  "Cannot change status of deleted server to deprecated",
+			},
+			{
+				name:           "deleted to deleted",
+				serverName:     "com.example/deleted-server",
+				version:        "1.0.0",
+				toStatus:       "deleted",
+				expectedStatus: http.StatusOK,
+			},
 			{
 				name:           "invalid status",
 				serverName:     "com.example/active-server",
@@ -501,6 +536,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 					Name:        tt.serverName,
 					Description: "Status transition test",
 					Version:     tt.version,
+					Packages:    testPackages,
 				}
 
 				bodyBytes, err := json.Marshal(requestBody)
@@ -549,6 +585,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 			Name:        specialServerName,
 			Description: "Server with special characters",
 			Version:     "1.0.0",
+			Packages:    testPackages,
 		})
 		require.NoError(t, err)
 
@@ -556,6 +593,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 			Name:        specialServerName,
 			Description: "Updated server with special chars",
 			Version:     "1.0.0",
+			Packages:    testPackages,
 		}
 
 		bodyBytes, err := json.Marshal(requestBody)
@@ -596,6 +634,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 			Name:        "com.example/multi-version-server",
 			Description: "Updated v1.0.0 specifically",
 			Version:     "1.0.0",
+			Packages:    testPackages,
 		}
 
 		bodyBytes, err := json.Marshal(requestBody)
@@ -636,7 +675,222 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 	})
 }
 
+func TestBulkStatusEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterEditEndpoints(api, registryService, cfg)
+
+	const serverName = "com.example/bulk-status-server"
+	versions := []string{"1.0.0", "1.1.0", "2.0.0"}
+	for _, version := range versions {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Name:        serverName,
+			Description: "Server with several versions",
+			Version:     version,
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+	}
+
+	setStatus := func(claims auth.JWTClaims, body v0.BulkStatusBody) *httptest.ResponseRecorder {
+		token, err := generateTestJWTToken(cfg, claims)
+		require.NoError(t, err)
+
+		requestBody, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/v0/servers/"+url.PathEscape(serverName)+"/status", bytes.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	adminClaims := auth.JWTClaims{
+		AuthMethod:  auth.MethodNone,
+		Permissions: []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}},
+	}
+
+	t.Run("namespace edit permission is not enough", func(t *testing.T) {
+		w := setStatus(auth.JWTClaims{
+			AuthMethod:  auth.MethodGitHubAT,
+			Permissions: []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"}},
+		}, v0.BulkStatusBody{Status: string(model.StatusDeprecated)})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("unknown version is rejected without changes", func(t *testing.T) {
+		w := setStatus(adminClaims, v0.BulkStatusBody{Status: string(model.StatusDeprecated), Versions: []string{"1.0.0", "9.9.9"}})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		server, err := registryService.GetServerByNameAndVersion(context.Background(), serverName, "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusActive, server.Meta.Official.Status)
+	})
+
+	t.Run("deprecates all versions", func(t *testing.T) {
+		w := setStatus(adminClaims, v0.BulkStatusBody{Status: string(model.StatusDeprecated)})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "*", w.Header().Get("X-Matched-Permission"))
+
+		var result v0.BulkStatusResult
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+		assert.Equal(t, len(versions), result.Updated)
+
+		for _, version := range versions {
+			server, err := registryService.GetServerByNameAndVersion(context.Background(), serverName, version)
+			require.NoError(t, err)
+			assert.Equal(t, model.StatusDeprecated, server.Meta.Official.Status, version)
+			assert.Equal(t, version == "2.0.0", server.Meta.Official.IsLatest, version)
+		}
+
+		latest, err := registryService.GetServerByName(context.Background(), serverName)
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", latest.Server.Version)
+	})
+
+	t.Run("reactivates listed versions only", func(t *testing.T) {
+		w := setStatus(adminClaims, v0.BulkStatusBody{Status: string(model.StatusActive), Versions: []string{"1.1.0"}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var result v0.BulkStatusResult
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+		assert.Equal(t, 1, result.Updated)
+
+		server, err := registryService.GetServerByNameAndVersion(context.Background(), serverName, "1.1.0")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusActive, server.Meta.Official.Status)
+
+		server, err = registryService.GetServerByNameAndVersion(context.Background(), serverName, "2.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusDeprecated, server.Meta.Official.Status)
+	})
+}
+
 // Helper function
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestEditServerEndpointDeprecationNotice(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	for _, name := range []string{"com.example/old-server", "com.example/new-server"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Name:        name,
+			Description: "Test server for deprecation",
+			Version:     "1.0.0",
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterEditEndpoints(api, registryService, cfg)
+	v0.RegisterServersEndpoints(api, registryService, cfg)
+
+	jwtManager := auth.NewJWTManager(cfg)
+	tokenResponse, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	edit := func(t *testing.T, query url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		bodyBytes, err := json.Marshal(apiv0.ServerJSON{
+			Name:        "com.example/old-server",
+			Description: "Test server for deprecation",
+			Version:     "1.0.0",
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+
+		requestURL := "/v0/servers/" + url.PathEscape("com.example/old-server") + "/versions/1.0.0?" + query.Encode()
+		req := httptest.NewRequest(http.MethodPut, requestURL, bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tokenResponse.RegistryToken)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	get := func(t *testing.T) apiv0.ServerResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape("com.example/old-server")+"/versions/1.0.0", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		return response
+	}
+
+	t.Run("requires deprecated status", func(t *testing.T) {
+		w := edit(t, url.Values{"deprecation_message": {"Use the new server"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "can only be set when setting status to deprecated")
+	})
+
+	t.Run("rejects a non-existent successor", func(t *testing.T) {
+		w := edit(t, url.Values{"status": {"deprecated"}, "superseded_by": {"com.example/missing-server"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "server com.example/missing-server does not exist")
+		assert.Equal(t, model.StatusActive, get(t).Meta.Official.Status)
+	})
+
+	t.Run("sets message and successor", func(t *testing.T) {
+		w := edit(t, url.Values{
+			"status":              {"deprecated"},
+			"deprecation_message": {"Replaced by the new server"},
+			"superseded_by":       {"com.example/new-server"},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, model.StatusDeprecated, response.Meta.Official.Status)
+		assert.Equal(t, "Replaced by the new server", response.Meta.Official.DeprecationMessage)
+		assert.Equal(t, "com.example/new-server", response.Meta.Official.SupersededBy)
+
+		// The notice round-trips through the get endpoint
+		stored := get(t)
+		assert.Equal(t, "Replaced by the new server", stored.Meta.Official.DeprecationMessage)
+		assert.Equal(t, "com.example/new-server", stored.Meta.Official.SupersededBy)
+	})
+
+	t.Run("reactivating clears the notice", func(t *testing.T) {
+		w := edit(t, url.Values{"status": {"active"}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		stored := get(t)
+		assert.Equal(t, model.StatusActive, stored.Meta.Official.Status)
+		assert.Empty(t, stored.Meta.Official.DeprecationMessage)
+		assert.Empty(t, stored.Meta.Official.SupersededBy)
+	})
+}
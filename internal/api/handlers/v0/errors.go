@@ -0,0 +1,130 @@
+package v0
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+// ErrorCode is a stable, machine-readable identifier for the kind of error an API response reports
+type ErrorCode string
+
+// Error codes returned in the code field of API error responses
+const (
+	ErrorCodeInvalidRequest          ErrorCode = "INVALID_REQUEST"
+	ErrorCodeUnauthenticated         ErrorCode = "UNAUTHENTICATED"
+	ErrorCodePermissionDenied        ErrorCode = "PERMISSION_DENIED"
+	ErrorCodeNotFound                ErrorCode = "NOT_FOUND"
+	ErrorCodeServerNotFound          ErrorCode = "SERVER_NOT_FOUND"
+	ErrorCodeDuplicateVersion        ErrorCode = "DUPLICATE_VERSION"
+	ErrorCodeInvalidStatusTransition ErrorCode = "INVALID_STATUS_TRANSITION"
+	ErrorCodeValidationTimeout       ErrorCode = "VALIDATION_TIMEOUT"
+	ErrorCodeConflict                ErrorCode = "CONFLICT"
+	ErrorCodePayloadTooLarge         ErrorCode = "PAYLOAD_TOO_LARGE"
+	ErrorCodeUnavailable             ErrorCode = "UNAVAILABLE"
+	ErrorCodeInternal                ErrorCode = "INTERNAL_ERROR"
+)
+
+// ErrorModel is the body of API error responses: RFC 9457 problem details, as in huma.ErrorModel, plus an
+// error code. The fields are declared rather than embedded so huma can generate its schema.
+type ErrorModel struct {
+	Type     string              `json:"type,omitempty" format:"uri" default:"about:blank" doc:"A URI reference to human-readable documentation for the error."`
+	Title    string              `json:"title,omitempty" example:"Not Found" doc:"A short, human-readable summary of the problem type."`
+	Status   int                 `json:"status,omitempty" example:"404" doc:"HTTP status code"`
+	Detail   string              `json:"detail,omitempty" example:"Server not found" doc:"A human-readable explanation specific to this occurrence of the problem."`
+	Instance string              `json:"instance,omitempty" format:"uri" doc:"A URI reference that identifies the specific occurrence of the problem."`
+	Code     ErrorCode           `json:"code" example:"SERVER_NOT_FOUND" doc:"Machine-readable error code"`
+	Errors   []*huma.ErrorDetail `json:"errors,omitempty" doc:"Optional list of individual error details"`
+}
+
+// Error returns the error's detail
+func (e *ErrorModel) Error() string {
+	return e.Detail
+}
+
+// Add appends an error detail
+func (e *ErrorModel) Add(err error) {
+	if converted, ok := err.(huma.ErrorDetailer); ok {
+		e.Errors = append(e.Errors, converted.ErrorDetail())
+		return
+	}
+	e.Errors = append(e.Errors, &huma.ErrorDetail{Message: err.Error()})
+}
+
+// GetStatus returns the HTTP status of the error
+func (e *ErrorModel) GetStatus() int {
+	return e.Status
+}
+
+// ContentType serves errors as RFC 9457 problem details
+func (e *ErrorModel) ContentType(ct string) string {
+	return (&huma.ErrorModel{}).ContentType(ct)
+}
+
+// newHumaError is huma's default error constructor, which NewError extends
+var newHumaError = huma.NewError
+
+func init() {
+	huma.NewError = NewError
+}
+
+// NewError creates an API error response, assigning it the code of the first error that has one and
+// otherwise a code based on the HTTP status. It replaces huma.NewError, so every huma.ErrorXXX helper
+// returns coded errors.
+func NewError(status int, msg string, errs ...error) huma.StatusError {
+	model, ok := newHumaError(status, msg, errs...).(*huma.ErrorModel)
+	if !ok {
+		return newHumaError(status, msg, errs...)
+	}
+	return &ErrorModel{
+		Type:     model.Type,
+		Title:    model.Title,
+		Status:   model.Status,
+		Detail:   model.Detail,
+		Instance: model.Instance,
+		Code:     errorCode(status, errs),
+		Errors:   model.Errors,
+	}
+}
+
+// errorCode maps service and database errors to error codes, falling back to a code for the HTTP status
+func errorCode(status int, errs []error) ErrorCode {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			return ErrorCodeServerNotFound
+		case errors.Is(err, database.ErrInvalidVersion):
+			return ErrorCodeDuplicateVersion
+		case errors.Is(err, service.ErrInvalidStatusTransition):
+			return ErrorCodeInvalidStatusTransition
+		case errors.Is(err, validators.ErrValidationTimeout):
+			return ErrorCodeValidationTimeout
+		}
+	}
+
+	switch {
+	case status == http.StatusUnauthorized:
+		return ErrorCodeUnauthenticated
+	case status == http.StatusForbidden:
+		return ErrorCodePermissionDenied
+	case status == http.StatusNotFound:
+		return ErrorCodeNotFound
+	case status == http.StatusConflict:
+		return ErrorCodeConflict
+	case status == http.StatusRequestEntityTooLarge:
+		return ErrorCodePayloadTooLarge
+	case status == http.StatusServiceUnavailable:
+		return ErrorCodeUnavailable
+	case status >= http.StatusInternalServerError:
+		return ErrorCodeInternal
+	default:
+		return ErrorCodeInvalidRequest
+	}
+}
@@ -0,0 +1,129 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestNewErrorCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      huma.StatusError
+		expected v0.ErrorCode
+	}{
+		{"database not found", huma.Error404NotFound("Server not found", database.ErrNotFound), v0.ErrorCodeServerNotFound},
+		{"wrapped duplicate version", huma.Error400BadRequest("Failed to publish server", fmt.Errorf("publish: %w", database.ErrInvalidVersion)), v0.ErrorCodeDuplicateVersion},
+		{"status transition", huma.Error400BadRequest("Cannot change status", &service.StatusTransitionError{From: "deleted", To: "active"}), v0.ErrorCodeInvalidStatusTransition},
+		{"unauthorized", huma.Error401Unauthorized("Invalid token"), v0.ErrorCodeUnauthenticated},
+		{"forbidden", huma.Error403Forbidden("No permission"), v0.ErrorCodePermissionDenied},
+		{"other not found", huma.Error404NotFound("Not found"), v0.ErrorCodeNotFound},
+		{"conflict", huma.Error409Conflict("Conflict"), v0.ErrorCodeConflict},
+		{"internal", huma.Error500InternalServerError("Failed", fmt.Errorf("boom")), v0.ErrorCodeInternal},
+		{"bad request", huma.Error400BadRequest("Invalid cursor"), v0.ErrorCodeInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.err)
+			require.NoError(t, err)
+
+			var body struct {
+				Status int          `json:"status"`
+				Detail string       `json:"detail"`
+				Code   v0.ErrorCode `json:"code"`
+			}
+			require.NoError(t, json.Unmarshal(data, &body))
+			assert.Equal(t, tt.expected, body.Code)
+			assert.Equal(t, tt.err.GetStatus(), body.Status)
+			assert.NotEmpty(t, body.Detail)
+		})
+	}
+}
+
+func TestErrorResponsesIncludeCode(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, cfg)
+	v0.RegisterPublishEndpoint(api, registryService, cfg)
+
+	token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publish := func(name string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Name:        name,
+			Description: "A test server",
+			Version:     "1.0.0",
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	assertCode := func(t *testing.T, rr *httptest.ResponseRecorder, status int, code v0.ErrorCode) {
+		t.Helper()
+		assert.Equal(t, status, rr.Code)
+		var body v0.ErrorModel
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, code, body.Code)
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/v0/servers/"+url.PathEscape("com.example/missing"), nil)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		assertCode(t, rr, http.StatusNotFound, v0.ErrorCodeServerNotFound)
+	})
+
+	t.Run("duplicate version", func(t *testing.T) {
+		rr := publish("com.example/coded-server")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assertCode(t, publish("com.example/coded-server"), http.StatusBadRequest, v0.ErrorCodeDuplicateVersion)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		assertCode(t, publish("org.other/server"), http.StatusForbidden, v0.ErrorCodePermissionDenied)
+	})
+}
@@ -0,0 +1,73 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/sse"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// eventsHeartbeatInterval is how often a heartbeat is sent to keep idle event streams open
+const eventsHeartbeatInterval = 15 * time.Second
+
+// eventsRetryMillis tells clients how long to wait before reconnecting to the event stream
+const eventsRetryMillis = 3000
+
+// ListEventsInput represents the input for streaming registry events
+type ListEventsInput struct {
+	LastEventID int `header:"Last-Event-ID" doc:"ID of the last event received; retained events after it are replayed on reconnect" required:"false" minimum:"0"`
+}
+
+// HeartbeatEvent is sent periodically on the event stream so clients can detect dropped connections
+type HeartbeatEvent struct {
+	Timestamp time.Time `json:"timestamp" doc:"When the heartbeat was sent"`
+}
+
+// RegisterEventsEndpoint registers the server-sent events endpoint for registry changes
+func RegisterEventsEndpoint(api huma.API, registry service.RegistryService) {
+	sse.Register(api, huma.Operation{
+		OperationID: "stream-events",
+		Method:      http.MethodGet,
+		Path:        "/v0/events",
+		Summary:     "Stream registry events",
+		Description: "Stream publish, edit and status change events as server-sent events. Reconnecting clients send the Last-Event-ID header to receive events they missed.",
+		Tags:        []string{"servers"},
+	}, map[string]any{
+		"change":    events.Event{},
+		"heartbeat": HeartbeatEvent{},
+	}, func(ctx context.Context, input *ListEventsInput, send sse.Sender) {
+		changes, unsubscribe := registry.SubscribeEvents(input.LastEventID)
+		defer unsubscribe()
+
+		// Open the stream straight away, telling clients how long to wait before reconnecting
+		if err := send(sse.Message{Data: HeartbeatEvent{Timestamp: time.Now()}, Retry: eventsRetryMillis}); err != nil {
+			return
+		}
+
+		ticker := time.NewTicker(eventsHeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-changes:
+				if !ok {
+					// Dropped for falling behind: the client reconnects with its Last-Event-ID to catch up
+					return
+				}
+				if err := send(sse.Message{ID: event.ID, Data: event}); err != nil {
+					return
+				}
+			case <-ticker.C:
+				if err := send.Data(HeartbeatEvent{Timestamp: time.Now()}); err != nil {
+					return
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package v0_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseMessage is a single parsed server-sent event
+type sseMessage struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// readSSEMessage reads lines until a complete server-sent event has been received
+func readSSEMessage(t *testing.T, reader *bufio.Reader) sseMessage {
+	t.Helper()
+	var msg sseMessage
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "":
+			if msg.Data != "" {
+				return msg
+			}
+		case strings.HasPrefix(line, "id: "):
+			msg.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			msg.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			msg.Data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+// connectEvents opens the event stream, optionally resuming after lastEventID
+func connectEvents(ctx context.Context, t *testing.T, serverURL, lastEventID string) *bufio.Reader {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL+"/v0/events", nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/event-stream")
+
+	reader := bufio.NewReader(resp.Body)
+	// The stream opens with a heartbeat
+	assert.Equal(t, "heartbeat", readSSEMessage(t, reader).Event)
+	return reader
+}
+
+func TestEventsEndpoint(t *testing.T) {
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{MaxVersionsPerServer: 10000})
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterEventsEndpoint(api, registryService)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Cancelled before the server closes, so the open streams end first
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	reader := connectEvents(ctx, t, server.URL, "")
+
+	serverJSON := &apiv0.ServerJSON{
+		Name:        "com.example/events-server",
+		Description: "Events test server",
+		Version:     "1.0.0",
+		Packages:    testPackages,
+	}
+	_, err := registryService.CreateServer(ctx, serverJSON)
+	require.NoError(t, err)
+
+	msg := readSSEMessage(t, reader)
+	assert.Equal(t, "change", msg.Event)
+	assert.NotEmpty(t, msg.ID)
+
+	var event events.Event
+	require.NoError(t, json.Unmarshal([]byte(msg.Data), &event))
+	assert.Equal(t, events.TypeServerPublished, event.Type)
+	assert.Equal(t, "com.example/events-server", event.ServerName)
+	assert.Equal(t, "1.0.0", event.Version)
+
+	// Deprecating the version emits a status change event
+	status := "deprecated"
+	_, err = registryService.UpdateServer(ctx, serverJSON.Name, serverJSON.Version, serverJSON, &status)
+	require.NoError(t, err)
+
+	msg = readSSEMessage(t, reader)
+	require.NoError(t, json.Unmarshal([]byte(msg.Data), &event))
+	assert.Equal(t, events.TypeServerStatusChanged, event.Type)
+
+	// Reconnecting with the first event's ID replays only the status change
+	reconnected := connectEvents(ctx, t, server.URL, strconv.Itoa(event.ID-1))
+	msg = readSSEMessage(t, reconnected)
+	require.NoError(t, json.Unmarshal([]byte(msg.Data), &event))
+	assert.Equal(t, events.TypeServerStatusChanged, event.Type)
+}
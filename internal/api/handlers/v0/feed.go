@@ -0,0 +1,146 @@
+package v0
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// defaultFeedItemCount is used when the config does not set FeedItemCount
+const defaultFeedItemCount = 50
+
+const atomContentType = "application/atom+xml; charset=utf-8"
+
+// AtomFeedOutput is the raw Atom document returned by the feed endpoint
+type AtomFeedOutput struct {
+	ContentType string `header:"Content-Type"`
+	Body        []byte
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published"`
+	Links     []atomLink `xml:"link"`
+	Summary   string     `xml:"summary,omitempty"`
+	Author    atomAuthor `xml:"author"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// RegisterFeedEndpoint registers the Atom feed of recent publishes and updates
+func RegisterFeedEndpoint(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	itemCount := cfg.FeedItemCount
+	if itemCount <= 0 {
+		itemCount = defaultFeedItemCount
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-feed-atom",
+		Method:      http.MethodGet,
+		Path:        "/v0/feed.atom",
+		Summary:     "Atom feed of registry changes",
+		Description: "Recently published and updated server versions as an Atom feed, most recent first.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, _ *struct{}) (*AtomFeedOutput, error) {
+		filter := &database.ServerFilter{Sort: database.SortByRecentlyUpdated}
+		servers, _, err := registry.ListServers(ctx, filter, "", itemCount)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get recent changes", err)
+		}
+
+		body, err := renderAtomFeed(servers, time.Now())
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to render feed", err)
+		}
+
+		return &AtomFeedOutput{
+			ContentType: atomContentType,
+			Body:        body,
+		}, nil
+	})
+}
+
+// renderAtomFeed renders server versions, most recently updated first, as an Atom document
+func renderAtomFeed(servers []*apiv0.ServerResponse, now time.Time) ([]byte, error) {
+	feed := atomFeed{
+		ID:      "urn:mcp-registry:feed",
+		Title:   "MCP Registry changes",
+		Updated: now.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: "/v0/feed.atom", Rel: "self", Type: "application/atom+xml"},
+		},
+	}
+
+	for _, server := range servers {
+		official := server.Meta.Official
+		if official == nil {
+			continue
+		}
+
+		path := "/v0/servers/" + url.PathEscape(server.Server.Name) + "/versions/" + url.PathEscape(server.Server.Version)
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:        fmt.Sprintf("urn:mcp-registry:server:%s:%s", server.Server.Name, server.Server.Version),
+			Title:     feedEntryTitle(server),
+			Updated:   official.UpdatedAt.UTC().Format(time.RFC3339Nano),
+			Published: official.PublishedAt.UTC().Format(time.RFC3339Nano),
+			Links:     []atomLink{{Href: path, Rel: "alternate", Type: "application/json"}},
+			Summary:   server.Server.Description,
+			Author:    atomAuthor{Name: server.Server.Name},
+		})
+	}
+
+	// The feed was last updated when its most recent entry was
+	if len(feed.Entries) > 0 {
+		feed.Updated = feed.Entries[0].Updated
+	}
+
+	out, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// feedEntryTitle describes what happened to the server version
+func feedEntryTitle(server *apiv0.ServerResponse) string {
+	official := server.Meta.Official
+	action := "published"
+	switch {
+	case official.Status == model.StatusDeprecated:
+		action = "deprecated"
+	case official.Status == model.StatusDeleted:
+		action = "deleted"
+	case official.UpdatedAt.After(official.PublishedAt):
+		action = "updated"
+	}
+	return fmt.Sprintf("%s %s %s", server.Server.Name, server.Server.Version, action)
+}
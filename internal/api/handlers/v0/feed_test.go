@@ -0,0 +1,92 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAtomFeed struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Updated string   `xml:"updated"`
+	Entries []struct {
+		ID      string `xml:"id"`
+		Title   string `xml:"title"`
+		Updated string `xml:"updated"`
+		Summary string `xml:"summary"`
+		Link    struct {
+			Href string `xml:"href,attr"`
+		} `xml:"link"`
+	} `xml:"entry"`
+}
+
+func TestFeedAtomEndpoint(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{FeedItemCount: 2, MaxVersionsPerServer: 10000}
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	for _, name := range []string{"com.example/feed-alpha", "com.example/feed-beta", "com.example/feed-gamma"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "Feed test server",
+			Version:     "1.0.0",
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond) // keep publish times distinct so ordering is deterministic
+	}
+
+	// Updating the oldest server moves it to the top of the feed
+	_, err := registryService.UpdateServer(ctx, "com.example/feed-alpha", "1.0.0", &apiv0.ServerJSON{
+		Name:        "com.example/feed-alpha",
+		Description: "Updated feed test server",
+		Version:     "1.0.0",
+		Packages:    testPackages,
+	}, nil)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterFeedEndpoint(api, registryService, cfg)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/feed.atom", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/atom+xml")
+
+	var feed testAtomFeed
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &feed), "feed should be valid Atom XML")
+	assert.NotEmpty(t, feed.ID)
+	assert.NotEmpty(t, feed.Title)
+
+	// Limited to the configured item count, most recent change first
+	require.Len(t, feed.Entries, 2)
+	assert.Equal(t, "com.example/feed-alpha 1.0.0 updated", feed.Entries[0].Title)
+	assert.Equal(t, "Updated feed test server", feed.Entries[0].Summary)
+	assert.Equal(t, "/v0/servers/com.example%2Ffeed-alpha/versions/1.0.0", feed.Entries[0].Link.Href)
+	assert.Equal(t, "com.example/feed-gamma 1.0.0 published", feed.Entries[1].Title)
+	assert.Equal(t, feed.Entries[0].Updated, feed.Updated)
+
+	for _, entry := range feed.Entries {
+		_, err := time.Parse(time.RFC3339Nano, entry.Updated)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, entry.ID)
+	}
+}
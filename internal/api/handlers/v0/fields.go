@@ -0,0 +1,121 @@
+package v0
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// fieldOfficial selects the registry-generated metadata (_meta.io.modelcontextprotocol.registry/official)
+const fieldOfficial = "official"
+
+// selectableServerFields is the whitelist of server.json fields that can be requested via the fields query parameter
+var selectableServerFields = map[string]bool{
+	"$schema":          true,
+	"name":             true,
+	"description":      true,
+	"repository":       true,
+	"version":          true,
+	"websiteUrl":       true,
+	"iconUrl":          true,
+	"documentationUrl": true,
+	"longDescription":  true,
+	"categories":       true,
+	"maintainers":      true,
+	"packages":         true,
+	"remotes":          true,
+	"_meta":            true,
+	fieldOfficial:      true,
+}
+
+// fieldSelectingOperations are the operations that accept the fields query parameter
+var fieldSelectingOperations = map[string]bool{
+	"list-servers":                true,
+	"get-server":                  true,
+	"get-server-version":          true,
+	"get-server-versions":         true,
+	"get-server-latest-for-major": true,
+}
+
+// validateFieldSelection returns a 400 error if any requested field is not selectable
+func validateFieldSelection(fields []string) error {
+	for _, field := range fields {
+		if !selectableServerFields[field] {
+			return huma.Error400BadRequest(fmt.Sprintf("Unknown field '%s' in fields parameter", field))
+		}
+	}
+	return nil
+}
+
+// SelectServerFields is a response transformer that projects server responses down to the fields
+// requested via the fields query parameter. It must be added to the API config's Transformers.
+func SelectServerFields(ctx huma.Context, _ string, v any) (any, error) {
+	if op := ctx.Operation(); op == nil || !fieldSelectingOperations[op.OperationID] {
+		return v, nil
+	}
+
+	var fields []string
+	requestURL := ctx.URL()
+	for _, value := range requestURL.Query()["fields"] {
+		for _, field := range strings.Split(value, ",") {
+			if field != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return v, nil
+	}
+
+	switch body := v.(type) {
+	case apiv0.ServerResponse:
+		return projectServerResponse(body, fields)
+	case apiv0.ServerListResponse:
+		servers := make([]map[string]any, len(body.Servers))
+		for i, server := range body.Servers {
+			projected, err := projectServerResponse(server, fields)
+			if err != nil {
+				return nil, err
+			}
+			servers[i] = projected
+		}
+		return map[string]any{
+			"servers":  servers,
+			"metadata": body.Metadata,
+		}, nil
+	default:
+		return v, nil
+	}
+}
+
+// projectServerResponse builds a response containing only the selected whitelisted fields
+func projectServerResponse(response apiv0.ServerResponse, fields []string) (map[string]any, error) {
+	serverJSON, err := json.Marshal(response.Server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server: %w", err)
+	}
+	var serverFields map[string]json.RawMessage
+	if err := json.Unmarshal(serverJSON, &serverFields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server: %w", err)
+	}
+
+	server := make(map[string]json.RawMessage, len(fields))
+	projected := map[string]any{"server": server}
+	for _, field := range fields {
+		if !selectableServerFields[field] {
+			continue
+		}
+		if field == fieldOfficial {
+			projected["_meta"] = response.Meta
+			continue
+		}
+		if value, ok := serverFields[field]; ok {
+			server[field] = value
+		}
+	}
+
+	return projected, nil
+}
@@ -0,0 +1,68 @@
+package v0
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLContentType is the media type clients send in the Accept header to receive YAML
+const YAMLContentType = "application/yaml"
+
+// YAMLFormat marshals responses as YAML for clients that prefer it, e.g. CLI tooling.
+// Values are converted through JSON so the output uses the same field names and order as JSON responses.
+var YAMLFormat = huma.Format{
+	Marshal: func(w io.Writer, v any) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		// JSON is valid YAML, so decoding it as a node keeps the JSON field order
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return err
+		}
+		useBlockStyle(&node)
+
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&node); err != nil {
+			return err
+		}
+		return encoder.Close()
+	},
+	Unmarshal: func(data []byte, v any) error {
+		var decoded any
+		if err := yaml.Unmarshal(data, &decoded); err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(decoded); err != nil {
+			return err
+		}
+		return json.Unmarshal(buf.Bytes(), v)
+	},
+}
+
+// useBlockStyle drops the flow style and quoting of nodes decoded from JSON. The encoder still quotes
+// strings that would otherwise read back as another type, such as "1.0", and YAML 1.1 booleans such as
+// "yes" stay quoted for older parsers.
+func useBlockStyle(node *yaml.Node) {
+	node.Style = 0
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" && yaml11Booleans[strings.ToLower(node.Value)] {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+	for _, child := range node.Content {
+		useBlockStyle(child)
+	}
+}
+
+// yaml11Booleans are the plain scalars YAML 1.1 parsers read as booleans
+var yaml11Booleans = map[string]bool{
+	"y": true, "yes": true, "n": true, "no": true, "on": true, "off": true,
+}
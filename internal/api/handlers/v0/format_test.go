@@ -0,0 +1,39 @@
+package v0_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestYAMLFormat_RoundTrip(t *testing.T) {
+	original := apiv0.ServerResponse{
+		Server: apiv0.ServerJSON{
+			Name:        "com.example/yaml-server",
+			Description: "yes",
+			Version:     "1.0",
+			Packages:    testPackages,
+		},
+		Meta: apiv0.ResponseMeta{
+			Official: &apiv0.RegistryExtensions{Status: model.StatusActive, IsLatest: true},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, v0.YAMLFormat.Marshal(&buf, original))
+
+	// Fields use their JSON names, in JSON order, and ambiguous strings stay quoted
+	assert.Contains(t, buf.String(), "server:\n  name: com.example/yaml-server\n  description: \"yes\"\n")
+	assert.Contains(t, buf.String(), "  version: \"1.0\"\n")
+	assert.Contains(t, buf.String(), "_meta:\n  io.modelcontextprotocol.registry/official:\n    status: active\n")
+
+	var decoded apiv0.ServerResponse
+	require.NoError(t, v0.YAMLFormat.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, original, decoded)
+}
@@ -10,16 +10,25 @@ import (
 
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+// Health statuses
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
 )
 
 // HealthBody represents the health check response body
 type HealthBody struct {
-	Status         string `json:"status" example:"ok" doc:"Health status"`
-	GitHubClientID string `json:"github_client_id,omitempty" doc:"GitHub OAuth App Client ID"`
+	Status             string   `json:"status" example:"ok" doc:"Health status: 'ok', or 'degraded' when package registries that ownership validation depends on are failing"`
+	DegradedRegistries []string `json:"degraded_registries,omitempty" doc:"Registry types whose ownership validation is failing because the registry is unreachable or timing out" example:"oci"`
+	GitHubClientID     string   `json:"github_client_id,omitempty" doc:"GitHub OAuth App Client ID"`
 }
 
-// RegisterHealthEndpoint registers the health check endpoint
-func RegisterHealthEndpoint(api huma.API, cfg *config.Config, metrics *telemetry.Metrics) {
+// RegisterHealthEndpoint registers the health check endpoint, reporting degradation of the package registries
+// tracked by breaker
+func RegisterHealthEndpoint(api huma.API, cfg *config.Config, metrics *telemetry.Metrics, breaker *validators.RegistryBreaker) {
 	huma.Register(api, huma.Operation{
 		OperationID: "get-health",
 		Method:      http.MethodGet,
@@ -31,11 +40,20 @@ func RegisterHealthEndpoint(api huma.API, cfg *config.Config, metrics *telemetry
 		// Record the health check metrics
 		recordHealthMetrics(ctx, metrics, "/v0/health", cfg.Version)
 
+		// The API itself still serves requests while registries are down, so degradation is not an error status
+		body := HealthBody{
+			Status:         HealthStatusOK,
+			GitHubClientID: cfg.GithubClientID,
+		}
+		if cfg.EnableRegistryValidation {
+			body.DegradedRegistries = breaker.Degraded(cfg)
+		}
+		if len(body.DegradedRegistries) > 0 {
+			body.Status = HealthStatusDegraded
+		}
+
 		return &Response[HealthBody]{
-			Body: HealthBody{
-				Status:         "ok",
-				GitHubClientID: cfg.GithubClientID,
-			},
+			Body: body,
 		}, nil
 	})
 }
@@ -2,17 +2,24 @@ package v0_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 func TestHealthEndpoint(t *testing.T) {
@@ -56,7 +63,7 @@ func TestHealthEndpoint(t *testing.T) {
 			shutdownTelemetry, metrics, _ := telemetry.InitMetrics("test")
 
 			// Register the health endpoint
-			v0.RegisterHealthEndpoint(api, tc.config, metrics)
+			v0.RegisterHealthEndpoint(api, tc.config, metrics, validators.NewRegistryBreaker())
 
 			// Create a test request
 			req := httptest.NewRequest(http.MethodGet, "/v0/health", nil)
@@ -84,3 +91,43 @@ func TestHealthEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthEndpointRegistryDegradation(t *testing.T) {
+	cfg := &config.Config{
+		EnableRegistryValidation: true,
+		RegistryBreakerThreshold: 3,
+		RegistryBreakerCooldown:  time.Minute,
+	}
+	breaker := validators.NewRegistryBreaker()
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	shutdownTelemetry, metrics, _ := telemetry.InitMetrics("test")
+	defer func() { _ = shutdownTelemetry(context.Background()) }()
+	v0.RegisterHealthEndpoint(api, cfg, metrics, breaker)
+
+	getHealth := func() v0.HealthBody {
+		req := httptest.NewRequest(http.MethodGet, "/v0/health", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var body v0.HealthBody
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body
+	}
+
+	unreachable := &url.Error{Op: "Get", URL: "https://ghcr.io/v2/", Err: errors.New("connection refused")}
+
+	// Failures below the threshold are not yet a degradation
+	breaker.Record(model.RegistryTypeOCI, unreachable)
+	breaker.Record(model.RegistryTypeOCI, unreachable)
+	assert.Equal(t, v0.HealthBody{Status: v0.HealthStatusOK}, getHealth())
+
+	breaker.Record(model.RegistryTypeOCI, unreachable)
+	assert.Equal(t, v0.HealthBody{Status: v0.HealthStatusDegraded, DegradedRegistries: []string{model.RegistryTypeOCI}}, getHealth())
+
+	// A successful check closes the breaker again
+	breaker.Record(model.RegistryTypeOCI, nil)
+	assert.Equal(t, v0.HealthBody{Status: v0.HealthStatusOK}, getHealth())
+}
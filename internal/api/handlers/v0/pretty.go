@@ -0,0 +1,49 @@
+package v0
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// prettyBody marks a response body to be written as indented JSON. It marshals as the wrapped value, so other
+// formats such as YAML are unaffected.
+type prettyBody struct {
+	value any
+}
+
+func (b prettyBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.value)
+}
+
+// PrettyPrint is a response transformer that marks GET responses for indented JSON when the request has
+// pretty=true. It must be added to the API config's Transformers after any transformer that inspects the body
+// type, and JSONFormat must be registered for the JSON content types to honour it.
+func PrettyPrint(ctx huma.Context, _ string, v any) (any, error) {
+	if ctx.Method() != http.MethodGet || v == nil {
+		return v, nil
+	}
+	requestURL := ctx.URL()
+	if pretty, err := strconv.ParseBool(requestURL.Query().Get("pretty")); err != nil || !pretty {
+		return v, nil
+	}
+	return prettyBody{value: v}, nil
+}
+
+// JSONFormat is huma's default JSON format, except that bodies marked by PrettyPrint are indented
+var JSONFormat = huma.Format{
+	Marshal: func(w io.Writer, v any) error {
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		// The encoder compacts the output of MarshalJSON, so unwrap the value and indent it here instead
+		if body, ok := v.(prettyBody); ok {
+			enc.SetIndent("", "  ")
+			return enc.Encode(body.value)
+		}
+		return enc.Encode(v)
+	},
+	Unmarshal: json.Unmarshal,
+}
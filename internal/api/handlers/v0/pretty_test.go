@@ -0,0 +1,91 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestPrettyPrint(t *testing.T) {
+	humaConfig := huma.DefaultConfig("Test API", "1.0.0")
+	humaConfig.CreateHooks = []func(huma.Config) huma.Config{}
+	humaConfig.Transformers = append(humaConfig.Transformers, v0.PrettyPrint)
+	humaConfig.Formats = maps.Clone(humaConfig.Formats)
+	humaConfig.Formats["application/json"] = v0.JSONFormat
+	humaConfig.Formats["json"] = v0.JSONFormat
+	humaConfig.Formats[v0.YAMLContentType] = v0.YAMLFormat
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, humaConfig)
+
+	server := apiv0.ServerResponse{
+		Server: apiv0.ServerJSON{
+			Name:        "com.example/pretty-server",
+			Description: "Tools for <html> & more",
+			Version:     "1.0.0",
+			Packages:    testPackages,
+		},
+		Meta: apiv0.ResponseMeta{
+			Official: &apiv0.RegistryExtensions{Status: model.StatusActive, IsLatest: true},
+		},
+	}
+	huma.Get(api, "/v0/server", func(_ context.Context, _ *struct{}) (*v0.Response[apiv0.ServerResponse], error) {
+		return &v0.Response[apiv0.ServerResponse]{Body: server}, nil
+	})
+	huma.Get(api, "/v0/missing", func(_ context.Context, _ *struct{}) (*struct{}, error) {
+		return nil, huma.Error404NotFound("Server not found")
+	})
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		return w
+	}
+
+	compact := get("/v0/server", "").Body.String()
+	assert.Equal(t, 1, strings.Count(compact, "\n"), "responses are compact by default")
+	assert.Equal(t, compact, get("/v0/server?pretty=false", "").Body.String())
+
+	t.Run("indents the same JSON", func(t *testing.T) {
+		pretty := get("/v0/server?pretty=true", "").Body.String()
+		require.True(t, json.Valid([]byte(pretty)))
+		assert.True(t, strings.HasPrefix(pretty, "{\n  \"server\": {\n    \"name\": \"com.example/pretty-server\""), pretty)
+		assert.Contains(t, pretty, "<html> & more", "HTML characters are not escaped")
+
+		var compactValue, prettyValue any
+		require.NoError(t, json.Unmarshal([]byte(compact), &compactValue))
+		require.NoError(t, json.Unmarshal([]byte(pretty), &prettyValue))
+		assert.Equal(t, compactValue, prettyValue)
+	})
+
+	t.Run("indents errors", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/missing?pretty=true", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "\n  \"status\": 404")
+	})
+
+	t.Run("leaves other formats alone", func(t *testing.T) {
+		yamlBody := get("/v0/server", v0.YAMLContentType).Body.String()
+		assert.Equal(t, yamlBody, get("/v0/server?pretty=true", v0.YAMLContentType).Body.String())
+	})
+}
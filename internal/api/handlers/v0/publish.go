@@ -2,20 +2,31 @@ package v0
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 // PublishServerInput represents the input for publishing a server
 type PublishServerInput struct {
 	Authorization string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)" required:"true"`
+	Unlisted      bool             `query:"unlisted" doc:"Hide this version from list and search results while keeping it resolvable by name" required:"false"`
+	DryRun        bool             `query:"dry_run" doc:"Validate the server and return the registry metadata it would be published with, including whether it would become the latest version, without publishing it" required:"false"`
+	RequireNewer  bool             `query:"require_newer" doc:"Reject the version with 409 Conflict unless it is newer than the current latest version, instead of backfilling an older version" required:"false"`
+	ContentType   string           `header:"Content-Type" hidden:"true"`
 	Body          apiv0.ServerJSON `body:""`
+	RawBody       []byte
 }
 
 // RegisterPublishEndpoint registers the publish endpoint
@@ -23,17 +34,33 @@ func RegisterPublishEndpoint(api huma.API, registry service.RegistryService, cfg
 	// Create JWT manager for token validation
 	jwtManager := auth.NewJWTManager(cfg)
 
-	huma.Register(api, huma.Operation{
-		OperationID: "publish-server",
-		Method:      http.MethodPost,
-		Path:        "/v0/publish",
-		Summary:     "Publish MCP server",
-		Description: "Publish a new MCP server to the registry or update an existing one",
-		Tags:        []string{"publish"},
+	op := huma.Operation{
+		OperationID:  "publish-server",
+		Method:       http.MethodPost,
+		Path:         "/v0/publish",
+		Summary:      "Publish MCP server",
+		Description:  "Publish a new MCP server to the registry or update an existing one",
+		Tags:         []string{"publish"},
+		MaxBodyBytes: cfg.MaxPublishBodyBytes,
 		Security: []map[string][]string{
 			{"bearer": {}},
 		},
-	}, func(ctx context.Context, input *PublishServerInput) (*Response[apiv0.ServerResponse], error) {
+	}
+	// Schema validation rejects fields the server JSON does not define. When they are allowed, the body is validated
+	// against a copy of the schema that accepts them, so they are dropped while decoding and every other rule holds.
+	if cfg.AllowUnknownPublishFields {
+		registry := api.OpenAPI().Components.Schemas
+		bodySchema := registry.Schema(reflect.TypeOf(apiv0.ServerJSON{}), true, "ServerJSON")
+		op.RequestBody = &huma.RequestBody{
+			Required: true,
+			Content: map[string]*huma.MediaType{
+				"application/json": {Schema: allowUnknownProperties(registry, bodySchema, map[string]*huma.Schema{})},
+			},
+		}
+	}
+
+	huma.Register(api, op, func(ctx context.Context, input *PublishServerInput) (*PermissionResponse[apiv0.ServerResponse], error) {
+
 		// Extract bearer token
 		const bearerPrefix = "Bearer "
 		authHeader := input.Authorization
@@ -49,21 +76,162 @@ func RegisterPublishEndpoint(api huma.API, registry service.RegistryService, cfg
 		}
 
 		// Verify that the token has permission to publish the server
-		if !jwtManager.HasPermission(input.Body.Name, auth.PermissionActionPublish, claims.Permissions) {
+		matchedPermission, ok := jwtManager.MatchPermission(input.Body.Name, auth.PermissionActionPublish, claims.Permissions)
+		if !ok {
 			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(input.Body.Name, claims.Permissions))
 		}
 
+		// The service validates the decoded server JSON, which has already lost properties the schema does not
+		// define, so check the body as sent unless they are allowed
+		if !cfg.AllowUnknownPublishFields {
+			if err := validatePublishDocument(api, input.ContentType, input.RawBody); err != nil {
+				return nil, huma.Error400BadRequest("Failed to publish server", err)
+			}
+		}
+
 		// Publish the server with extensions
-		publishedServer, err := registry.CreateServer(ctx, &input.Body)
+		publishedServer, err := registry.PublishServer(ctx, &input.Body, service.PublishOptions{
+			Unlisted:         input.Unlisted,
+			DryRun:           input.DryRun,
+			RequireNewer:     input.RequireNewer,
+			Actor:            claims.Actor(),
+			PublisherSubject: claims.AuthMethodSubject,
+		})
 		if err != nil {
+			var maxVersionsErr *database.MaxVersionsError
+			if errors.As(err, &maxVersionsErr) {
+				return nil, huma.Error409Conflict("Failed to publish server", &huma.ErrorDetail{
+					Message:  maxVersionsErr.Error(),
+					Location: "body.name",
+					Value: map[string]int{
+						"count": maxVersionsErr.Count,
+						"limit": maxVersionsErr.Limit,
+					},
+				})
+			}
+			var notNewerErr *service.VersionNotNewerError
+			if errors.As(err, &notNewerErr) {
+				return nil, huma.Error409Conflict("Failed to publish server", &huma.ErrorDetail{
+					Message:  notNewerErr.Error(),
+					Location: "body.version",
+					Value: map[string]string{
+						"version": notNewerErr.Version,
+						"latest":  notNewerErr.Latest,
+					},
+				})
+			}
+			if conflict := remoteURLConflict("Failed to publish server", err); conflict != nil {
+				return nil, conflict
+			}
+			if errors.Is(err, validators.ErrValidationTimeout) {
+				return nil, huma.Error504GatewayTimeout("Failed to publish server", err)
+			}
+			if errors.Is(err, validators.ErrValidationQueueTimeout) {
+				return nil, huma.Error503ServiceUnavailable("Failed to publish server", err)
+			}
+			if errors.Is(err, validators.ErrNamespaceNotAllowed) || errors.Is(err, validators.ErrNameBlocked) {
+				return nil, huma.Error403Forbidden("Failed to publish server", err)
+			}
 			return nil, huma.Error400BadRequest("Failed to publish server", err)
 		}
 
 		// Return the published server response with metadata
-		return &Response[apiv0.ServerResponse]{
-			Body: *publishedServer,
+		return &PermissionResponse[apiv0.ServerResponse]{
+			MatchedPermission: matchedPermission.ResourcePattern,
+			Warnings:          publishWarnings(cfg, publishedServer.Server),
+			Body:              *publishedServer,
 		}, nil
 	})
+	// RawBody documents the body as an additional binary content type, but it is only accepted as server JSON
+	delete(api.OpenAPI().Paths[op.Path].Post.RequestBody.Content, "application/octet-stream")
+}
+
+// validatePublishDocument validates the request body as sent against server.schema.json, decoding it with the
+// format of its content type
+func validatePublishDocument(api huma.API, contentType string, body []byte) error {
+	var document any
+	if err := api.Unmarshal(contentType, body, &document); err != nil {
+		return fmt.Errorf("%w: %w", validators.ErrSchemaValidation, err)
+	}
+	data, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("%w: %w", validators.ErrSchemaValidation, err)
+	}
+	return validators.ValidateServerJSONDocument(data)
+}
+
+// allowUnknownProperties returns a copy of schema, with references resolved, in which objects accept properties
+// they do not define. Registered schemas are shared with other operations, so they are copied rather than changed.
+func allowUnknownProperties(registry huma.Registry, schema *huma.Schema, copies map[string]*huma.Schema) *huma.Schema {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		if copied, ok := copies[schema.Ref]; ok {
+			return copied
+		}
+		copied := &huma.Schema{}
+		copies[schema.Ref] = copied
+		*copied = *allowUnknownProperties(registry, registry.SchemaFromRef(schema.Ref), copies)
+		return copied
+	}
+
+	copied := *schema
+	if copied.Type == huma.TypeObject {
+		if additional, ok := copied.AdditionalProperties.(*huma.Schema); ok {
+			copied.AdditionalProperties = allowUnknownProperties(registry, additional, copies)
+		} else {
+			copied.AdditionalProperties = true
+		}
+	}
+	if schema.Properties != nil {
+		copied.Properties = make(map[string]*huma.Schema, len(schema.Properties))
+		for name, property := range schema.Properties {
+			copied.Properties[name] = allowUnknownProperties(registry, property, copies)
+		}
+	}
+	copied.Items = allowUnknownProperties(registry, schema.Items, copies)
+	copied.Not = allowUnknownProperties(registry, schema.Not, copies)
+	for _, list := range []*[]*huma.Schema{&copied.OneOf, &copied.AnyOf, &copied.AllOf} {
+		if *list == nil {
+			continue
+		}
+		schemas := make([]*huma.Schema, len(*list))
+		for i, item := range *list {
+			schemas[i] = allowUnknownProperties(registry, item, copies)
+		}
+		*list = schemas
+	}
+	return &copied
+}
+
+// remoteURLConflict maps a duplicate remote URL error to a 409 naming the server that holds the URL, returning
+// nil for other errors
+func remoteURLConflict(msg string, err error) error {
+	var duplicateErr *database.DuplicateRemoteURLError
+	if !errors.As(err, &duplicateErr) {
+		return nil
+	}
+	return huma.Error409Conflict(msg, &huma.ErrorDetail{
+		Message:  duplicateErr.Error(),
+		Location: "body.remotes",
+		Value: map[string]string{
+			"url":         duplicateErr.URL,
+			"server_name": duplicateErr.ServerName,
+		},
+	})
+}
+
+// publishWarnings returns the enabled advisory warnings for the server
+func publishWarnings(cfg *config.Config, server apiv0.ServerJSON) []string {
+	var warnings []string
+	if cfg.EnableTransportConsistencyCheck {
+		warnings = append(warnings, validators.TransportConsistencyWarnings(server)...)
+	}
+	if cfg.EnableSecretValueCheck {
+		warnings = append(warnings, validators.SecretValueWarnings(server)...)
+	}
+	return warnings
 }
 
 // buildPermissionErrorMessage creates a detailed error message showing what permissions
@@ -84,5 +252,14 @@ func buildPermissionErrorMessage(attemptedResource string, permissions []auth.Pe
 	}
 	errorMsg += ". Attempting to publish: " + attemptedResource
 
+	// Namespaces are case-sensitive, so point out permissions that only differ in case, e.g. a GitHub owner's casing
+	for _, pattern := range permissionStrs {
+		prefix, isPrefix := strings.CutSuffix(pattern, "*")
+		if isPrefix && len(attemptedResource) >= len(prefix) && strings.EqualFold(attemptedResource[:len(prefix)], prefix) {
+			errorMsg += ". Server names are case-sensitive: did you mean " + prefix + attemptedResource[len(prefix):] + "?"
+			break
+		}
+	}
+
 	return errorMsg
 }
@@ -43,6 +43,7 @@ func TestPublishIntegration(t *testing.T) {
 	testConfig := &config.Config{
 		JWTPrivateKey:            hex.EncodeToString(testSeed),
 		EnableRegistryValidation: false, // Disable for integration tests
+		MaxVersionsPerServer:     10000,
 	}
 
 	// Setup fake service
@@ -64,7 +65,8 @@ func TestPublishIntegration(t *testing.T) {
 				Source: "github",
 				ID:     "testuser/test-mcp-server",
 			},
-			Version: "1.0.0",
+			Version:  "1.0.0",
+			Packages: testPackages,
 		}
 
 		// Generate valid JWT token
@@ -108,7 +110,8 @@ func TestPublishIntegration(t *testing.T) {
 				Source: "github",
 				ID:     "example/test-server",
 			},
-			Version: "1.0.0",
+			Version:  "1.0.0",
+			Packages: testPackages,
 		}
 
 		// Generate valid JWT token with wildcard permission
@@ -143,7 +146,8 @@ func TestPublishIntegration(t *testing.T) {
 
 	t.Run("publish fails with missing authorization header", func(t *testing.T) {
 		publishReq := apiv0.ServerJSON{
-			Name: "test-server",
+			Name:    "test-server",
+			Version: "1.0.0",
 		}
 
 		body, err := json.Marshal(publishReq)
@@ -165,6 +169,7 @@ func TestPublishIntegration(t *testing.T) {
 			Name:        "io.github.domdomegg/test-server",
 			Description: "Test server",
 			Version:     "1.0.0",
+			Packages:    testPackages,
 		}
 
 		body, err := json.Marshal(publishReq)
@@ -186,6 +191,7 @@ func TestPublishIntegration(t *testing.T) {
 			Name:        "io.github.other/test-server",
 			Description: "A test server",
 			Version:     "1.0.0",
+			Packages:    testPackages,
 			Repository: model.Repository{
 				URL:    "https://github.com/example/test-server",
 				Source: "github",
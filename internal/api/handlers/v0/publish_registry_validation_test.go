@@ -31,6 +31,7 @@ func TestPublishRegistryValidation(t *testing.T) {
 	testConfig := &config.Config{
 		JWTPrivateKey:            hex.EncodeToString(testSeed),
 		EnableRegistryValidation: true, // Enable validation for this test
+		MaxVersionsPerServer:     10000,
 	}
 
 	// Setup fake service
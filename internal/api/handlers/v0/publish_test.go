@@ -7,8 +7,11 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -24,6 +27,16 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// testPackages is a minimal runnable package, for servers that would otherwise declare no packages or remotes
+var testPackages = []model.Package{
+	{
+		RegistryType: model.RegistryTypeNPM,
+		Identifier:   "test-package",
+		Version:      "1.0.0",
+		Transport:    model.Transport{Type: model.TransportTypeStdio},
+	},
+}
+
 // Helper function to generate a valid JWT token for testing
 func generateTestJWTToken(cfg *config.Config, claims auth.JWTClaims) (string, error) {
 	jwtManager := auth.NewJWTManager(cfg)
@@ -42,6 +55,7 @@ func TestPublishEndpoint(t *testing.T) {
 	testConfig := &config.Config{
 		JWTPrivateKey:            hex.EncodeToString(testSeed),
 		EnableRegistryValidation: false, // Disable for unit tests
+		MaxVersionsPerServer:     10000,
 	}
 
 	testCases := []struct {
@@ -63,7 +77,8 @@ func TestPublishEndpoint(t *testing.T) {
 					Source: "github",
 					ID:     "example/test-server",
 				},
-				Version: "1.0.0",
+				Version:  "1.0.0",
+				Packages: testPackages,
 			},
 			tokenClaims: &auth.JWTClaims{
 				AuthMethod:        auth.MethodGitHubAT,
@@ -87,7 +102,8 @@ func TestPublishEndpoint(t *testing.T) {
 					Source: "github",
 					ID:     "example/test-server",
 				},
-				Version: "1.0.0",
+				Version:  "1.0.0",
+				Packages: testPackages,
 			},
 			tokenClaims: &auth.JWTClaims{
 				AuthMethod: auth.MethodNone,
@@ -116,6 +132,7 @@ func TestPublishEndpoint(t *testing.T) {
 				Name:        "io.github.domdomegg/test-server",
 				Description: "Test server",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			authHeader: "InvalidFormat",
 			setupRegistryService: func(_ service.RegistryService) {
@@ -130,6 +147,7 @@ func TestPublishEndpoint(t *testing.T) {
 				Name:        "test-server",
 				Description: "A test server",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			authHeader: "Bearer invalidToken",
 			setupRegistryService: func(_ service.RegistryService) {
@@ -144,6 +162,7 @@ func TestPublishEndpoint(t *testing.T) {
 				Name:        "io.github.other/test-server",
 				Description: "A test server",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 				Repository: model.Repository{
 					URL:    "https://github.com/example/test-server",
 					Source: "github",
@@ -162,12 +181,33 @@ func TestPublishEndpoint(t *testing.T) {
 			expectedStatus: http.StatusForbidden,
 			expectedError:  "You do not have permission to publish this server",
 		},
+		{
+			name: "permission denied for namespace differing in case from the GitHub owner",
+			requestBody: apiv0.ServerJSON{
+				Name:        "io.github.myorg/test-server",
+				Description: "A test server",
+				Version:     "1.0.0",
+				Packages:    testPackages,
+			},
+			tokenClaims: &auth.JWTClaims{
+				AuthMethod: auth.MethodGitHubAT,
+				Permissions: []auth.Permission{
+					{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.MyOrg/*"},
+				},
+			},
+			setupRegistryService: func(_ service.RegistryService) {
+				// Empty registry - no setup needed
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "did you mean io.github.MyOrg/test-server?",
+		},
 		{
 			name: "registry service error",
 			requestBody: apiv0.ServerJSON{
 				Name:        "example/test-server",
 				Description: "A test server",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 				Repository: model.Repository{
 					URL:    "https://github.com/example/test-server",
 					Source: "github",
@@ -186,6 +226,7 @@ func TestPublishEndpoint(t *testing.T) {
 					Name:        "example/test-server",
 					Description: "Existing test server",
 					Version:     "1.0.0",
+					Packages:    testPackages,
 					Repository: model.Repository{
 						URL:    "https://github.com/example/test-server-existing",
 						Source: "github",
@@ -230,6 +271,7 @@ func TestPublishEndpoint(t *testing.T) {
 				Name:        "com.example/server/path",
 				Description: "Server with multiple slashes in name",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 				Repository: model.Repository{
 					URL:    "https://github.com/example/test-server",
 					Source: "github",
@@ -252,6 +294,7 @@ func TestPublishEndpoint(t *testing.T) {
 				Name:        "org.company/dept/team/project",
 				Description: "Server with three slashes in name",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			tokenClaims: &auth.JWTClaims{
 				AuthMethod: auth.MethodNone,
@@ -269,6 +312,7 @@ func TestPublishEndpoint(t *testing.T) {
 				Name:        "com.example//double-slash",
 				Description: "Server with consecutive slashes",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			tokenClaims: &auth.JWTClaims{
 				AuthMethod: auth.MethodNone,
@@ -286,6 +330,7 @@ func TestPublishEndpoint(t *testing.T) {
 				Name:        "com.example/servers/v1/api",
 				Description: "Server with URL-like path structure",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			tokenClaims: &auth.JWTClaims{
 				AuthMethod: auth.MethodNone,
@@ -303,6 +348,7 @@ func TestPublishEndpoint(t *testing.T) {
 				Name:        "a/b/c/d/e/f",
 				Description: "Server with many slashes",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			tokenClaims: &auth.JWTClaims{
 				AuthMethod: auth.MethodNone,
@@ -416,6 +462,7 @@ func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
 	testConfig := &config.Config{
 		JWTPrivateKey:            hex.EncodeToString(testSeed),
 		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
 	}
 
 	testCases := []struct {
@@ -473,6 +520,7 @@ func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
 				Name:        tc.serverName,
 				Description: "Test server",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			}
 
 			bodyBytes, err := json.Marshal(requestBody)
@@ -509,3 +557,660 @@ func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishEndpoint_MaxVersionsPerServer(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     3,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publish := func(version string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Name:        "com.example/limited-server",
+			Description: "A server with a version limit",
+			Version:     version,
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Publish up to the limit
+	for _, version := range []string{"1.0.0", "1.0.1", "1.0.2"} {
+		rr := publish(version)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	// The next publish should be rejected with a structured limit error
+	rr := publish("1.0.3")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	var errorBody huma.ErrorModel
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorBody))
+	require.Len(t, errorBody.Errors, 1)
+	assert.Contains(t, errorBody.Errors[0].Message, "maximum number of versions for this server reached (3 of 3 allowed)")
+	assert.Equal(t, map[string]any{"count": float64(3), "limit": float64(3)}, errorBody.Errors[0].Value)
+}
+
+func TestPublishEndpoint_DuplicateRemoteURL(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publish := func(name string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Name:        name,
+			Description: "A server with a remote",
+			Version:     "1.0.0",
+			Remotes: []model.Transport{
+				{Type: model.TransportTypeStreamableHTTP, URL: "https://mcp.example.com/mcp"},
+			},
+		})
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := publish("com.example/first-server")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	// A different server claiming the same remote URL conflicts with the first
+	rr = publish("com.example/second-server")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	var errorBody v0.ErrorModel
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorBody))
+	assert.Equal(t, v0.ErrorCodeConflict, errorBody.Code)
+	require.Len(t, errorBody.Errors, 1)
+	assert.Equal(t, "remote URL https://mcp.example.com/mcp is already used by server com.example/first-server", errorBody.Errors[0].Message)
+	assert.Equal(t, "body.remotes", errorBody.Errors[0].Location)
+	assert.Equal(t, map[string]any{"url": "https://mcp.example.com/mcp", "server_name": "com.example/first-server"}, errorBody.Errors[0].Value)
+}
+
+func TestPublishEndpoint_RequireNewer(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publish := func(version, query string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Name:        "com.example/monotonic-server",
+			Description: "A server enforcing monotonic releases",
+			Version:     version,
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish"+query, bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := publish("1.0.0", "?require_newer=true")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	// An older version is rejected when the publisher requires newer versions
+	rr = publish("0.9.0", "?require_newer=true")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	var errorBody v0.ErrorModel
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errorBody))
+	require.Len(t, errorBody.Errors, 1)
+	assert.Equal(t, "version 0.9.0 is not newer than the latest version 1.0.0", errorBody.Errors[0].Message)
+	assert.Equal(t, "body.version", errorBody.Errors[0].Location)
+	assert.Equal(t, map[string]any{"version": "0.9.0", "latest": "1.0.0"}, errorBody.Errors[0].Value)
+
+	// Without the flag, older versions can still be backfilled
+	rr = publish("0.9.0", "")
+	assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+}
+
+func TestPublishEndpoint_ReportsMatchedPermission(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodGitHubAT,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.example/*"},
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.example-org/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	body, err := json.Marshal(apiv0.ServerJSON{
+		Name:        "io.github.example-org/matched-server",
+		Description: "A server published through an org permission",
+		Version:     "1.0.0",
+		Packages:    testPackages,
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Equal(t, "io.github.example-org/*", rr.Header().Get("X-Matched-Permission"))
+}
+
+func TestPublishEndpoint_MaxBodySize(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxPublishBodyBytes:      4096,
+		MaxVersionsPerServer:     10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+	v0.RegisterEditEndpoints(api, registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	send := func(method, target string, server apiv0.ServerJSON) *httptest.ResponseRecorder {
+		body, err := json.Marshal(server)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, target, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	server := apiv0.ServerJSON{
+		Name:        "com.example/body-size-server",
+		Description: "A server with a normal sized body",
+		Version:     "1.0.0",
+		Packages:    testPackages,
+	}
+	oversized := server
+	oversized.Description = strings.Repeat("x", 8192)
+
+	t.Run("normal body succeeds", func(t *testing.T) {
+		rr := send(http.MethodPost, "/v0/publish", server)
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("oversized publish body is rejected", func(t *testing.T) {
+		rr := send(http.MethodPost, "/v0/publish", oversized)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		assert.Contains(t, rr.Body.String(), "request body is too large")
+		assert.Contains(t, rr.Body.String(), "PAYLOAD_TOO_LARGE")
+	})
+
+	t.Run("oversized edit body is rejected", func(t *testing.T) {
+		rr := send(http.MethodPut, "/v0/servers/"+url.PathEscape(server.Name)+"/versions/1.0.0", oversized)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		assert.Contains(t, rr.Body.String(), "request body is too large")
+	})
+}
+
+func TestPublishEndpoint_NamespaceAllowlist(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:             hex.EncodeToString(testSeed),
+		EnableRegistryValidation:  false,
+		PublishNamespaceAllowlist: []string{"io.github.myorg"},
+		MaxVersionsPerServer:      10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publish := func(name string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Name:        name,
+			Description: "A server published during bootstrap",
+			Version:     "1.0.0",
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("allowlisted namespace is accepted", func(t *testing.T) {
+		rr := publish("io.github.myorg/server")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("other namespace is forbidden", func(t *testing.T) {
+		rr := publish("com.other/server")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "namespace 'com.other' may not publish yet")
+	})
+}
+
+func TestPublishEndpoint_BlockedNamePatterns(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		BlockedNamePatterns:      []string{"com.evil/*", "com.example/blocked-server"},
+		MaxVersionsPerServer:     10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publish := func(name string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Name:        name,
+			Description: "A test server",
+			Version:     "1.0.0",
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("blocked namespace is forbidden", func(t *testing.T) {
+		rr := publish("com.evil/server")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "server name is not allowed on this registry")
+		assert.NotContains(t, rr.Body.String(), "com.evil/*")
+	})
+
+	t.Run("blocked name is forbidden", func(t *testing.T) {
+		rr := publish("com.example/blocked-server")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("unrelated name is accepted", func(t *testing.T) {
+		rr := publish("com.example/server")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+}
+
+func TestPublishEndpoint_DeclaredStatus(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:              hex.EncodeToString(testSeed),
+		EnableRegistryValidation:   false,
+		EnforceActivePublishStatus: true,
+		MaxVersionsPerServer:       10000,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publish := func(name string, status model.Status) *httptest.ResponseRecorder {
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Name:        name,
+			Description: "A server declaring its own status",
+			Version:     "1.0.0",
+			Packages:    testPackages,
+			Meta: &apiv0.ServerMeta{
+				Official: &apiv0.RegistryExtensions{Status: status},
+			},
+		})
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("deprecated status is rejected", func(t *testing.T) {
+		rr := publish("com.example/deprecated-server", model.StatusDeprecated)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "servers can only be published as active")
+	})
+
+	t.Run("deleted status is rejected", func(t *testing.T) {
+		rr := publish("com.example/deleted-server", model.StatusDeleted)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "servers can only be published as active")
+	})
+
+	t.Run("active status is accepted and not stored", func(t *testing.T) {
+		rr := publish("com.example/active-server", model.StatusActive)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Nil(t, response.Server.Meta)
+		require.NotNil(t, response.Meta.Official)
+		assert.Equal(t, model.StatusActive, response.Meta.Official.Status)
+	})
+}
+
+func TestPublishEndpoint_UnknownFields(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+
+	const typoBody = `{"name": "com.example/typo-server", "descripton": "Misspelled", "description": "A test server", "version": "1.0.0", "packages": [{"registryType": "npm", "identifier": "@example/typo-server", "version": "1.0.0", "transport": {"type": "stdio"}}]}`
+	const nestedTypoBody = `{"name": "com.example/nested-typo-server", "description": "A test server", "version": "1.0.0", "packages": [{"registryType": "npm", "identifier": "@example/nested-typo-server", "version": "1.0.0", "transport": {"type": "stdio"}, "enviromentVariables": []}]}`
+	longDescriptionBody := `{"name": "com.example/long-server", "descripton": "Misspelled", "description": "` + strings.Repeat("a", 101) + `", "version": "1.0.0", "packages": [{"registryType": "npm", "identifier": "@example/long-server", "version": "1.0.0", "transport": {"type": "stdio"}}]}`
+
+	tests := []struct {
+		name             string
+		allowUnknown     bool
+		body             string
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{"unknown top-level field is rejected", false, typoBody, http.StatusUnprocessableEntity, "body.descripton"},
+		{"misspelled nested field is rejected", false, nestedTypoBody, http.StatusUnprocessableEntity, "body.packages[0].enviromentVariables"},
+		{"unknown fields are dropped when allowed", true, typoBody, http.StatusOK, ""},
+		{"unknown nested fields are dropped when allowed", true, nestedTypoBody, http.StatusOK, ""},
+		{"other body rules still apply when unknown fields are allowed", true, longDescriptionBody, http.StatusUnprocessableEntity, "body.description"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testConfig := &config.Config{
+				JWTPrivateKey:             hex.EncodeToString(testSeed),
+				EnableRegistryValidation:  false,
+				AllowUnknownPublishFields: tt.allowUnknown,
+				MaxVersionsPerServer:      10000,
+			}
+			registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0.RegisterPublishEndpoint(api, registryService, testConfig)
+
+			token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+				AuthMethod: auth.MethodNone,
+				Permissions: []auth.Permission{
+					{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+				},
+			})
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish", bytes.NewBufferString(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code, rr.Body.String())
+			if tt.expectedLocation != "" {
+				assert.Contains(t, rr.Body.String(), `"location":"`+tt.expectedLocation+`"`)
+			} else {
+				assert.NotContains(t, rr.Body.String(), "descripton")
+			}
+		})
+	}
+}
+
+func TestPublishEndpoint_RawBodySchema(t *testing.T) {
+	testConfig := newTestConfig(t)
+	// The body is rejected before the service reaches the database
+	registryService := service.NewRegistryService(nil, testConfig)
+
+	mux := http.NewServeMux()
+	humaConfig := huma.DefaultConfig("Test API", "1.0.0")
+	humaConfig.Formats = maps.Clone(humaConfig.Formats)
+	humaConfig.Formats[v0.YAMLContentType] = v0.YAMLFormat
+	api := humago.New(mux, humaConfig)
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:  auth.MethodNone,
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"JSON", "application/json", `{"name": "com.example/raw-server", "description": "A test server", "version": "1.0.0", "websiteUrl": "not a url"}`},
+		{"YAML", v0.YAMLContentType, "name: com.example/raw-server\ndescription: A test server\nversion: 1.0.0\nwebsiteUrl: not a url\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v0/publish", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+			assert.Contains(t, rr.Body.String(), "server.schema.json")
+		})
+	}
+
+	t.Run("the body is only documented as server JSON", func(t *testing.T) {
+		content := api.OpenAPI().Paths["/v0/publish"].Post.RequestBody.Content
+		assert.Len(t, content, 1)
+		assert.Contains(t, content, "application/json")
+	})
+}
+
+func TestPublishEndpoint_DocumentationFields(t *testing.T) {
+	testConfig := newTestConfig(t)
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+	v0.RegisterServersEndpoints(api, registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publish := func(serverJSON apiv0.ServerJSON) *httptest.ResponseRecorder {
+		body, err := json.Marshal(serverJSON)
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("fields round-trip", func(t *testing.T) {
+		serverJSON := apiv0.ServerJSON{
+			Name:             "com.example/documented-server",
+			Description:      "A documented server",
+			Version:          "1.0.0",
+			DocumentationURL: "https://example.com/docs/README.md",
+			LongDescription:  "# Documented server\n\nSupports **markdown**.",
+			Packages:         testPackages,
+		}
+		rr := publish(serverJSON)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverJSON.Name)+"/versions/1.0.0", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, serverJSON.DocumentationURL, resp.Server.DocumentationURL)
+		assert.Equal(t, serverJSON.LongDescription, resp.Server.LongDescription)
+	})
+
+	t.Run("long description over the limit is rejected", func(t *testing.T) {
+		rr := publish(apiv0.ServerJSON{
+			Name:            "com.example/verbose-server",
+			Description:     "A verbose server",
+			Version:         "1.0.0",
+			LongDescription: strings.Repeat("a", 10001),
+			Packages:        testPackages,
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), "body.longDescription")
+	})
+}
@@ -6,6 +6,14 @@ type Response[T any] struct {
 	Body T
 }
 
+// PermissionResponse is a Response for authenticated writes that also reports which of the caller's
+// token permission patterns granted access, to help debug permission issues
+type PermissionResponse[T any] struct {
+	MatchedPermission string   `header:"X-Matched-Permission" doc:"Resource pattern of the token permission that granted access"`
+	Warnings          []string `header:"X-Registry-Warning" doc:"Advisory warnings about the written server, which was still accepted"`
+	Body              T
+}
+
 // Example usage:
 // Instead of:
 //   type HealthOutput struct {
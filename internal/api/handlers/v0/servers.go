@@ -1,49 +1,125 @@
 package v0
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 const errRecordNotFound = "record not found"
 
 // ListServersInput represents the input for listing servers
 type ListServersInput struct {
-	Cursor       string `query:"cursor" doc:"Pagination cursor" required:"false" example:"server-cursor-123"`
-	Limit        int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
-	UpdatedSince string `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
-	Search       string `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
-	Version      string `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Authorization     string   `header:"Authorization" doc:"Optional Registry JWT token; tokens with read permission on * also list unlisted, pending and deleted servers" required:"false"`
+	Cursor            string   `query:"cursor" doc:"Pagination cursor: the nextCursor or prevCursor of another page" required:"false" example:"server-cursor-123"`
+	Limit             int      `query:"limit" doc:"Number of items per page" minimum:"1" example:"50"`
+	UpdatedSince      string   `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	UpdatedBefore     string   `query:"updated_before" doc:"Filter servers updated before timestamp (RFC3339 datetime)" required:"false" example:"2025-08-08T00:00:00Z"`
+	Status            string   `query:"status" doc:"Filter by version status; deleted and pending versions are only listed for tokens with read permission on *" required:"false" enum:"active,deprecated,deleted,pending" example:"active"`
+	Search            string   `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
+	Version           string   `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Category          string   `query:"category" doc:"Filter servers declaring this category" required:"false" example:"databases"`
+	MaintainerEmail   string   `query:"maintainer_email" doc:"Filter servers listing a maintainer with this email address (case-insensitive)" required:"false" example:"jane@example.com"`
+	OriginRegistry    string   `query:"origin_registry" doc:"Filter by the registry servers were imported from (empty for servers published to this registry)" required:"false" example:"https://registry.modelcontextprotocol.io"`
+	PublishedBy       string   `query:"published_by" doc:"Filter by the subject of the token versions were published with, e.g. a GitHub username" required:"false" example:"octocat"`
+	RegistryBaseURL   string   `query:"registry_base_url" doc:"Filter servers with a package hosted on this package registry; OCI packages without a registry base URL are on Docker Hub (https://docker.io)" required:"false" example:"https://ghcr.io"`
+	PackageIdentifier string   `query:"package_identifier" doc:"Filter servers with a package with this identifier, e.g. an npm package name or OCI image" required:"false" example:"@modelcontextprotocol/server-filesystem"`
+	Verified          string   `query:"verified" doc:"Filter by whether the registry proved ownership of the server's packages" required:"false" enum:"true,false" example:"true"`
+	HasRepository     string   `query:"has_repository" doc:"Filter by whether servers declare a source repository URL" required:"false" enum:"true,false" example:"true"`
+	Sort              string   `query:"sort" doc:"Order results by server name (default), by number of versions ('version_count'), or by most recent publish ('recent')" required:"false" enum:"name,version_count,recent" example:"recent"`
+	Omit              []string `query:"omit" doc:"Comma-separated list of heavy fields to leave out of the response (packages, remotes, _meta)" required:"false" enum:"packages,remotes,_meta" example:"packages,remotes"`
+	Fields            []string `query:"fields" doc:"Comma-separated list of fields to include in the response (name, description, version, repository, websiteUrl, iconUrl, documentationUrl, longDescription, categories, maintainers, packages, remotes, _meta, $schema, official)" required:"false" example:"name,description,version"`
+}
+
+// ListServersByRepositoryInput represents the input for listing servers that share a repository
+type ListServersByRepositoryInput struct {
+	Authorization string `header:"Authorization" doc:"Optional Registry JWT token; tokens with read permission on * also list unlisted, pending and deleted servers" required:"false"`
+	URL           string `query:"url" doc:"Repository URL, matched exactly" required:"true" example:"https://github.com/modelcontextprotocol/servers"`
+	Cursor        string `query:"cursor" doc:"Pagination cursor" required:"false" example:"server-cursor-123"`
+	Limit         int    `query:"limit" doc:"Number of items per page" minimum:"1" example:"50"`
+}
+
+// ListServerChangesInput represents the input for polling the server changes feed
+type ListServerChangesInput struct {
+	Cursor string `query:"cursor" doc:"Cursor returned by the previous poll; omit to start from the beginning" required:"false" example:"server-cursor-123"`
+	Limit  int    `query:"limit" doc:"Number of changes per page" minimum:"1" example:"50"`
 }
 
 // ServerDetailInput represents the input for getting server details
 type ServerDetailInput struct {
-	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	ServerName string   `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Omit       []string `query:"omit" doc:"Comma-separated list of heavy fields to leave out of the response (packages, remotes, _meta)" required:"false" enum:"packages,remotes,_meta" example:"packages,remotes"`
+	Fields     []string `query:"fields" doc:"Comma-separated list of fields to include in the response (name, description, version, repository, websiteUrl, iconUrl, documentationUrl, longDescription, categories, maintainers, packages, remotes, _meta, $schema, official)" required:"false" example:"name,description,version"`
+}
+
+// ServerDetailResponse is the latest version of a server, with headers pointing at its canonical name when it
+// was requested by an alias of an old name
+type ServerDetailResponse struct {
+	Deprecation string `header:"Deprecation" doc:"Set to true when the server was requested by an alias of its name"`
+	Link        string `header:"Link" doc:"Canonical URL of the server when it was requested by an alias of its name"`
+	Body        apiv0.ServerResponse
 }
 
 // ServerVersionDetailInput represents the input for getting a specific version
 type ServerVersionDetailInput struct {
+	ServerName string   `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string   `path:"version" doc:"URL-encoded server version, or 'latest' for the current latest version" example:"1.0.0"`
+	Omit       []string `query:"omit" doc:"Comma-separated list of heavy fields to leave out of the response (packages, remotes, _meta)" required:"false" enum:"packages,remotes,_meta" example:"packages,remotes"`
+	Fields     []string `query:"fields" doc:"Comma-separated list of fields to include in the response (name, description, version, repository, websiteUrl, iconUrl, documentationUrl, longDescription, categories, maintainers, packages, remotes, _meta, $schema, official)" required:"false" example:"name,description,version"`
+}
+
+// ServerLatestForMajorInput represents the input for getting the newest version of a server within a major version
+type ServerLatestForMajorInput struct {
+	ServerName string   `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Major      int      `path:"major" doc:"Semver major version to find the newest version of" minimum:"0" example:"1"`
+	Omit       []string `query:"omit" doc:"Comma-separated list of heavy fields to leave out of the response (packages, remotes, _meta)" required:"false" enum:"packages,remotes,_meta" example:"packages,remotes"`
+	Fields     []string `query:"fields" doc:"Comma-separated list of fields to include in the response (name, description, version, repository, websiteUrl, iconUrl, documentationUrl, longDescription, categories, maintainers, packages, remotes, _meta, $schema, official)" required:"false" example:"name,description,version"`
+}
+
+// ServerVersionPackagesInput represents the input for getting the packages of a specific version
+type ServerVersionPackagesInput struct {
 	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 	Version    string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
 }
 
 // ServerVersionsInput represents the input for listing all versions of a server
 type ServerVersionsInput struct {
-	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	ServerName string   `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Sort       string   `query:"sort" doc:"Order versions newest first by semantic version (default), falling back to publish time for non-semver versions, or by publish time alone ('published')" required:"false" enum:"semver,published" example:"semver"`
+	Omit       []string `query:"omit" doc:"Comma-separated list of heavy fields to leave out of the response (packages, remotes, _meta)" required:"false" enum:"packages,remotes,_meta" example:"packages,remotes"`
+	Fields     []string `query:"fields" doc:"Comma-separated list of fields to include in the response (name, description, version, repository, websiteUrl, iconUrl, documentationUrl, longDescription, categories, maintainers, packages, remotes, _meta, $schema, official)" required:"false" example:"name,description,version"`
+}
+
+// VersionsExistInput represents the input for checking which versions of a server already exist
+type VersionsExistInput struct {
+	ServerName string            `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Body       VersionsExistBody `body:""`
+}
+
+// VersionsExistBody lists the versions to check
+type VersionsExistBody struct {
+	Versions []string `json:"versions" doc:"Versions to check, at most 100 at once" minItems:"1" maxItems:"100" example:"[\"1.0.0\",\"1.1.0\"]"`
 }
 
 // RegisterServersEndpoints registers all server-related endpoints
 //
 //nolint:cyclop // Multiple endpoint registrations are inherently complex
-func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
+func RegisterServersEndpoints(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	defaultLimit, maxLimit := registry.ListLimits()
+
 	// List servers endpoint
 	huma.Register(api, huma.Operation{
 		OperationID: "list-servers",
@@ -53,43 +129,183 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		Description: "Get a paginated list of MCP servers from the registry",
 		Tags:        []string{"servers"},
 	}, func(ctx context.Context, input *ListServersInput) (*Response[apiv0.ServerListResponse], error) {
-		// Build filter from input parameters
-		filter := &database.ServerFilter{}
+		if err := validateFieldSelection(input.Fields); err != nil {
+			return nil, err
+		}
+
+		filter, err := listVisibilityFilter(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		// Build filter from input parameters, recording the normalized values to echo back
+		echo := &apiv0.ListFilter{
+			VersionMode: apiv0.VersionModeAll,
+			Sort:        string(database.SortByName),
+			Limit:       cmp.Or(input.Limit, defaultLimit),
+		}
 
 		// Parse updated_since parameter
 		if input.UpdatedSince != "" {
 			// Parse RFC3339 format
 			if updatedTime, err := time.Parse(time.RFC3339, input.UpdatedSince); err == nil {
 				filter.UpdatedSince = &updatedTime
+				updatedSinceUTC := updatedTime.UTC()
+				echo.UpdatedSince = &updatedSinceUTC
 			} else {
 				return nil, huma.Error400BadRequest("Invalid updated_since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
 			}
 		}
 
+		// Parse updated_before parameter, which bounds updated_since to a time window
+		if input.UpdatedBefore != "" {
+			updatedTime, err := time.Parse(time.RFC3339, input.UpdatedBefore)
+			if err != nil {
+				return nil, huma.Error400BadRequest("Invalid updated_before format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+			}
+			if filter.UpdatedSince != nil && !updatedTime.After(*filter.UpdatedSince) {
+				return nil, huma.Error400BadRequest("updated_before must be after updated_since")
+			}
+			filter.UpdatedBefore = &updatedTime
+			updatedBeforeUTC := updatedTime.UTC()
+			echo.UpdatedBefore = &updatedBeforeUTC
+		}
+
+		// Handle status parameter
+		if input.Status != "" {
+			filter.Status = &input.Status
+			echo.Status = input.Status
+		}
+
 		// Handle search parameter
-		if input.Search != "" {
-			filter.SubstringName = &input.Search
+		if search := strings.TrimSpace(input.Search); search != "" {
+			filter.SubstringName = &search
+			echo.Search = search
 		}
 
 		// Handle version parameter
-		if input.Version != "" {
-			if input.Version == "latest" {
+		if version := strings.TrimSpace(input.Version); version != "" {
+			if version == "latest" {
 				// Special case: filter for latest versions
 				isLatest := true
 				filter.IsLatest = &isLatest
+				echo.VersionMode = apiv0.VersionModeLatest
 			} else {
 				// Future: exact version matching
-				filter.Version = &input.Version
+				filter.Version = &version
+				echo.VersionMode = apiv0.VersionModeExact
+				echo.Version = version
 			}
 		}
 
+		// Handle category parameter
+		if category := strings.TrimSpace(input.Category); category != "" {
+			filter.Category = &category
+			echo.Category = category
+		}
+
+		// Handle maintainer_email parameter
+		if maintainerEmail := strings.TrimSpace(input.MaintainerEmail); maintainerEmail != "" {
+			filter.MaintainerEmail = &maintainerEmail
+			echo.MaintainerEmail = maintainerEmail
+		}
+
+		// Handle origin_registry parameter
+		if originRegistry := strings.TrimSpace(input.OriginRegistry); originRegistry != "" {
+			filter.OriginRegistry = &originRegistry
+			echo.OriginRegistry = originRegistry
+		}
+
+		// Handle published_by parameter
+		if publishedBy := strings.TrimSpace(input.PublishedBy); publishedBy != "" {
+			filter.PublishedBy = &publishedBy
+			echo.PublishedBy = publishedBy
+		}
+
+		// Handle registry_base_url parameter, which matches registry base URLs without a trailing slash
+		if registryBaseURL := strings.TrimRight(strings.TrimSpace(input.RegistryBaseURL), "/"); registryBaseURL != "" {
+			filter.RegistryBaseURL = &registryBaseURL
+			echo.RegistryBaseURL = registryBaseURL
+		}
+
+		// Handle package_identifier parameter
+		if packageIdentifier := strings.TrimSpace(input.PackageIdentifier); packageIdentifier != "" {
+			filter.PackageIdentifier = &packageIdentifier
+			echo.PackageIdentifier = packageIdentifier
+		}
+
+		// Handle verified parameter
+		if input.Verified != "" {
+			verified := input.Verified == "true"
+			filter.Verified = &verified
+			echo.Verified = &verified
+		}
+
+		// Handle has_repository parameter
+		if input.HasRepository != "" {
+			hasRepository := input.HasRepository == "true"
+			filter.HasRepository = &hasRepository
+			echo.HasRepository = &hasRepository
+		}
+
+		// Handle sort parameter
+		if input.Sort != "" {
+			filter.Sort = database.ServerSort(input.Sort)
+			echo.Sort = input.Sort
+		}
+
 		// Get paginated results with filtering
-		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, input.Limit)
+		page, err := registry.ListServersPage(ctx, filter, input.Cursor, input.Limit)
 		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid list parameters", err)
+			}
 			return nil, huma.Error500InternalServerError("Failed to get registry list", err)
 		}
+		servers := page.Servers
 
 		// Convert []*ServerResponse to []ServerResponse
+		serverValues := make([]apiv0.ServerResponse, len(servers))
+		for i, server := range servers {
+			serverValues[i] = *server
+			omitServerFields(&serverValues[i].Server, input.Omit)
+		}
+
+		return &Response[apiv0.ServerListResponse]{
+			Body: apiv0.ServerListResponse{
+				Servers: serverValues,
+				Metadata: apiv0.Metadata{
+					NextCursor: page.NextCursor,
+					PrevCursor: page.PrevCursor,
+					Count:      len(servers),
+					Filter:     echo,
+				},
+			},
+		}, nil
+	})
+	applyListLimits(api, "/v0/servers", defaultLimit, maxLimit)
+
+	// Server changes feed endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-changes",
+		Method:      http.MethodGet,
+		Path:        "/v0/servers/changes",
+		Summary:     "List MCP server changes",
+		Description: "Get server versions ordered by when their last change was committed, oldest first, including unlisted, pending and deleted versions so mirrors can follow changes in visibility. Polling again with the returned cursor yields only newer changes; when there are none, no cursor is returned and clients keep polling with the one they have.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ListServerChangesInput) (*Response[apiv0.ServerListResponse], error) {
+		// Mirrors must see a version becoming unlisted or pending to stop serving it, so the feed does not hide
+		// them like list results do; the official metadata says which it is
+		filter := &database.ServerFilter{Sort: database.SortByChange, IncludeUnlisted: true}
+
+		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid cursor", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server changes", err)
+		}
+
 		serverValues := make([]apiv0.ServerResponse, len(servers))
 		for i, server := range servers {
 			serverValues[i] = *server
@@ -105,6 +321,54 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 			},
 		}, nil
 	})
+	applyListLimits(api, "/v0/servers/changes", defaultLimit, maxLimit)
+
+	// Servers by repository endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "list-servers-by-repository",
+		Method:      http.MethodGet,
+		Path:        "/v0/servers/by-repository",
+		Summary:     "List MCP servers by repository",
+		Description: "Get the latest version of every server whose repository URL matches the given URL. A repository may host several servers.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ListServersByRepositoryInput) (*Response[apiv0.ServerListResponse], error) {
+		repositoryURL := strings.TrimSpace(input.URL)
+		if repositoryURL == "" {
+			return nil, huma.Error400BadRequest("Repository URL is required")
+		}
+
+		filter, err := listVisibilityFilter(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		isLatest := true
+		filter.RepositoryURL = &repositoryURL
+		filter.IsLatest = &isLatest
+
+		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid cursor", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to get servers by repository", err)
+		}
+
+		serverValues := make([]apiv0.ServerResponse, len(servers))
+		for i, server := range servers {
+			serverValues[i] = *server
+		}
+
+		return &Response[apiv0.ServerListResponse]{
+			Body: apiv0.ServerListResponse{
+				Servers: serverValues,
+				Metadata: apiv0.Metadata{
+					NextCursor: nextCursor,
+					Count:      len(servers),
+				},
+			},
+		}, nil
+	})
+	applyListLimits(api, "/v0/servers/by-repository", defaultLimit, maxLimit)
 
 	// Get server details endpoint (latest version)
 	huma.Register(api, huma.Operation{
@@ -114,7 +378,11 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		Summary:     "Get MCP server details",
 		Description: "Get detailed information about the latest version of a specific MCP server.",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ServerDetailInput) (*Response[apiv0.ServerResponse], error) {
+	}, func(ctx context.Context, input *ServerDetailInput) (*ServerDetailResponse, error) {
+		if err := validateFieldSelection(input.Fields); err != nil {
+			return nil, err
+		}
+
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
@@ -125,14 +393,22 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		serverResponse, err := registry.GetServerByName(ctx, serverName)
 		if err != nil {
 			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
+				return nil, huma.Error404NotFound("Server not found", err)
 			}
 			return nil, huma.Error500InternalServerError("Failed to get server details", err)
 		}
 
-		return &Response[apiv0.ServerResponse]{
-			Body: *serverResponse,
-		}, nil
+		// A server found under another name was requested by an alias, so point clients at its canonical name
+		response := &ServerDetailResponse{}
+		if serverResponse.Server.Name != serverName {
+			response.Deprecation = "true"
+			response.Link = "</v0/servers/" + url.PathEscape(serverResponse.Server.Name) + `>; rel="canonical"`
+		}
+
+		omitServerFields(&serverResponse.Server, input.Omit)
+
+		response.Body = *serverResponse
+		return response, nil
 	})
 
 	// Get specific server version endpoint
@@ -141,9 +417,13 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		Method:      http.MethodGet,
 		Path:        "/v0/servers/{serverName}/versions/{version}",
 		Summary:     "Get specific MCP server version",
-		Description: "Get detailed information about a specific version of an MCP server.",
+		Description: "Get detailed information about a specific version of an MCP server, or about its current latest version when the version is 'latest'.",
 		Tags:        []string{"servers"},
 	}, func(ctx context.Context, input *ServerVersionDetailInput) (*Response[apiv0.ServerResponse], error) {
+		if err := validateFieldSelection(input.Fields); err != nil {
+			return nil, err
+		}
+
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
@@ -156,20 +436,102 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 			return nil, huma.Error400BadRequest("Invalid version encoding", err)
 		}
 
-		// Get specific version by server name and version
-		serverResponse, err := registry.GetServerByNameAndVersion(ctx, serverName, version)
+		// Get specific version by server name and version. Servers cannot publish a version named "latest", so it
+		// resolves to the current latest version, giving clients a stable URL for it.
+		var serverResponse *apiv0.ServerResponse
+		if version == "latest" {
+			serverResponse, err = registry.GetServerByName(ctx, serverName)
+		} else {
+			serverResponse, err = registry.GetServerByNameAndVersion(ctx, serverName, version)
+		}
 		if err != nil {
 			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
+				return nil, huma.Error404NotFound("Server not found", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server details", err)
+		}
+
+		omitServerFields(&serverResponse.Server, input.Omit)
+
+		return &Response[apiv0.ServerResponse]{
+			Body: *serverResponse,
+		}, nil
+	})
+
+	// Get latest version within a major version endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-latest-for-major",
+		Method:      http.MethodGet,
+		Path:        "/v0/servers/{serverName}/latest/{major}",
+		Summary:     "Get the latest MCP server version within a major version",
+		Description: "Get the highest semver version of an MCP server whose major version matches, for clients pinned to a major version such as 1.x.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerLatestForMajorInput) (*Response[apiv0.ServerResponse], error) {
+		if err := validateFieldSelection(input.Fields); err != nil {
+			return nil, err
+		}
+
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		serverResponse, err := registry.GetLatestServerVersionForMajor(ctx, serverName, input.Major)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("No version found for this major version", err)
 			}
 			return nil, huma.Error500InternalServerError("Failed to get server details", err)
 		}
 
+		omitServerFields(&serverResponse.Server, input.Omit)
+
 		return &Response[apiv0.ServerResponse]{
 			Body: *serverResponse,
 		}, nil
 	})
 
+	// Get server version packages endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-version-packages",
+		Method:      http.MethodGet,
+		Path:        "/v0/servers/{serverName}/versions/{version}/packages",
+		Summary:     "Get the packages of an MCP server version",
+		Description: "Get only the packages of a specific version of an MCP server, for resolving install instructions.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerVersionPackagesInput) (*Response[apiv0.PackagesResponse], error) {
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		// URL-decode the version
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		serverResponse, err := registry.GetServerByNameAndVersion(ctx, serverName, version)
+		if err != nil {
+			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server packages", err)
+		}
+
+		// Versions without packages, e.g. remote-only servers, list none rather than null
+		packages := serverResponse.Server.Packages
+		if packages == nil {
+			packages = []model.Package{}
+		}
+
+		return &Response[apiv0.PackagesResponse]{
+			Body: apiv0.PackagesResponse{Packages: packages},
+		}, nil
+	})
+
 	// Get server versions endpoint
 	huma.Register(api, huma.Operation{
 		OperationID: "get-server-versions",
@@ -179,6 +541,10 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		Description: "Get all available versions for a specific MCP server",
 		Tags:        []string{"servers"},
 	}, func(ctx context.Context, input *ServerVersionsInput) (*Response[apiv0.ServerListResponse], error) {
+		if err := validateFieldSelection(input.Fields); err != nil {
+			return nil, err
+		}
+
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
@@ -189,15 +555,21 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		servers, err := registry.GetAllVersionsByServerName(ctx, serverName)
 		if err != nil {
 			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
+				return nil, huma.Error404NotFound("Server not found", err)
 			}
 			return nil, huma.Error500InternalServerError("Failed to get server versions", err)
 		}
 
+		// Versions come back most recently published first; order them like latest version selection unless asked not to
+		if input.Sort != "published" {
+			service.SortVersionsNewestFirst(servers)
+		}
+
 		// Convert []*ServerResponse to []ServerResponse
 		serverValues := make([]apiv0.ServerResponse, len(servers))
 		for i, server := range servers {
 			serverValues[i] = *server
+			omitServerFields(&serverValues[i].Server, input.Omit)
 		}
 
 		return &Response[apiv0.ServerListResponse]{
@@ -209,4 +581,80 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 			},
 		}, nil
 	})
+
+	// Check which versions of a server exist endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "check-server-versions-exist",
+		Method:      http.MethodPost,
+		Path:        "/v0/servers/{serverName}/versions/exists",
+		Summary:     "Check which versions of an MCP server exist",
+		Description: "Report, for each requested version, whether it has already been published, so publishing tools can skip existing versions. Unlisted and deleted versions count as existing, as their version numbers can't be published again.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *VersionsExistInput) (*Response[map[string]bool], error) {
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		existing, err := registry.CheckVersionsExist(ctx, serverName, input.Body.Versions)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to check server versions", err)
+		}
+
+		return &Response[map[string]bool]{
+			Body: existing,
+		}, nil
+	})
+}
+
+// omitServerFields clears the requested heavy fields so they are left out of the serialized response
+func omitServerFields(server *apiv0.ServerJSON, omit []string) {
+	for _, field := range omit {
+		switch field {
+		case "packages":
+			server.Packages = nil
+		case "remotes":
+			server.Remotes = nil
+		case "_meta":
+			server.Meta = nil
+		}
+	}
+}
+
+// applyListLimits makes the limit parameter of a registered list endpoint document the configured default and
+// enforce the configured maximum, which static struct tags can't express
+func applyListLimits(api huma.API, path string, defaultLimit, maxLimit int) {
+	for _, param := range api.OpenAPI().Paths[path].Get.Parameters {
+		if param.In != "query" || param.Name != "limit" {
+			continue
+		}
+		maximum := float64(maxLimit)
+		param.Schema.Maximum = &maximum
+		param.Schema.Default = defaultLimit
+		param.Schema.PrecomputeMessages()
+	}
+}
+
+// listVisibilityFilter returns the base filter for a list request: anonymous requests see neither unlisted,
+// pending nor deleted servers, while tokens with read permission on * see every server. Requests sending an
+// invalid token are rejected rather than treated as anonymous.
+func listVisibilityFilter(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*database.ServerFilter, error) {
+	if authHeader == "" {
+		return &database.ServerFilter{ExcludeDeleted: true}, nil
+	}
+
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+	claims, err := jwtManager.ValidateToken(ctx, authHeader[len(bearerPrefix):])
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+
+	if _, ok := jwtManager.MatchGlobalPermission(auth.PermissionActionRead, claims.Permissions); ok {
+		return &database.ServerFilter{IncludeUnlisted: true}, nil
+	}
+	return &database.ServerFilter{ExcludeDeleted: true}, nil
 }
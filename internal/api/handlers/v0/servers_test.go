@@ -1,16 +1,23 @@
 package v0_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
@@ -18,6 +25,7 @@ import (
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestListServersEndpoint(t *testing.T) {
@@ -29,6 +37,7 @@ func TestListServersEndpoint(t *testing.T) {
 		Name:        "com.example/server-alpha",
 		Description: "Alpha test server",
 		Version:     "1.0.0",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
@@ -36,13 +45,14 @@ func TestListServersEndpoint(t *testing.T) {
 		Name:        "com.example/server-beta",
 		Description: "Beta test server",
 		Version:     "2.0.0",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, registryService)
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
 
 	tests := []struct {
 		name           string
@@ -121,13 +131,14 @@ func TestGetServerByNameEndpoint(t *testing.T) {
 		Name:        "com.example/detail-server",
 		Description: "Server for detail testing",
 		Version:     "1.0.0",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, registryService)
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
 
 	tests := []struct {
 		name           string
@@ -172,6 +183,50 @@ func TestGetServerByNameEndpoint(t *testing.T) {
 	}
 }
 
+func TestGetServerByAliasEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Name:        "io.github.new-org/renamed-server",
+		Description: "Server renamed from an old namespace",
+		Version:     "1.0.0",
+		Packages:    testPackages,
+	})
+	require.NoError(t, err)
+	_, err = registryService.CreateAlias(ctx, "io.github.old-org/renamed-server", "io.github.new-org/renamed-server")
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	get := func(serverName string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName), nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("alias returns the target with a canonical link", func(t *testing.T) {
+		w := get("io.github.old-org/renamed-server")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "true", w.Header().Get("Deprecation"))
+		assert.Equal(t, `</v0/servers/io.github.new-org%2Frenamed-server>; rel="canonical"`, w.Header().Get("Link"))
+
+		var resp apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "io.github.new-org/renamed-server", resp.Server.Name)
+	})
+
+	t.Run("canonical name has no alias headers", func(t *testing.T) {
+		w := get("io.github.new-org/renamed-server")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Empty(t, w.Header().Get("Deprecation"))
+		assert.Empty(t, w.Header().Get("Link"))
+	})
+}
+
 func TestGetServerVersionEndpoint(t *testing.T) {
 	ctx := context.Background()
 	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
@@ -183,6 +238,7 @@ func TestGetServerVersionEndpoint(t *testing.T) {
 		Name:        serverName,
 		Description: "Version test server v1",
 		Version:     "1.0.0",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
@@ -190,6 +246,7 @@ func TestGetServerVersionEndpoint(t *testing.T) {
 		Name:        serverName,
 		Description: "Version test server v2",
 		Version:     "2.0.0",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
@@ -198,13 +255,14 @@ func TestGetServerVersionEndpoint(t *testing.T) {
 		Name:        serverName,
 		Description: "Version test server with build metadata",
 		Version:     "1.0.0+20130313144700",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, registryService)
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
 
 	tests := []struct {
 		name           string
@@ -294,6 +352,75 @@ func TestGetServerVersionEndpoint(t *testing.T) {
 	}
 }
 
+func TestGetServerVersionPackagesEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	serverName := "com.example/packages-server"
+	packages := []model.Package{
+		testPackages[0],
+		{
+			RegistryType: model.RegistryTypeOCI,
+			Identifier:   "example/packages-server",
+			Version:      "1.0.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+		},
+	}
+
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Name:        serverName,
+		Description: "Server with packages",
+		Version:     "1.0.0",
+		Packages:    packages,
+	})
+	require.NoError(t, err)
+
+	_, err = registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Name:        serverName,
+		Description: "Remote-only server",
+		Version:     "2.0.0",
+		Remotes: []model.Transport{
+			{Type: "streamable-http", URL: "https://packages.example.com/mcp"},
+		},
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	get := func(version string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+"/versions/"+url.PathEscape(version)+"/packages", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("version with multiple packages", func(t *testing.T) {
+		w := get("1.0.0")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.PackagesResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, packages, resp.Packages)
+	})
+
+	t.Run("version without packages", func(t *testing.T) {
+		w := get("2.0.0")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp map[string]json.RawMessage
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.JSONEq(t, `[]`, string(resp["packages"]))
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		w := get("3.0.0")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Server not found")
+	})
+}
+
 func TestGetAllVersionsEndpoint(t *testing.T) {
 	ctx := context.Background()
 	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
@@ -307,6 +434,7 @@ func TestGetAllVersionsEndpoint(t *testing.T) {
 			Name:        serverName,
 			Description: "Multi-version test server " + version,
 			Version:     version,
+			Packages:    testPackages,
 		})
 		require.NoError(t, err)
 	}
@@ -314,7 +442,7 @@ func TestGetAllVersionsEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, registryService)
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
 
 	tests := []struct {
 		name           string
@@ -385,6 +513,151 @@ func TestGetAllVersionsEndpoint(t *testing.T) {
 	}
 }
 
+func TestGetLatestVersionAliasEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	serverName := "com.example/latest-alias-server"
+	for _, version := range []string{"1.0.0", "2.0.0", "1.5.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Name:        serverName,
+			Description: "Latest alias test server " + version,
+			Version:     version,
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	latest, err := registryService.GetServerByName(ctx, serverName)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+"/versions/latest", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp apiv0.ServerResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "2.0.0", resp.Server.Version)
+	assert.Equal(t, latest.Server, resp.Server)
+	assert.True(t, resp.Meta.Official.IsLatest)
+
+	req = httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape("com.example/unknown-server")+"/versions/latest", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetLatestForMajorEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	serverName := "com.example/major-version-server"
+	// Publish out of order, so the newest within a major is not simply the most recently published
+	for _, version := range []string{"1.0.0", "1.10.0", "2.1.0", "1.2.0", "2.0.0", "1.11.0-beta.1"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Name:        serverName,
+			Description: "Major version test server " + version,
+			Version:     version,
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	tests := []struct {
+		name            string
+		serverName      string
+		major           string
+		expectedStatus  int
+		expectedVersion string
+	}{
+		{"latest within major 1", serverName, "1", http.StatusOK, "1.11.0-beta.1"},
+		{"latest within major 2", serverName, "2", http.StatusOK, "2.1.0"},
+		{"absent major", serverName, "3", http.StatusNotFound, ""},
+		{"unknown server", "com.example/unknown-server", "1", http.StatusNotFound, ""},
+		{"non-numeric major", serverName, "one", http.StatusUnprocessableEntity, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(tt.serverName)+"/latest/"+tt.major, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus == http.StatusOK {
+				var resp apiv0.ServerResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, tt.expectedVersion, resp.Server.Version)
+			}
+		})
+	}
+}
+
+func TestCheckVersionsExistEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	serverName := "com.example/versions-exist-server"
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Name:        serverName,
+			Description: "Versions exist test server " + version,
+			Version:     version,
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("0.0.%d", i)
+	}
+
+	tests := []struct {
+		name           string
+		serverName     string
+		versions       []string
+		expectedStatus int
+		expected       map[string]bool
+	}{
+		{"mix of existing and missing versions", serverName, []string{"1.0.0", "1.2.0", "1.1.0"}, http.StatusOK, map[string]bool{"1.0.0": true, "1.2.0": false, "1.1.0": true}},
+		{"unknown server", "com.example/unknown-server", []string{"1.0.0"}, http.StatusOK, map[string]bool{"1.0.0": false}},
+		{"no versions", serverName, []string{}, http.StatusUnprocessableEntity, nil},
+		{"too many versions", serverName, tooMany, http.StatusUnprocessableEntity, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(v0.VersionsExistBody{Versions: tt.versions})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/v0/servers/"+url.PathEscape(tt.serverName)+"/versions/exists", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus == http.StatusOK {
+				var resp map[string]bool
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, tt.expected, resp)
+			}
+		})
+	}
+}
+
 func TestServersEndpointEdgeCases(t *testing.T) {
 	ctx := context.Background()
 	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
@@ -405,6 +678,7 @@ func TestServersEndpointEdgeCases(t *testing.T) {
 			Name:        server.name,
 			Description: server.description,
 			Version:     server.version,
+			Packages:    testPackages,
 		})
 		require.NoError(t, err)
 	}
@@ -412,7 +686,7 @@ func TestServersEndpointEdgeCases(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, registryService)
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
 
 	t.Run("URL encoding edge cases", func(t *testing.T) {
 		tests := []struct {
@@ -455,6 +729,10 @@ func TestServersEndpointEdgeCases(t *testing.T) {
 			{"invalid updated_since format", "?updated_since=invalid", http.StatusBadRequest, "Invalid updated_since format"},
 			{"future updated_since", "?updated_since=2030-01-01T00:00:00Z", http.StatusOK, ""},
 			{"very old updated_since", "?updated_since=1990-01-01T00:00:00Z", http.StatusOK, ""},
+			{"invalid updated_before format", "?updated_before=invalid", http.StatusBadRequest, "Invalid updated_before format"},
+			{"updated_before not after updated_since", "?updated_since=2025-01-02T00:00:00Z&updated_before=2025-01-01T00:00:00Z", http.StatusBadRequest, "updated_before must be after updated_since"},
+			{"invalid status", "?status=archived", http.StatusUnprocessableEntity, "validation failed"},
+			{"combined time window, status and latest", "?updated_since=2025-01-01T00:00:00Z&updated_before=2030-01-01T00:00:00Z&status=active&version=latest", http.StatusOK, ""},
 			{"empty search parameter", "?search=", http.StatusOK, ""},
 			{"search with special characters", "?search=测试", http.StatusOK, ""},
 			{"combined valid parameters", "?search=server&limit=5&version=latest", http.StatusOK, ""},
@@ -509,4 +787,1251 @@ func TestServersEndpointEdgeCases(t *testing.T) {
 			assert.Contains(t, []model.Status{model.StatusActive, model.StatusDeprecated, model.StatusDeleted}, server.Meta.Official.Status)
 		}
 	})
+
+	t.Run("echoes normalized filter", func(t *testing.T) {
+		updatedSince := time.Date(2025, 8, 7, 11, 15, 4, 0, time.UTC)
+		tests := []struct {
+			name        string
+			queryParams string
+			expected    apiv0.ListFilter
+		}{
+			{"defaults", "", apiv0.ListFilter{VersionMode: apiv0.VersionModeAll, Sort: "name", Limit: 30}},
+			{"explicit limit", "?limit=5", apiv0.ListFilter{VersionMode: apiv0.VersionModeAll, Sort: "name", Limit: 5}},
+			{"trimmed search", "?search=%20dots%20", apiv0.ListFilter{Search: "dots", VersionMode: apiv0.VersionModeAll, Sort: "name", Limit: 30}},
+			{"latest versions", "?version=latest", apiv0.ListFilter{VersionMode: apiv0.VersionModeLatest, Sort: "name", Limit: 30}},
+			{"exact version", "?version=1.0.0", apiv0.ListFilter{VersionMode: apiv0.VersionModeExact, Version: "1.0.0", Sort: "name", Limit: 30}},
+			{"origin registry", "?origin_registry=https://mirror.example.com", apiv0.ListFilter{OriginRegistry: "https://mirror.example.com", VersionMode: apiv0.VersionModeAll, Sort: "name", Limit: 30}},
+			{"published by", "?published_by=octocat", apiv0.ListFilter{PublishedBy: "octocat", VersionMode: apiv0.VersionModeAll, Sort: "name", Limit: 30}},
+			{"updated_since normalized to UTC", "?updated_since=2025-08-07T13:15:04%2B02:00", apiv0.ListFilter{VersionMode: apiv0.VersionModeAll, UpdatedSince: &updatedSince, Sort: "name", Limit: 30}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, "/v0/servers"+tt.queryParams, nil)
+				w := httptest.NewRecorder()
+
+				mux.ServeHTTP(w, req)
+
+				require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+				var resp apiv0.ServerListResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				require.NotNil(t, resp.Metadata.Filter)
+				assert.Equal(t, tt.expected, *resp.Metadata.Filter)
+			})
+		}
+	})
+}
+
+func TestListServerChangesEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	// poll drains the feed from the given cursor and returns every change seen plus the cursor to resume from
+	poll := func(t *testing.T, cursor string) ([]string, string) {
+		t.Helper()
+		var seen []string
+		for {
+			query := url.Values{"limit": {"2"}}
+			if cursor != "" {
+				query.Set("cursor", cursor)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers/changes?"+query.Encode(), nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			if len(resp.Servers) == 0 {
+				require.Empty(t, resp.Metadata.NextCursor, "empty pages should not return a cursor")
+				return seen, cursor
+			}
+			require.NotEmpty(t, resp.Metadata.NextCursor, "pages with changes should return a cursor to resume from")
+			for _, server := range resp.Servers {
+				seen = append(seen, server.Server.Name+"@"+server.Server.Version)
+			}
+			cursor = resp.Metadata.NextCursor
+		}
+	}
+
+	// Publish several versions, many of which will share a timestamp
+	published := []string{}
+	for _, name := range []string{"com.example/changes-a", "com.example/changes-b", "com.example/changes-c"} {
+		for _, version := range []string{"1.0.0", "1.1.0"} {
+			_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+				Name:        name,
+				Description: "Changes feed test server",
+				Version:     version,
+				Packages:    testPackages,
+			})
+			require.NoError(t, err)
+			published = append(published, name+"@"+version)
+		}
+	}
+
+	seen, cursor := poll(t, "")
+	assert.ElementsMatch(t, published, seen, "every published version should be seen exactly once")
+
+	// Polling again without further changes yields nothing, so the client keeps its cursor
+	again, sameCursor := poll(t, cursor)
+	assert.Empty(t, again)
+	assert.Equal(t, cursor, sameCursor)
+
+	// Updating a version moves it to the end of the feed
+	_, err := registryService.UpdateServer(ctx, "com.example/changes-b", "1.0.0", &apiv0.ServerJSON{
+		Name:        "com.example/changes-b",
+		Description: "Updated description",
+		Version:     "1.0.0",
+		Packages:    testPackages,
+	}, nil)
+	require.NoError(t, err)
+
+	seen, cursor = poll(t, cursor)
+	assert.Equal(t, []string{"com.example/changes-b@1.0.0"}, seen)
+
+	// Unlisting a version is a change too, marked in the official metadata so mirrors can stop serving it
+	unlisted := true
+	_, err = registryService.EditServer(ctx, "com.example/changes-c", "1.0.0", &apiv0.ServerJSON{
+		Name:        "com.example/changes-c",
+		Description: "Changes feed test server",
+		Version:     "1.0.0",
+		Packages:    testPackages,
+	}, service.UpdateOptions{Unlisted: &unlisted})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/servers/changes?cursor="+url.QueryEscape(cursor), nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp apiv0.ServerListResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Servers, 1)
+	assert.Equal(t, "com.example/changes-c", resp.Servers[0].Server.Name)
+	assert.True(t, resp.Servers[0].Meta.Official.Unlisted)
+
+	t.Run("malformed cursor", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/changes?cursor=not-a-cursor", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestServersEndpointOmitFields(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Name:        "com.example/omit-server",
+		Description: "Server for omit testing",
+		Version:     "1.0.0",
+		WebsiteURL:  "https://example.com/omit-server",
+		Packages: []model.Package{
+			{
+				RegistryType: "npm",
+				Identifier:   "omit-server",
+				Version:      "1.0.0",
+				Transport:    model.Transport{Type: "stdio"},
+			},
+		},
+		Remotes: []model.Transport{
+			{Type: "streamable-http", URL: "https://omit.example.com/mcp"},
+		},
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	encodedName := url.PathEscape("com.example/omit-server")
+
+	tests := []struct {
+		name            string
+		path            string
+		expectPackages  bool
+		expectRemotes   bool
+		isList          bool
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:           "list without omit keeps all fields",
+			path:           "/v0/servers",
+			expectPackages: true,
+			expectRemotes:  true,
+			isList:         true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "list omitting packages and remotes",
+			path:           "/v0/servers?omit=packages,remotes",
+			isList:         true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "detail omitting remotes",
+			path:           "/v0/servers/" + encodedName + "?omit=remotes",
+			expectPackages: true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "versions omitting packages",
+			path:           "/v0/servers/" + encodedName + "/versions?omit=packages",
+			expectRemotes:  true,
+			isList:         true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "unknown field is rejected",
+			path:            "/v0/servers?omit=name",
+			expectedStatus:  http.StatusUnprocessableEntity,
+			expectedMessage: "validation failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				assert.Contains(t, w.Body.String(), tt.expectedMessage)
+				return
+			}
+
+			var servers []map[string]any
+			if tt.isList {
+				var resp struct {
+					Servers []map[string]any `json:"servers"`
+				}
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				servers = resp.Servers
+			} else {
+				var resp map[string]any
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				servers = []map[string]any{resp}
+			}
+			require.Len(t, servers, 1)
+
+			server, ok := servers[0]["server"].(map[string]any)
+			require.True(t, ok)
+			_, hasPackages := server["packages"]
+			_, hasRemotes := server["remotes"]
+			assert.Equal(t, tt.expectPackages, hasPackages)
+			assert.Equal(t, tt.expectRemotes, hasRemotes)
+
+			// Fields that were not omitted remain untouched
+			assert.Equal(t, "com.example/omit-server", server["name"])
+			assert.Equal(t, "https://example.com/omit-server", server["websiteUrl"])
+			assert.NotNil(t, servers[0]["_meta"])
+		})
+	}
+}
+
+func TestServersEndpointCategoryFilter(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	for _, server := range []apiv0.ServerJSON{
+		{Name: "com.example/postgres-server", Description: "Postgres", Version: "1.0.0", Categories: []string{"databases"}, Packages: testPackages},
+		{Name: "com.example/files-server", Description: "Files", Version: "1.0.0", Categories: []string{"filesystem", "databases"}, Packages: testPackages},
+		{Name: "com.example/weather-server", Description: "Weather", Version: "1.0.0", Categories: []string{"weather"}, Packages: testPackages},
+		{Name: "com.example/plain-server", Description: "No categories", Version: "1.0.0", Packages: testPackages},
+	} {
+		_, err := registryService.CreateServer(ctx, &server)
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	tests := []struct {
+		category string
+		expected []string
+	}{
+		{"databases", []string{"com.example/files-server", "com.example/postgres-server"}},
+		{"weather", []string{"com.example/weather-server"}},
+		{"unknown", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers?category="+tt.category, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			names := []string{}
+			for _, server := range resp.Servers {
+				names = append(names, server.Server.Name)
+				assert.Contains(t, server.Server.Categories, tt.category)
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Equal(t, tt.category, resp.Metadata.Filter.Category)
+		})
+	}
+}
+
+func TestServersEndpointMaintainerEmailFilter(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	jane := model.Maintainer{Name: "Jane Doe", Email: "jane@example.com"}
+	for _, server := range []apiv0.ServerJSON{
+		{Name: "com.example/jane-server", Description: "Jane's", Version: "1.0.0", Maintainers: []model.Maintainer{jane}, Packages: testPackages},
+		{Name: "com.example/shared-server", Description: "Shared", Version: "1.0.0", Maintainers: []model.Maintainer{{Name: "Sam", URL: "https://example.com/sam"}, jane}, Packages: testPackages},
+		{Name: "com.example/sam-server", Description: "Sam's", Version: "1.0.0", Maintainers: []model.Maintainer{{Name: "Sam", Email: "sam@example.com"}}, Packages: testPackages},
+		{Name: "com.example/plain-server", Description: "No maintainers", Version: "1.0.0", Packages: testPackages},
+	} {
+		_, err := registryService.CreateServer(ctx, &server)
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	tests := []struct {
+		email    string
+		expected []string
+	}{
+		{"jane@example.com", []string{"com.example/jane-server", "com.example/shared-server"}},
+		{"Jane@Example.com", []string{"com.example/jane-server", "com.example/shared-server"}},
+		{"sam@example.com", []string{"com.example/sam-server"}},
+		{"nobody@example.com", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers?maintainer_email="+url.QueryEscape(tt.email), nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			names := []string{}
+			for _, server := range resp.Servers {
+				names = append(names, server.Server.Name)
+				assert.NotEmpty(t, server.Server.Maintainers)
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Equal(t, tt.email, resp.Metadata.Filter.MaintainerEmail)
+		})
+	}
+}
+
+func TestServersEndpointVerifiedFilter(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	// Remote-only servers pass ownership validation without reaching an external package registry
+	validatingService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: true, MaxVersionsPerServer: 10000})
+	_, err := validatingService.CreateServer(ctx, &apiv0.ServerJSON{
+		Name:        "com.example/verified-server",
+		Description: "Verified",
+		Version:     "1.0.0",
+		Remotes:     []model.Transport{{Type: "streamable-http", URL: "https://verified.example.com/mcp"}},
+	})
+	require.NoError(t, err)
+	_, err = registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Name:        "com.example/unverified-server",
+		Description: "Unverified",
+		Version:     "1.0.0",
+		Packages:    testPackages,
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"", []string{"com.example/unverified-server", "com.example/verified-server"}},
+		{"?verified=true", []string{"com.example/verified-server"}},
+		{"?verified=false", []string{"com.example/unverified-server"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers"+tt.query, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			names := []string{}
+			for _, server := range resp.Servers {
+				names = append(names, server.Server.Name)
+				assert.Equal(t, server.Server.Name == "com.example/verified-server", server.Meta.Official.Verified)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/servers?verified=maybe", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestServersEndpointHasRepositoryFilter(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	for _, server := range []apiv0.ServerJSON{
+		{
+			Name:        "com.example/with-repository",
+			Description: "With repository",
+			Version:     "1.0.0",
+			Repository:  model.Repository{URL: "https://github.com/example/with-repository", Source: "github"},
+			Packages:    testPackages,
+		},
+		{
+			Name:        "com.example/without-repository",
+			Description: "Without repository",
+			Version:     "1.0.0",
+			Packages:    testPackages,
+		},
+	} {
+		_, err := registryService.CreateServer(ctx, &server)
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"", []string{"com.example/with-repository", "com.example/without-repository"}},
+		{"?has_repository=true", []string{"com.example/with-repository"}},
+		{"?has_repository=false", []string{"com.example/without-repository"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers"+tt.query, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			names := []string{}
+			for _, server := range resp.Servers {
+				names = append(names, server.Server.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/servers?has_repository=maybe", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestServersEndpointPublishedByFilter(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, testConfig)
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+
+	publish := func(subject, name string) {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: subject,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+			},
+		})
+		require.NoError(t, err)
+
+		body, err := json.Marshal(apiv0.ServerJSON{Name: name, Description: "Published by " + subject, Version: "1.0.0", Packages: testPackages})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+	publish("alice", "io.github.alice/first-server")
+	publish("alice", "io.github.alice/second-server")
+	publish("bob", "io.github.bob/server")
+
+	// Servers created without a publisher token have no subject
+	_, err = registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Name: "com.example/imported-server", Description: "Imported", Version: "1.0.0", Packages: testPackages,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		publishedBy string
+		expected    []string
+	}{
+		{"alice", []string{"io.github.alice/first-server", "io.github.alice/second-server"}},
+		{"bob", []string{"io.github.bob/server"}},
+		{"carol", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.publishedBy, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers?published_by="+tt.publishedBy, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			names := []string{}
+			for _, server := range resp.Servers {
+				names = append(names, server.Server.Name)
+				assert.Equal(t, tt.publishedBy, server.Meta.Official.PublisherSubject)
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Equal(t, tt.publishedBy, resp.Metadata.Filter.PublishedBy)
+		})
+	}
+}
+
+func TestServersEndpointRegistryBaseURLFilter(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	ociPackage := func(registryBaseURL string) model.Package {
+		return model.Package{
+			RegistryType:    model.RegistryTypeOCI,
+			RegistryBaseURL: registryBaseURL,
+			Identifier:      "example/server",
+			Version:         "1.0.0",
+			Transport:       model.Transport{Type: model.TransportTypeStdio},
+		}
+	}
+	servers := map[string][]model.Package{
+		"com.example/ghcr-server":     {ociPackage(model.RegistryURLGHCR)},
+		"com.example/dockerhub":       {ociPackage(model.RegistryURLDocker)},
+		"com.example/implicit-docker": {ociPackage("")},
+		"com.example/npm-server":      testPackages,
+		"com.example/mixed-server":    {testPackages[0], ociPackage(model.RegistryURLGHCR)},
+	}
+	for name, packages := range servers {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Name: name, Description: "Registry base URL test", Version: "1.0.0", Packages: packages,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	tests := []struct {
+		name            string
+		registryBaseURL string
+		expected        []string
+		expectedEcho    string
+	}{
+		{
+			name:            "ghcr",
+			registryBaseURL: "https://ghcr.io",
+			expected:        []string{"com.example/ghcr-server", "com.example/mixed-server"},
+			expectedEcho:    "https://ghcr.io",
+		},
+		{
+			name:            "docker hub includes OCI packages without a registry base URL",
+			registryBaseURL: "https://docker.io",
+			expected:        []string{"com.example/dockerhub", "com.example/implicit-docker"},
+			expectedEcho:    "https://docker.io",
+		},
+		{
+			name:            "trailing slash is ignored",
+			registryBaseURL: "https://ghcr.io/",
+			expected:        []string{"com.example/ghcr-server", "com.example/mixed-server"},
+			expectedEcho:    "https://ghcr.io",
+		},
+		{
+			name:            "unknown registry",
+			registryBaseURL: "https://registry.example.com",
+			expected:        []string{},
+			expectedEcho:    "https://registry.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers?registry_base_url="+url.QueryEscape(tt.registryBaseURL), nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			names := []string{}
+			for _, server := range resp.Servers {
+				names = append(names, server.Server.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Equal(t, tt.expectedEcho, resp.Metadata.Filter.RegistryBaseURL)
+		})
+	}
+}
+
+func TestServersEndpointPackageIdentifierFilter(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	for name, identifier := range map[string]string{
+		"com.example/filesystem-server": "@example/filesystem",
+		"com.example/weather-server":    "@example/weather",
+	} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Name: name, Description: "Package identifier test", Version: "1.0.0",
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   identifier,
+				Version:      "1.0.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			}},
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	tests := []struct {
+		identifier string
+		expected   []string
+	}{
+		{"@example/filesystem", []string{"com.example/filesystem-server"}},
+		{"@example/file", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.identifier, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers?package_identifier="+url.QueryEscape(tt.identifier), nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			names := []string{}
+			for _, server := range resp.Servers {
+				names = append(names, server.Server.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Equal(t, tt.identifier, resp.Metadata.Filter.PackageIdentifier)
+		})
+	}
+}
+
+func TestServersByRepositoryEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	monorepo := model.Repository{URL: "https://github.com/example/mcp-servers", Source: "github"}
+	for _, server := range []apiv0.ServerJSON{
+		{Name: "io.github.example/alpha", Description: "Alpha", Version: "1.0.0", Repository: monorepo, Packages: testPackages},
+		{Name: "io.github.example/alpha", Description: "Alpha", Version: "1.1.0", Repository: monorepo, Packages: testPackages},
+		{Name: "io.github.example/beta", Description: "Beta", Version: "1.0.0", Repository: monorepo, Packages: testPackages},
+		{Name: "io.github.example/gamma", Description: "Gamma", Version: "1.0.0", Repository: model.Repository{URL: "https://github.com/example/gamma", Source: "github"}, Packages: testPackages},
+	} {
+		_, err := registryService.CreateServer(ctx, &server)
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	t.Run("returns the latest version of each server in the repository", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/by-repository?url="+url.QueryEscape(monorepo.URL), nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		versions := map[string]string{}
+		for _, server := range resp.Servers {
+			versions[server.Server.Name] = server.Server.Version
+		}
+		assert.Equal(t, map[string]string{"io.github.example/alpha": "1.1.0", "io.github.example/beta": "1.0.0"}, versions)
+	})
+
+	t.Run("unknown repository returns no servers", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/by-repository?url="+url.QueryEscape("https://github.com/example/unknown"), nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Empty(t, resp.Servers)
+	})
+
+	t.Run("missing url is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/by-repository", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+func TestServersEndpointFieldSelection(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Name:        "com.example/fields-server",
+		Description: "Server for field selection testing",
+		Version:     "1.0.0",
+		Remotes: []model.Transport{
+			{Type: "streamable-http", URL: "https://fields.example.com/mcp"},
+		},
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	humaConfig := huma.DefaultConfig("Test API", "1.0.0")
+	humaConfig.Transformers = append(humaConfig.Transformers, v0.SelectServerFields)
+	api := humago.New(mux, humaConfig)
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	encodedName := url.PathEscape("com.example/fields-server")
+
+	tests := []struct {
+		name           string
+		path           string
+		isList         bool
+		expectedStatus int
+		expectedKeys   []string
+		expectOfficial bool
+	}{
+		{
+			name:           "list without fields returns full servers",
+			path:           "/v0/servers",
+			isList:         true,
+			expectedStatus: http.StatusOK,
+			expectedKeys:   []string{"name", "description", "version", "remotes"},
+			expectOfficial: true,
+		},
+		{
+			name:           "list with selected fields",
+			path:           "/v0/servers?fields=name,version",
+			isList:         true,
+			expectedStatus: http.StatusOK,
+			expectedKeys:   []string{"name", "version"},
+		},
+		{
+			name:           "detail including official metadata",
+			path:           "/v0/servers/" + encodedName + "?fields=name,official",
+			expectedStatus: http.StatusOK,
+			expectedKeys:   []string{"name"},
+			expectOfficial: true,
+		},
+		{
+			name:           "version detail with selected fields",
+			path:           "/v0/servers/" + encodedName + "/versions/1.0.0?fields=description",
+			expectedStatus: http.StatusOK,
+			expectedKeys:   []string{"description"},
+		},
+		{
+			name:           "latest within a major version with selected fields",
+			path:           "/v0/servers/" + encodedName + "/latest/1?fields=name,version",
+			expectedStatus: http.StatusOK,
+			expectedKeys:   []string{"name", "version"},
+		},
+		{
+			name:           "unknown field is rejected",
+			path:           "/v0/servers?fields=name,secret",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				assert.Contains(t, w.Body.String(), "Unknown field 'secret'")
+				return
+			}
+
+			var entry map[string]json.RawMessage
+			if tt.isList {
+				var resp struct {
+					Servers []map[string]json.RawMessage `json:"servers"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Len(t, resp.Servers, 1)
+				entry = resp.Servers[0]
+			} else {
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entry))
+			}
+
+			var server map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(entry["server"], &server))
+			keys := make([]string, 0, len(server))
+			for key := range server {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, tt.expectedKeys, keys)
+
+			_, hasMeta := entry["_meta"]
+			assert.Equal(t, tt.expectOfficial, hasMeta)
+			if tt.expectOfficial {
+				assert.Contains(t, string(entry["_meta"]), "io.modelcontextprotocol.registry/official")
+			}
+		})
+	}
+}
+
+func TestUnlistedServers(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		MaxVersionsPerServer:     10000,
+	}
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, cfg)
+	v0.RegisterPublishEndpoint(api, registryService, cfg)
+	v0.RegisterEditEndpoints(api, registryService, cfg)
+
+	token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	send := func(method, target string, body any) *httptest.ResponseRecorder {
+		var reader *bytes.Reader
+		if body != nil {
+			bodyBytes, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(bodyBytes)
+		} else {
+			reader = bytes.NewReader(nil)
+		}
+		req := httptest.NewRequest(method, target, reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	listNames := func(query string) []string {
+		w := send(http.MethodGet, "/v0/servers"+query, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		names := make([]string, 0, len(resp.Servers))
+		for _, server := range resp.Servers {
+			names = append(names, server.Server.Name)
+		}
+		return names
+	}
+
+	listed := apiv0.ServerJSON{Name: "com.example/listed-server", Description: "Listed server", Version: "1.0.0", Packages: testPackages}
+	unlisted := apiv0.ServerJSON{Name: "com.example/unlisted-server", Description: "Unlisted server", Version: "1.0.0", Packages: testPackages}
+
+	w := send(http.MethodPost, "/v0/publish", listed)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = send(http.MethodPost, "/v0/publish?unlisted=true", unlisted)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var published apiv0.ServerResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&published))
+	assert.True(t, published.Meta.Official.Unlisted)
+
+	// Hidden from list and search results
+	assert.Equal(t, []string{"com.example/listed-server"}, listNames(""))
+	assert.Empty(t, listNames("?search=unlisted"))
+
+	// Still resolvable by exact name
+	encodedName := url.PathEscape(unlisted.Name)
+	for _, path := range []string{
+		"/v0/servers/" + encodedName,
+		"/v0/servers/" + encodedName + "/versions/1.0.0",
+	} {
+		w = send(http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, w.Code, path)
+		var resp apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, unlisted.Name, resp.Server.Name)
+		assert.True(t, resp.Meta.Official.Unlisted)
+	}
+	w = send(http.MethodGet, "/v0/servers/"+encodedName+"/versions", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Admins can list it again via edit
+	w = send(http.MethodPut, "/v0/servers/"+encodedName+"/versions/1.0.0?unlisted=false", unlisted)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.ElementsMatch(t, []string{"com.example/listed-server", "com.example/unlisted-server"}, listNames(""))
+}
+
+func TestListServersReadPermission(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig(t)
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	for _, name := range []string{"com.example/active-server", "com.example/deleted-server"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Name: name, Description: "Read permission test server", Version: "1.0.0", Packages: testPackages,
+		})
+		require.NoError(t, err)
+	}
+	_, err := registryService.SetServerStatus(ctx, "com.example/deleted-server", nil, model.StatusDeleted, "test")
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, cfg)
+
+	tokenWith := func(permissions ...auth.Permission) string {
+		token, err := generateTestJWTToken(cfg, auth.JWTClaims{AuthMethod: auth.MethodNone, Permissions: permissions})
+		require.NoError(t, err)
+		return "Bearer " + token
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		authorization  string
+		expectedStatus int
+		expected       []string
+	}{
+		{
+			name:           "anonymous",
+			expectedStatus: http.StatusOK,
+			expected:       []string{"com.example/active-server"},
+		},
+		{
+			name:           "anonymous filtering by deleted status",
+			query:          "?status=deleted",
+			expectedStatus: http.StatusOK,
+			expected:       []string{},
+		},
+		{
+			name:           "publish-only token",
+			authorization:  tokenWith(auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "*"}),
+			expectedStatus: http.StatusOK,
+			expected:       []string{"com.example/active-server"},
+		},
+		{
+			name:           "read-scoped token",
+			authorization:  tokenWith(auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "*"}),
+			expectedStatus: http.StatusOK,
+			expected:       []string{"com.example/active-server", "com.example/deleted-server"},
+		},
+		{
+			name:           "read-scoped token filtering by deleted status",
+			query:          "?status=deleted",
+			authorization:  tokenWith(auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "*"}),
+			expectedStatus: http.StatusOK,
+			expected:       []string{"com.example/deleted-server"},
+		},
+		{
+			name:           "invalid token",
+			authorization:  "Bearer not-a-token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers"+tt.query, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			names := []string{}
+			for _, server := range resp.Servers {
+				names = append(names, server.Server.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestServersEndpointYAML(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Name:        "com.example/yaml-server",
+		Description: "Server for content negotiation testing",
+		Version:     "1.0.0",
+		Packages:    testPackages,
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	humaConfig := huma.DefaultConfig("Test API", "1.0.0")
+	humaConfig.Formats = map[string]huma.Format{
+		"application/json": huma.DefaultJSONFormat,
+		v0.YAMLContentType: v0.YAMLFormat,
+	}
+	api := humago.New(mux, humaConfig)
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	encodedName := url.PathEscape("com.example/yaml-server")
+
+	for _, path := range []string{
+		"/v0/servers/" + encodedName,
+		"/v0/servers/" + encodedName + "/versions/1.0.0",
+	} {
+		t.Run(path, func(t *testing.T) {
+			get := func(accept string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				req.Header.Set("Accept", accept)
+				w := httptest.NewRecorder()
+				mux.ServeHTTP(w, req)
+				require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+				return w
+			}
+
+			jsonResponse := get("application/json")
+			assert.Equal(t, "application/json", jsonResponse.Header().Get("Content-Type"))
+			var fromJSON map[string]any
+			require.NoError(t, json.Unmarshal(jsonResponse.Body.Bytes(), &fromJSON))
+
+			yamlResponse := get(v0.YAMLContentType)
+			assert.Equal(t, v0.YAMLContentType, yamlResponse.Header().Get("Content-Type"))
+			var fromYAML map[string]any
+			require.NoError(t, yaml.Unmarshal(yamlResponse.Body.Bytes(), &fromYAML))
+
+			assert.Equal(t, fromJSON, fromYAML)
+			server, ok := fromYAML["server"].(map[string]any)
+			require.True(t, ok)
+			assert.Equal(t, "1.0.0", server["version"])
+		})
+	}
+}
+
+func TestServersEndpointListLimits(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{
+		EnableRegistryValidation: false,
+		DefaultListLimit:         2,
+		MaxListLimit:             3,
+		MaxVersionsPerServer:     10000,
+	})
+
+	for _, name := range []string{"com.example/server-a", "com.example/server-b", "com.example/server-c", "com.example/server-d"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{Name: name, Description: "A test server", Version: "1.0.0", Packages: testPackages})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	list := func(t *testing.T, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("unspecified limit uses the configured default", func(t *testing.T) {
+		w := list(t, "/v0/servers")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Len(t, resp.Servers, 2)
+		assert.Equal(t, 2, resp.Metadata.Filter.Limit)
+	})
+
+	t.Run("limit up to the configured max is accepted", func(t *testing.T) {
+		w := list(t, "/v0/servers?limit=3")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Len(t, resp.Servers, 3)
+	})
+
+	for _, path := range []string{"/v0/servers?limit=4", "/v0/servers/changes?limit=4", "/v0/servers/by-repository?url=https://github.com/example/repo&limit=4"} {
+		t.Run("limit above the configured max is rejected: "+path, func(t *testing.T) {
+			w := list(t, path)
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+			assert.Contains(t, w.Body.String(), "expected number <= 3")
+		})
+	}
+
+	t.Run("OpenAPI documents the configured limits", func(t *testing.T) {
+		for _, param := range api.OpenAPI().Paths["/v0/servers"].Get.Parameters {
+			if param.Name == "limit" {
+				require.NotNil(t, param.Schema.Maximum)
+				assert.InDelta(t, 3, *param.Schema.Maximum, 0)
+				assert.Equal(t, 2, param.Schema.Default)
+			}
+		}
+	})
+}
+
+func TestServersEndpointPrevCursor(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	for _, name := range []string{"com.example/server-a", "com.example/server-b", "com.example/server-c", "com.example/server-d", "com.example/server-e"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{Name: name, Description: "A test server", Version: "1.0.0", Packages: testPackages})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	list := func(t *testing.T, cursor string) apiv0.ServerListResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers?limit=2&cursor="+url.QueryEscape(cursor), nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+
+	page1 := list(t, "")
+	assert.Empty(t, page1.Metadata.PrevCursor, "the first page has no previous page")
+	require.NotEmpty(t, page1.Metadata.NextCursor)
+
+	page2 := list(t, page1.Metadata.NextCursor)
+	require.NotEmpty(t, page2.Metadata.PrevCursor)
+	assert.NotEqual(t, page1.Servers, page2.Servers)
+
+	backToPage1 := list(t, page2.Metadata.PrevCursor)
+	assert.Equal(t, page1.Servers, backToPage1.Servers)
+	assert.Equal(t, page1.Metadata.NextCursor, backToPage1.Metadata.NextCursor)
+}
+
+func TestGetAllVersionsEndpointSort(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	// Publish out of semver order, so publish order and semver order differ
+	serverName := "com.example/sorted-versions-server"
+	for _, version := range []string{"1.0.0", "2.0.0", "1.5.0", "2.0.0-rc.1"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Name:        serverName,
+			Description: "Sorted versions test server",
+			Version:     version,
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"", []string{"2.0.0", "2.0.0-rc.1", "1.5.0", "1.0.0"}},
+		{"?sort=semver", []string{"2.0.0", "2.0.0-rc.1", "1.5.0", "1.0.0"}},
+		{"?sort=published", []string{"2.0.0-rc.1", "1.5.0", "2.0.0", "1.0.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("sort"+tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+"/versions"+tt.query, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+			versions := make([]string, len(resp.Servers))
+			var latest []string
+			for i, server := range resp.Servers {
+				versions[i] = server.Server.Version
+				if server.Meta.Official.IsLatest {
+					latest = append(latest, server.Server.Version)
+				}
+			}
+			assert.Equal(t, tt.expected, versions)
+			assert.Equal(t, []string{"2.0.0"}, latest, "exactly one version is flagged as latest")
+		})
+	}
+
+	t.Run("rejects unknown sort", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+"/versions?sort=size", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+// newTestConfig returns a config with a freshly generated JWT key, which the servers endpoints need to
+// validate optional read tokens
+func newTestConfig(t *testing.T) *config.Config {
+	t.Helper()
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	return &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), MaxVersionsPerServer: 10000}
 }
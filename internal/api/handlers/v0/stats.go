@@ -0,0 +1,31 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// RegisterStatsEndpoint registers the server stats endpoint
+func RegisterStatsEndpoint(api huma.API, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-stats",
+		Method:      http.MethodGet,
+		Path:        "/v0/stats",
+		Summary:     "Get registry stats",
+		Description: "Get the number of servers by the status of their latest version, along with the total number of versions. Results may be cached for a short time.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, _ *struct{}) (*Response[apiv0.ServerStats], error) {
+		stats, err := registry.GetServerStats(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get registry stats", err)
+		}
+
+		return &Response[apiv0.ServerStats]{
+			Body: *stats,
+		}, nil
+	})
+}
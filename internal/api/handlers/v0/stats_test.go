@@ -0,0 +1,72 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{MaxVersionsPerServer: 10000})
+
+	servers := []struct {
+		name     string
+		versions []string
+		status   string
+	}{
+		{name: "com.example/stats-active-one", versions: []string{"1.0.0", "1.1.0"}},
+		{name: "com.example/stats-active-two", versions: []string{"1.0.0"}},
+		{name: "com.example/stats-deprecated", versions: []string{"1.0.0"}, status: "deprecated"},
+		{name: "com.example/stats-deleted", versions: []string{"1.0.0", "2.0.0"}, status: "deleted"},
+	}
+	for _, server := range servers {
+		for _, version := range server.versions {
+			serverJSON := &apiv0.ServerJSON{
+				Name:        server.name,
+				Description: "Stats test server",
+				Version:     version,
+				Packages:    testPackages,
+			}
+			_, err := registryService.CreateServer(ctx, serverJSON)
+			require.NoError(t, err)
+
+			if server.status != "" {
+				_, err = registryService.UpdateServer(ctx, server.name, version, serverJSON, &server.status)
+				require.NoError(t, err)
+			}
+		}
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterStatsEndpoint(api, registryService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/stats", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var stats apiv0.ServerStats
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, apiv0.ServerStats{
+		Active:        2,
+		Deprecated:    1,
+		Deleted:       1,
+		TotalServers:  4,
+		TotalVersions: 6,
+	}, stats)
+}
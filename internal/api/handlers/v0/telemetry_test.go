@@ -17,6 +17,7 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -31,7 +32,8 @@ func TestPrometheusHandler(t *testing.T) {
 			Source: "github",
 			ID:     "example/test-server",
 		},
-		Version: "2.0.0",
+		Version:  "2.0.0",
+		Packages: testPackages,
 	})
 	assert.NoError(t, err)
 
@@ -45,8 +47,8 @@ func TestPrometheusHandler(t *testing.T) {
 	api.UseMiddleware(router.MetricTelemetryMiddleware(metrics,
 		router.WithSkipPaths("/health", "/metrics", "/ping", "/docs"),
 	))
-	v0.RegisterHealthEndpoint(api, cfg, metrics)
-	v0.RegisterServersEndpoints(api, registryService)
+	v0.RegisterHealthEndpoint(api, cfg, metrics, validators.NewRegistryBreaker())
+	v0.RegisterServersEndpoints(api, registryService, newTestConfig(t))
 
 	// Add /metrics for Prometheus metrics using promhttp
 	mux.Handle("/metrics", metrics.PrometheusHandler())
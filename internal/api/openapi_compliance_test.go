@@ -16,6 +16,7 @@ import (
 
 	"github.com/modelcontextprotocol/registry/internal/api/router"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
 )
 
 // OpenAPISpec represents the minimal structure we need to compare paths
@@ -43,7 +44,8 @@ func TestOpenAPIEndpointCompliance(t *testing.T) {
 	}
 
 	// Register V0 routes exactly like production does
-	router.RegisterV0Routes(api, cfg, nil, nil) // nil service and metrics for schema testing
+	// A service without a database and nil metrics for schema testing; registration only reads the list limits
+	router.RegisterV0Routes(api, cfg, service.NewRegistryService(nil, cfg), nil)
 
 	// Get the OpenAPI schema
 	req := httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil)
@@ -4,15 +4,21 @@ package router
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
 
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
@@ -84,6 +90,65 @@ func MetricTelemetryMiddleware(metrics *telemetry.Metrics, options ...Middleware
 	}
 }
 
+// TracingMiddleware starts a root span for each request, continuing any trace the caller propagated, with
+// the request's method, route and response status
+func TracingMiddleware(options ...MiddlewareOption) func(huma.Context, func(huma.Context)) {
+	config := &middlewareConfig{
+		skipPaths: make(map[string]bool),
+	}
+
+	for _, opt := range options {
+		opt(config)
+	}
+
+	return func(ctx huma.Context, next func(huma.Context)) {
+		path := ctx.URL().Path
+		pathParts := strings.Split(path, "/")
+		pathToMatch := "/" + pathParts[len(pathParts)-1]
+		if config.skipPaths[pathToMatch] || config.skipPaths[path] {
+			next(ctx)
+			return
+		}
+
+		method := ctx.Method()
+		routePath := getRoutePath(ctx)
+
+		parent := otel.GetTextMapPropagator().Extract(ctx.Context(), humaHeaderCarrier{ctx})
+		spanCtx, span := otel.Tracer(telemetry.Namespace).Start(parent, method+" "+routePath,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPMethod(method), semconv.HTTPRoute(routePath)),
+		)
+		defer span.End()
+
+		next(huma.WithContext(ctx, spanCtx))
+
+		statusCode := ctx.Status()
+		span.SetAttributes(semconv.HTTPStatusCode(statusCode))
+		if statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(statusCode))
+		}
+	}
+}
+
+// humaHeaderCarrier reads propagated trace context from request headers
+type humaHeaderCarrier struct {
+	ctx huma.Context
+}
+
+func (c humaHeaderCarrier) Get(key string) string {
+	return c.ctx.Header(key)
+}
+
+func (c humaHeaderCarrier) Set(string, string) {}
+
+func (c humaHeaderCarrier) Keys() []string {
+	var keys []string
+	c.ctx.EachHeader(func(name, _ string) {
+		keys = append(keys, name)
+	})
+	return keys
+}
+
 // WithSkipPaths allows skipping instrumentation for specific paths
 func WithSkipPaths(paths ...string) MiddlewareOption {
 	return func(c *middlewareConfig) {
@@ -134,6 +199,16 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 	humaConfig.Info.Description = "A community driven registry service for Model Context Protocol (MCP) servers.\n\n[GitHub repository](https://github.com/modelcontextprotocol/registry) | [Documentation](https://github.com/modelcontextprotocol/registry/tree/main/docs)"
 	// Disable $schema property in responses: https://github.com/danielgtaylor/huma/issues/230
 	humaConfig.CreateHooks = []func(huma.Config) huma.Config{}
+	// Project server responses down to the fields requested via the fields query parameter
+	humaConfig.Transformers = append(humaConfig.Transformers, v0.SelectServerFields)
+	// Indent JSON responses for requests with pretty=true, e.g. when debugging with curl
+	humaConfig.Transformers = append(humaConfig.Transformers, v0.PrettyPrint)
+	// Offer YAML responses to clients that ask for them with the Accept header
+	humaConfig.Formats = maps.Clone(humaConfig.Formats)
+	humaConfig.Formats["application/json"] = v0.JSONFormat
+	humaConfig.Formats["json"] = v0.JSONFormat
+	humaConfig.Formats[v0.YAMLContentType] = v0.YAMLFormat
+	humaConfig.Formats["yaml"] = v0.YAMLFormat
 
 	// Create a new API using humago adapter for standard library
 	api := humago.New(mux, humaConfig)
@@ -143,6 +218,11 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 		WithSkipPaths("/health", "/metrics", "/ping", "/docs"),
 	))
 
+	// Add a root span per request, under which the service and database spans are recorded
+	api.UseMiddleware(TracingMiddleware(
+		WithSkipPaths("/health", "/metrics", "/ping", "/docs"),
+	))
+
 	// Register routes for all API versions
 	RegisterV0Routes(api, cfg, registry, metrics)
 
@@ -0,0 +1,105 @@
+package router_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.TracingMiddleware(router.WithSkipPaths("/ping")))
+
+	type itemInput struct {
+		ID string `path:"id"`
+	}
+	var handlerSpan trace.SpanContext
+	huma.Get(api, "/v0/items/{id}", func(ctx context.Context, input *itemInput) (*struct{}, error) {
+		handlerSpan = trace.SpanContextFromContext(ctx)
+		if input.ID == "broken" {
+			return nil, huma.Error500InternalServerError("broken")
+		}
+		return &struct{}{}, nil
+	})
+	huma.Get(api, "/ping", func(_ context.Context, _ *struct{}) (*struct{}, error) {
+		return &struct{}{}, nil
+	})
+
+	serve := func(path string, header http.Header) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	t.Run("records a server span with route and status", func(t *testing.T) {
+		recorder.Reset()
+		serve("/v0/items/123", nil)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		span := spans[0]
+		assert.Equal(t, "GET /v0/items/{id}", span.Name())
+		assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+		assert.Subset(t, span.Attributes(), []any{
+			semconv.HTTPMethod(http.MethodGet),
+			semconv.HTTPRoute("/v0/items/{id}"),
+			semconv.HTTPStatusCode(http.StatusNoContent),
+		})
+		assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID(), "handlers should run in the request span")
+	})
+
+	t.Run("marks server errors", func(t *testing.T) {
+		recorder.Reset()
+		serve("/v0/items/broken", nil)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Contains(t, spans[0].Attributes(), semconv.HTTPStatusCode(http.StatusInternalServerError))
+	})
+
+	t.Run("continues a propagated trace", func(t *testing.T) {
+		recorder.Reset()
+		const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		serve("/v0/items/123", http.Header{"Traceparent": {"00-" + traceID + "-00f067aa0ba902b7-01"}})
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, traceID, spans[0].SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	})
+
+	t.Run("skips configured paths", func(t *testing.T) {
+		recorder.Reset()
+		serve("/ping", nil)
+		assert.Empty(t, recorder.Ended())
+	})
+}
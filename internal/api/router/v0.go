@@ -9,15 +9,21 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 )
 
 func RegisterV0Routes(
 	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics,
 ) {
-	v0.RegisterHealthEndpoint(api, cfg, metrics)
+	v0.RegisterHealthEndpoint(api, cfg, metrics, validators.DefaultRegistryBreaker)
 	v0.RegisterPingEndpoint(api)
-	v0.RegisterServersEndpoints(api, registry)
+	v0.RegisterServersEndpoints(api, registry, cfg)
+	v0.RegisterFeedEndpoint(api, registry, cfg)
+	v0.RegisterStatsEndpoint(api, registry)
+	v0.RegisterEventsEndpoint(api, registry)
 	v0.RegisterEditEndpoints(api, registry, cfg)
+	v0.RegisterAuditEndpoint(api, registry, cfg)
+	v0.RegisterAdminEndpoints(api, registry, cfg)
 	v0auth.RegisterAuthEndpoints(api, cfg)
 	v0.RegisterPublishEndpoint(api, registry, cfg)
 }
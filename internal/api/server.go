@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -33,6 +34,78 @@ func TrailingSlashMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// Defaults for cross-origin requests when the config leaves the methods or headers empty
+var (
+	defaultCORSMethods = []string{http.MethodGet, http.MethodHead}
+	defaultCORSHeaders = []string{"Accept", "Authorization"}
+)
+
+// corsExposedHeaders are the response headers cross-origin pages may read besides the CORS-safelisted ones
+const corsExposedHeaders = "Deprecation, Link, X-Matched-Permission, X-Registry-Warning"
+
+// CORSMiddleware lets browsers call the /v0 API from the configured origins. Preflight requests from other
+// origins, or for methods that are not allowed, are refused with 403 Forbidden. Other requests are passed
+// through, and browsers keep cross-origin pages from reading responses that lack CORS headers.
+func CORSMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	allowedOrigins := make([]string, 0, len(cfg.CORSAllowedOrigins))
+	for _, origin := range cfg.CORSAllowedOrigins {
+		allowedOrigins = append(allowedOrigins, strings.ToLower(strings.TrimSuffix(strings.TrimSpace(origin), "/")))
+	}
+	allowAnyOrigin := slices.Contains(allowedOrigins, "*")
+
+	methods := cfg.CORSAllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	headers := cfg.CORSAllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" || !strings.HasPrefix(r.URL.Path, "/v0/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			originAllowed := allowAnyOrigin || slices.Contains(allowedOrigins, strings.ToLower(origin))
+			requestedMethod := r.Header.Get("Access-Control-Request-Method")
+			isPreflight := r.Method == http.MethodOptions && requestedMethod != ""
+			w.Header().Add("Vary", "Origin")
+
+			if isPreflight {
+				if !originAllowed || !slices.ContainsFunc(methods, func(m string) bool { return strings.EqualFold(m, requestedMethod) }) {
+					http.Error(w, "Cross-origin request not allowed", http.StatusForbidden)
+					return
+				}
+				setCORSOriginHeader(w, origin, allowAnyOrigin)
+				w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+				w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+				w.Header().Set("Access-Control-Max-Age", "600")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if originAllowed && slices.ContainsFunc(methods, func(m string) bool { return strings.EqualFold(m, r.Method) }) {
+				setCORSOriginHeader(w, origin, allowAnyOrigin)
+				w.Header().Set("Access-Control-Expose-Headers", corsExposedHeaders)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setCORSOriginHeader allows the request's origin, or any origin when every origin is allowed
+func setCORSOriginHeader(w http.ResponseWriter, origin string, allowAnyOrigin bool) {
+	if allowAnyOrigin {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+}
+
 // Server represents the HTTP server
 type Server struct {
 	config   *config.Config
@@ -48,8 +121,12 @@ func NewServer(cfg *config.Config, registryService service.RegistryService, metr
 
 	api := router.NewHumaAPI(cfg, registryService, mux, metrics)
 
-	// Wrap the mux with trailing slash middleware
+	// Wrap the mux with trailing slash middleware, answering CORS preflight requests before any redirect
 	handler := TrailingSlashMiddleware(mux)
+	if cfg.EnableCompression {
+		handler = CompressionMiddleware(cfg.CompressionMinBytes)(handler)
+	}
+	handler = CORSMiddleware(cfg)(handler)
 
 	server := &Server{
 		config:   cfg,
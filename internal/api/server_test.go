@@ -1,11 +1,20 @@
 package api_test
 
 import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 func TestTrailingSlashMiddleware(t *testing.T) {
@@ -93,3 +102,185 @@ func TestTrailingSlashMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestCORSMiddleware(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	cfg := &config.Config{CORSAllowedOrigins: []string{"https://explorer.example.com"}}
+
+	tests := []struct {
+		name                 string
+		cfg                  *config.Config
+		method               string
+		path                 string
+		origin               string
+		requestMethod        string
+		expectedStatus       int
+		expectedAllowOrigin  string
+		expectedAllowMethods string
+	}{
+		{
+			name:                 "preflight from allowed origin",
+			cfg:                  cfg,
+			method:               http.MethodOptions,
+			path:                 "/v0/servers",
+			origin:               "https://explorer.example.com",
+			requestMethod:        http.MethodGet,
+			expectedStatus:       http.StatusNoContent,
+			expectedAllowOrigin:  "https://explorer.example.com",
+			expectedAllowMethods: "GET, HEAD",
+		},
+		{
+			name:           "preflight from disallowed origin is refused",
+			cfg:            cfg,
+			method:         http.MethodOptions,
+			path:           "/v0/servers",
+			origin:         "https://evil.example.com",
+			requestMethod:  http.MethodGet,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "preflight for a mutating method is refused",
+			cfg:            cfg,
+			method:         http.MethodOptions,
+			path:           "/v0/publish",
+			origin:         "https://explorer.example.com",
+			requestMethod:  http.MethodPost,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "same-origin only by default",
+			cfg:            &config.Config{},
+			method:         http.MethodOptions,
+			path:           "/v0/servers",
+			origin:         "https://explorer.example.com",
+			requestMethod:  http.MethodGet,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:                "request from allowed origin",
+			cfg:                 cfg,
+			method:              http.MethodGet,
+			path:                "/v0/servers",
+			origin:              "https://explorer.example.com",
+			expectedStatus:      http.StatusOK,
+			expectedAllowOrigin: "https://explorer.example.com",
+		},
+		{
+			name:           "request from disallowed origin has no CORS headers",
+			cfg:            cfg,
+			method:         http.MethodGet,
+			path:           "/v0/servers",
+			origin:         "https://evil.example.com",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:                "any origin",
+			cfg:                 &config.Config{CORSAllowedOrigins: []string{"*"}},
+			method:              http.MethodGet,
+			path:                "/v0/servers",
+			origin:              "https://anywhere.example.com",
+			expectedStatus:      http.StatusOK,
+			expectedAllowOrigin: "*",
+		},
+		{
+			name:           "paths outside the API are not shared",
+			cfg:            cfg,
+			method:         http.MethodGet,
+			path:           "/metrics",
+			origin:         "https://explorer.example.com",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.requestMethod != "" {
+				req.Header.Set("Access-Control-Request-Method", tt.requestMethod)
+			}
+			rr := httptest.NewRecorder()
+
+			api.CORSMiddleware(tt.cfg)(handler).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.expectedAllowOrigin, rr.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.expectedAllowMethods, rr.Header().Get("Access-Control-Allow-Methods"))
+		})
+	}
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	var list apiv0.ServerListResponse
+	for i := range 100 {
+		list.Servers = append(list.Servers, apiv0.ServerResponse{Server: apiv0.ServerJSON{
+			Name:        fmt.Sprintf("com.example/server-%d", i),
+			Description: "A server with a description long enough to make the list worth compressing",
+			Version:     "1.0.0",
+		}})
+	}
+	largeBody, err := json.Marshal(list)
+	require.NoError(t, err)
+	smallBody := []byte(`{"servers":[]}`)
+
+	handler := api.CompressionMiddleware(1024)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := largeBody
+		if r.URL.Query().Get("small") == "true" {
+			body = smallBody
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+		w.Header().Set("ETag", `"abc"`)
+		_, _ = w.Write(body)
+	}))
+
+	get := func(target, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("large list is gzipped when requested", func(t *testing.T) {
+		rr := get("/v0/servers", "gzip, deflate")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+		assert.Empty(t, rr.Header().Get("Content-Length"))
+		assert.Equal(t, `W/"abc"`, rr.Header().Get("ETag"))
+		assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+		assert.Less(t, rr.Body.Len(), len(largeBody))
+
+		reader, err := gzip.NewReader(rr.Body)
+		require.NoError(t, err)
+		decompressed, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(largeBody), string(decompressed))
+	})
+
+	t.Run("large list is not gzipped without Accept-Encoding", func(t *testing.T) {
+		rr := get("/v0/servers", "")
+		assert.Empty(t, rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, `"abc"`, rr.Header().Get("ETag"))
+		assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+		assert.Equal(t, largeBody, rr.Body.Bytes())
+	})
+
+	t.Run("gzip refused with q=0", func(t *testing.T) {
+		rr := get("/v0/servers", "gzip;q=0, identity")
+		assert.Empty(t, rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, largeBody, rr.Body.Bytes())
+	})
+
+	t.Run("small body is sent uncompressed", func(t *testing.T) {
+		rr := get("/v0/servers?small=true", "gzip")
+		assert.Empty(t, rr.Header().Get("Content-Encoding"))
+		assert.Equal(t, fmt.Sprint(len(smallBody)), rr.Header().Get("Content-Length"))
+		assert.Equal(t, `"abc"`, rr.Header().Get("ETag"))
+		assert.Equal(t, smallBody, rr.Body.Bytes())
+	})
+}
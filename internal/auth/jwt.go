@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"crypto/ed25519"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"strings"
@@ -19,10 +20,12 @@ const (
 	PermissionActionPublish PermissionAction = "publish"
 	// Intended for admins taking moderation actions only, at least for now
 	PermissionActionEdit PermissionAction = "edit"
+	// Lets support staff see unlisted, pending and deleted servers in list results, without edit rights
+	PermissionActionRead PermissionAction = "read"
 )
 
 type Permission struct {
-	Action          PermissionAction `json:"action"`   // The action type (publish or edit)
+	Action          PermissionAction `json:"action"`   // The action type (publish, edit or read)
 	ResourcePattern string           `json:"resource"` // e.g., "io.github.username/*"
 }
 
@@ -35,6 +38,11 @@ type JWTClaims struct {
 	Permissions       []Permission `json:"permissions"`
 }
 
+// Actor identifies who the token was issued to, as "method:subject" (e.g. "github-at:octocat"), for audit records
+func (c *JWTClaims) Actor() string {
+	return string(c.AuthMethod) + ":" + c.AuthMethodSubject
+}
+
 type TokenResponse struct {
 	RegistryToken string `json:"registry_token"`
 	ExpiresAt     int    `json:"expires_at"`
@@ -42,33 +50,59 @@ type TokenResponse struct {
 
 // JWTManager handles JWT token operations
 type JWTManager struct {
-	privateKey    ed25519.PrivateKey
-	publicKey     ed25519.PublicKey
-	tokenDuration time.Duration
+	privateKey ed25519.PrivateKey
+	keyID      string
+	// verificationKeys are the public keys tokens may be signed with, by key ID: the current key and any
+	// previous keys kept while their tokens expire
+	verificationKeys map[string]ed25519.PublicKey
+	tokenDuration    time.Duration
+	audience         string
 }
 
+// defaultTokenDuration is how long registry tokens are valid for when no TTL is configured
+const defaultTokenDuration = 5 * time.Minute
+
 func NewJWTManager(cfg *config.Config) *JWTManager {
-	seed, err := hex.DecodeString(cfg.JWTPrivateKey)
+	seed, err := config.DecodeJWTPrivateKey(cfg.JWTPrivateKey)
 	if err != nil {
-		panic(fmt.Sprintf("JWTPrivateKey must be a valid hex-encoded string: %v", err))
-	}
-
-	// Require a valid Ed25519 seed (32 bytes)
-	if len(seed) != ed25519.SeedSize {
-		panic(fmt.Sprintf("JWTPrivateKey seed must be exactly %d bytes for Ed25519, got %d bytes", ed25519.SeedSize, len(seed)))
+		panic(fmt.Sprintf("JWTPrivateKey %v", err))
 	}
 
 	// Generate the full Ed25519 key pair from the seed
 	privateKey := ed25519.NewKeyFromSeed(seed)
 	publicKey := privateKey.Public().(ed25519.PublicKey)
+	keyID := jwtKeyID(publicKey)
+
+	verificationKeys := map[string]ed25519.PublicKey{keyID: publicKey}
+	for _, previousKey := range cfg.JWTPreviousKeys {
+		previousSeed, err := config.DecodeJWTPrivateKey(previousKey)
+		if err != nil {
+			panic(fmt.Sprintf("JWTPreviousKeys %v", err))
+		}
+		previousPublicKey := ed25519.NewKeyFromSeed(previousSeed).Public().(ed25519.PublicKey)
+		verificationKeys[jwtKeyID(previousPublicKey)] = previousPublicKey
+	}
+
+	tokenDuration := cfg.JWTTokenTTL
+	if tokenDuration <= 0 {
+		tokenDuration = defaultTokenDuration
+	}
 
 	return &JWTManager{
-		privateKey:    privateKey,
-		publicKey:     publicKey,
-		tokenDuration: 5 * time.Minute, // 5-minute tokens as per requirements
+		privateKey:       privateKey,
+		keyID:            keyID,
+		verificationKeys: verificationKeys,
+		tokenDuration:    tokenDuration,
+		audience:         cfg.BaseURL,
 	}
 }
 
+// jwtKeyID identifies a signing key in the kid header of the tokens it signs, without revealing the key
+func jwtKeyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:8])
+}
+
 // GenerateToken generates a new Registry JWT token
 func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims) (*TokenResponse, error) {
 	// Check whether they have global permissions (used by admins)
@@ -101,9 +135,13 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 	if claims.Issuer == "" {
 		claims.Issuer = "mcp-registry"
 	}
+	if len(claims.Audience) == 0 && j.audience != "" {
+		claims.Audience = jwt.ClaimStrings{j.audience}
+	}
 
-	// Create token with claims
+	// Create token with claims, naming the key it is signed with so it can still be verified after rotation
 	token := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, claims)
+	token.Header["kid"] = j.keyID
 
 	// Sign token with Ed25519 private key
 	tokenString, err := token.SignedString(j.privateKey)
@@ -119,14 +157,22 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 
 // ValidateToken validates a Registry JWT token and returns the claims
 func (j *JWTManager) ValidateToken(_ context.Context, tokenString string) (*JWTClaims, error) {
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"EdDSA"}),
+		jwt.WithExpirationRequired(),
+	}
+	// Reject tokens issued for another registry sharing the same key, e.g. staging tokens used against prod
+	if j.audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(j.audience))
+	}
+
 	// Parse token
-	// This also validates expiry
+	// This also validates expiry and audience
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&JWTClaims{},
-		func(_ *jwt.Token) (interface{}, error) { return j.publicKey, nil },
-		jwt.WithValidMethods([]string{"EdDSA"}),
-		jwt.WithExpirationRequired(),
+		j.verificationKey,
+		parserOptions...,
 	)
 
 	// Validate token
@@ -146,13 +192,44 @@ func (j *JWTManager) ValidateToken(_ context.Context, tokenString string) (*JWTC
 	return claims, nil
 }
 
+// verificationKey returns the public key named by the token's kid header. Tokens without one were issued before
+// key IDs were added, and are checked against the current key.
+func (j *JWTManager) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"]
+	if !ok {
+		return j.verificationKeys[j.keyID], nil
+	}
+	keyID, _ := kid.(string)
+	publicKey, ok := j.verificationKeys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("token is signed with an unknown key %q", keyID)
+	}
+	return publicKey, nil
+}
+
 func (j *JWTManager) HasPermission(resource string, action PermissionAction, permissions []Permission) bool {
+	_, ok := j.MatchPermission(resource, action, permissions)
+	return ok
+}
+
+// MatchPermission returns the first permission granting the action on the resource, if any
+func (j *JWTManager) MatchPermission(resource string, action PermissionAction, permissions []Permission) (Permission, bool) {
 	for _, perm := range permissions {
 		if perm.Action == action && isResourceMatch(resource, perm.ResourcePattern) {
-			return true
+			return perm, true
+		}
+	}
+	return Permission{}, false
+}
+
+// MatchGlobalPermission returns the permission granting the action on every resource, as held by admins, if any
+func (j *JWTManager) MatchGlobalPermission(action PermissionAction, permissions []Permission) (Permission, bool) {
+	for _, perm := range permissions {
+		if perm.Action == action && perm.ResourcePattern == "*" {
+			return perm, true
 		}
 	}
-	return false
+	return Permission{}, false
 }
 
 func isResourceMatch(resource, pattern string) bool {
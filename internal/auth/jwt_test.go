@@ -5,6 +5,7 @@ import (
 	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/hex"
+	"strings"
 	"testing"
 	"time"
 
@@ -180,6 +181,165 @@ func TestJWTManager_GenerateAndVerifyToken(t *testing.T) {
 	})
 }
 
+func TestJWTManager_TokenTTL(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		expected time.Duration
+	}{
+		{name: "configured TTL", ttl: 30 * time.Minute, expected: 30 * time.Minute},
+		{name: "zero keeps the default", ttl: 0, expected: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtManager := auth.NewJWTManager(&config.Config{
+				JWTPrivateKey: hex.EncodeToString(testSeed),
+				JWTTokenTTL:   tt.ttl,
+			})
+
+			before := time.Now()
+			tokenResponse, err := jwtManager.GenerateTokenResponse(ctx, auth.JWTClaims{
+				AuthMethod:        auth.MethodGitHubAT,
+				AuthMethodSubject: "testuser",
+			})
+			require.NoError(t, err)
+
+			claims, err := jwtManager.ValidateToken(ctx, tokenResponse.RegistryToken)
+			require.NoError(t, err)
+			assert.WithinDuration(t, before.Add(tt.expected), claims.ExpiresAt.Time, 2*time.Second)
+			assert.Equal(t, claims.ExpiresAt.Unix(), int64(tokenResponse.ExpiresAt))
+		})
+	}
+
+	t.Run("token past its TTL is rejected", func(t *testing.T) {
+		jwtManager := auth.NewJWTManager(&config.Config{
+			JWTPrivateKey: hex.EncodeToString(testSeed),
+			JWTTokenTTL:   time.Minute,
+		})
+
+		tokenResponse, err := jwtManager.GenerateTokenResponse(ctx, auth.JWTClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				IssuedAt:  jwt.NewNumericDate(time.Now().Add(-2 * time.Minute)),
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(-1 * time.Minute)),
+			},
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "testuser",
+		})
+		require.NoError(t, err)
+
+		_, err = jwtManager.ValidateToken(ctx, tokenResponse.RegistryToken)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+	})
+}
+
+func TestJWTManager_Audience(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	newManager := func(baseURL string) *auth.JWTManager {
+		return auth.NewJWTManager(&config.Config{
+			JWTPrivateKey: hex.EncodeToString(testSeed),
+			BaseURL:       baseURL,
+		})
+	}
+	prod := newManager("https://registry.example.com")
+	claims := auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "testuser",
+	}
+
+	t.Run("token carries the registry base URL as audience", func(t *testing.T) {
+		tokenResponse, err := prod.GenerateTokenResponse(ctx, claims)
+		require.NoError(t, err)
+
+		verifiedClaims, err := prod.ValidateToken(ctx, tokenResponse.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, jwt.ClaimStrings{"https://registry.example.com"}, verifiedClaims.Audience)
+	})
+
+	t.Run("token for another registry is rejected", func(t *testing.T) {
+		tokenResponse, err := newManager("https://staging.registry.example.com").GenerateTokenResponse(ctx, claims)
+		require.NoError(t, err)
+
+		_, err = prod.ValidateToken(ctx, tokenResponse.RegistryToken)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+	})
+
+	t.Run("token without audience is rejected", func(t *testing.T) {
+		tokenResponse, err := newManager("").GenerateTokenResponse(ctx, claims)
+		require.NoError(t, err)
+
+		_, err = prod.ValidateToken(ctx, tokenResponse.RegistryToken)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, jwt.ErrTokenRequiredClaimMissing)
+	})
+}
+
+func TestJWTManager_KeyRotation(t *testing.T) {
+	newSeed := func() string {
+		seed := make([]byte, ed25519.SeedSize)
+		_, err := rand.Read(seed)
+		require.NoError(t, err)
+		return hex.EncodeToString(seed)
+	}
+	oldKey, currentKey, unrelatedKey := newSeed(), newSeed(), newSeed()
+	ctx := context.Background()
+	claims := auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "testuser",
+	}
+
+	oldManager := auth.NewJWTManager(&config.Config{JWTPrivateKey: oldKey})
+	rotated := auth.NewJWTManager(&config.Config{JWTPrivateKey: currentKey, JWTPreviousKeys: []string{oldKey}})
+
+	oldToken, err := oldManager.GenerateTokenResponse(ctx, claims)
+	require.NoError(t, err)
+
+	t.Run("token signed with a previous key is accepted after rotation", func(t *testing.T) {
+		verifiedClaims, err := rotated.ValidateToken(ctx, oldToken.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, "testuser", verifiedClaims.AuthMethodSubject)
+	})
+
+	t.Run("new tokens are signed with the current key", func(t *testing.T) {
+		tokenResponse, err := rotated.GenerateTokenResponse(ctx, claims)
+		require.NoError(t, err)
+
+		_, err = rotated.ValidateToken(ctx, tokenResponse.RegistryToken)
+		require.NoError(t, err)
+		_, err = oldManager.ValidateToken(ctx, tokenResponse.RegistryToken)
+		assert.Error(t, err)
+
+		token, _, err := jwt.NewParser().ParseUnverified(tokenResponse.RegistryToken, &auth.JWTClaims{})
+		require.NoError(t, err)
+		assert.NotEmpty(t, token.Header["kid"])
+	})
+
+	t.Run("token signed with a key not in the set is rejected", func(t *testing.T) {
+		tokenResponse, err := auth.NewJWTManager(&config.Config{JWTPrivateKey: unrelatedKey}).GenerateTokenResponse(ctx, claims)
+		require.NoError(t, err)
+
+		_, err = rotated.ValidateToken(ctx, tokenResponse.RegistryToken)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown key")
+	})
+
+	t.Run("token signed with a dropped key is rejected", func(t *testing.T) {
+		_, err := auth.NewJWTManager(&config.Config{JWTPrivateKey: currentKey}).ValidateToken(ctx, oldToken.RegistryToken)
+		assert.Error(t, err)
+	})
+}
+
 func TestJWTManager_HasPermission(t *testing.T) {
 	// Generate a proper Ed25519 seed for testing
 	testSeed := make([]byte, ed25519.SeedSize)
@@ -272,6 +432,35 @@ func TestJWTManager_HasPermission(t *testing.T) {
 	}
 }
 
+func TestJWTManager_MatchPermission(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+
+	jwtManager := auth.NewJWTManager(&config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)})
+
+	permissions := []auth.Permission{
+		{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.org/*"},
+	}
+
+	matched, ok := jwtManager.MatchPermission("io.github.org/server1", auth.PermissionActionPublish, permissions)
+	require.True(t, ok)
+	assert.Equal(t, auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.org/*"}, matched)
+
+	matched, ok = jwtManager.MatchPermission("io.github.other/server1", auth.PermissionActionPublish, permissions)
+	assert.False(t, ok)
+	assert.Equal(t, auth.Permission{}, matched)
+
+	matched, ok = jwtManager.MatchGlobalPermission(auth.PermissionActionEdit, permissions)
+	require.True(t, ok)
+	assert.Equal(t, auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"}, matched)
+
+	_, ok = jwtManager.MatchGlobalPermission(auth.PermissionActionPublish, permissions)
+	assert.False(t, ok)
+}
+
 func TestNewJWTManager_InvalidKeySize(t *testing.T) {
 	// Test with invalid key size (should panic)
 	cfg := &config.Config{
@@ -283,6 +472,17 @@ func TestNewJWTManager_InvalidKeySize(t *testing.T) {
 	})
 }
 
+func TestNewJWTManager_WeakKey(t *testing.T) {
+	// An all-zero seed has the right size but is rejected as a placeholder
+	cfg := &config.Config{
+		JWTPrivateKey: strings.Repeat("00", ed25519.SeedSize),
+	}
+
+	assert.Panics(t, func() {
+		auth.NewJWTManager(cfg)
+	})
+}
+
 func TestJWTManager_BlockedNamespaces(t *testing.T) {
 	// Generate a proper Ed25519 seed for testing
 	testSeed := make([]byte, ed25519.SeedSize)
@@ -1,15 +1,34 @@
 package config
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
 	env "github.com/caarlos0/env/v11"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
+// RequirableServerFields are the top-level server.json fields REQUIRED_SERVER_FIELDS may list
+var RequirableServerFields = []string{"$schema", "repository", "websiteUrl", "iconUrl", "documentationUrl", "longDescription", "categories", "maintainers", "packages", "remotes"}
+
 // Config holds the application configuration
 // See .env.example for more documentation
 type Config struct {
 	ServerAddress            string `env:"SERVER_ADDRESS" envDefault:":8080"`
 	DatabaseURL              string `env:"DATABASE_URL" envDefault:"postgres://localhost:5432/mcp-registry?sslmode=disable"`
 	SeedFrom                 string `env:"SEED_FROM" envDefault:""`
+	SeedBulkLoad             bool   `env:"SEED_BULK_LOAD" envDefault:"false"`
 	Version                  string `env:"VERSION" envDefault:"dev"`
 	GithubClientID           string `env:"GITHUB_CLIENT_ID" envDefault:""`
 	GithubClientSecret       string `env:"GITHUB_CLIENT_SECRET" envDefault:""`
@@ -17,6 +36,209 @@ type Config struct {
 	EnableAnonymousAuth      bool   `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
 	EnableRegistryValidation bool   `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
 
+	// JWTPreviousKeys are earlier JWTPrivateKey seeds whose tokens are still accepted, so rotating the signing key
+	// does not invalidate outstanding tokens. New tokens are always signed with JWTPrivateKey.
+	JWTPreviousKeys []string `env:"JWT_PREVIOUS_KEYS" envSeparator:","`
+
+	// JWTTokenTTL is how long registry tokens are valid for once issued; zero keeps the default of 5 minutes
+	JWTTokenTTL time.Duration `env:"JWT_TOKEN_TTL" envDefault:"5m"`
+	// BaseURL is the public URL of this registry (e.g. https://registry.modelcontextprotocol.io). When set,
+	// registry tokens carry it as their audience and tokens issued for other registries are rejected.
+	BaseURL string `env:"BASE_URL" envDefault:""`
+
+	// CORSAllowedOrigins are the origins (e.g. https://explorer.example.com, or * for any) browsers may call the
+	// /v0 API from, with the CORSAllowedMethods and CORSAllowedHeaders. Empty keeps the API same-origin only.
+	// The methods default to read-only requests, so cross-origin pages cannot publish or edit servers.
+	CORSAllowedOrigins []string `env:"CORS_ALLOWED_ORIGINS" envSeparator:","`
+	CORSAllowedMethods []string `env:"CORS_ALLOWED_METHODS" envSeparator:"," envDefault:"GET,HEAD"`
+	CORSAllowedHeaders []string `env:"CORS_ALLOWED_HEADERS" envSeparator:"," envDefault:"Accept,Authorization"`
+
+	// TracingEndpoint is the OTLP/HTTP endpoint traces are exported to, e.g. http://otel-collector:4318.
+	// Tracing is disabled when empty.
+	TracingEndpoint string `env:"TRACING_ENDPOINT" envDefault:""`
+
+	// HTTPAuthCABundle is the path to a PEM bundle of extra CA certificates trusted, alongside the system
+	// roots, when fetching keys for HTTP authentication from https://{domain}/.well-known/mcp-registry-auth
+	HTTPAuthCABundle string `env:"HTTP_AUTH_CA_BUNDLE" envDefault:""`
+
+	// EnableStrictOCIBinding requires every platform manifest of an OCI tag to carry the server name
+	// annotation, and the tag to still resolve to the same digest once validation completes
+	EnableStrictOCIBinding bool `env:"ENABLE_STRICT_OCI_BINDING" envDefault:"false"`
+
+	// EnableOCIPinnedDigest validates OCI packages by the digest pinned in their version (e.g. 1.0.0@sha256:...)
+	// instead of the tag, so the validated image cannot change if the tag is moved
+	EnableOCIPinnedDigest bool `env:"ENABLE_OCI_PINNED_DIGEST" envDefault:"true"`
+
+	// RejectMutableOCITags rejects OCI packages whose version is a mutable tag such as "latest" unless it pins
+	// a digest (e.g. latest@sha256:...), in which case the image is validated by that digest
+	RejectMutableOCITags bool `env:"REJECT_MUTABLE_OCI_TAGS" envDefault:"false"`
+
+	// EnableTransportConsistencyCheck adds an advisory X-Registry-Warning response header on publish and edit when a
+	// server's remotes and packages share no transport type
+	EnableTransportConsistencyCheck bool `env:"ENABLE_TRANSPORT_CONSISTENCY_CHECK" envDefault:"false"`
+
+	// EnableSecretValueCheck adds an advisory X-Registry-Warning response header on publish and edit when a
+	// package environment variable or argument has a value or default that looks like a hardcoded secret
+	EnableSecretValueCheck bool `env:"ENABLE_SECRET_VALUE_CHECK" envDefault:"false"`
+
+	// EnableIconContentTypeCheck makes a HEAD request to a published server's iconUrl and rejects it unless it is
+	// served with an image content type
+	EnableIconContentTypeCheck bool `env:"ENABLE_ICON_CONTENT_TYPE_CHECK" envDefault:"false"`
+
+	// EnforceActivePublishStatus rejects publish requests declaring a status other than active in the
+	// registry's official _meta. Status changes must go through the edit endpoint.
+	EnforceActivePublishStatus bool `env:"ENFORCE_ACTIVE_PUBLISH_STATUS" envDefault:"true"`
+
+	// EnforceGitHubOwnerCase rejects io.github servers whose namespace owner differs only in case from their
+	// GitHub repository's owner, since publish permissions are case-sensitive
+	EnforceGitHubOwnerCase bool `env:"ENFORCE_GITHUB_OWNER_CASE" envDefault:"true"`
+
+	// RegistryValidationTimeout bounds each package's ownership check against its registry, separately from
+	// database operations. RegistryValidationTimeouts overrides it per registry type (e.g. oci:60s).
+	RegistryValidationTimeout  time.Duration            `env:"REGISTRY_VALIDATION_TIMEOUT" envDefault:"30s"`
+	RegistryValidationTimeouts map[string]time.Duration `env:"REGISTRY_VALIDATION_TIMEOUTS" envKeyValSeparator:":"`
+
+	// RegistryBreakerThreshold is how many consecutive times a package registry must be unreachable or time out
+	// before it is reported as degraded, and RegistryBreakerCooldown how long it then stays degraded before the
+	// next check is trusted to tell whether it recovered. SkipValidationOnOutage skips the ownership checks of
+	// a degraded registry instead of failing publishes until it recovers.
+	RegistryBreakerThreshold int           `env:"REGISTRY_BREAKER_THRESHOLD" envDefault:"5"`
+	RegistryBreakerCooldown  time.Duration `env:"REGISTRY_BREAKER_COOLDOWN" envDefault:"1m"`
+	SkipValidationOnOutage   bool          `env:"SKIP_VALIDATION_ON_OUTAGE" envDefault:"false"`
+
+	// ValidationCacheTTL is how long the outcome of a package's ownership check is reused for the same package
+	// and server, so repeated publishes and edits don't query the package registry again. Outages and rate
+	// limiting are never cached. Zero disables the cache.
+	ValidationCacheTTL time.Duration `env:"VALIDATION_CACHE_TTL" envDefault:"1m"`
+
+	// MaxConcurrentPublishValidations caps how many publishes and edits check package ownership at once, so bursts
+	// queue instead of tripping package registries' rate limits. Requests waiting longer than
+	// PublishValidationQueueTimeout for their turn are rejected with 503. Zero does not limit validations.
+	MaxConcurrentPublishValidations int           `env:"MAX_CONCURRENT_PUBLISH_VALIDATIONS" envDefault:"0"`
+	PublishValidationQueueTimeout   time.Duration `env:"PUBLISH_VALIDATION_QUEUE_TIMEOUT" envDefault:"30s"`
+
+	// PublishDBTimeout bounds the database transaction of a publish, which runs after registry validation
+	PublishDBTimeout time.Duration `env:"PUBLISH_DB_TIMEOUT" envDefault:"5s"`
+
+	// AllowedRegistryBaseURLs restricts packages to these registry base URLs (e.g. https://ghcr.io). Empty allows all
+	// registries the registry type supports. DeniedRegistryBaseURLs rejects specific registries.
+	AllowedRegistryBaseURLs []string `env:"ALLOWED_REGISTRY_BASE_URLS" envSeparator:","`
+	DeniedRegistryBaseURLs  []string `env:"DENIED_REGISTRY_BASE_URLS" envSeparator:","`
+
+	// OCIRetryAttempts is how many times an OCI registry request is tried when it fails with a connection
+	// error or 5xx response, and OCIRetryBackoff the delay before the first retry (doubling each time)
+	OCIRetryAttempts int           `env:"OCI_RETRY_ATTEMPTS" envDefault:"3"`
+	OCIRetryBackoff  time.Duration `env:"OCI_RETRY_BACKOFF" envDefault:"500ms"`
+
+	// OutboundUserAgent is sent on requests to package registries and publisher domains, followed by
+	// OperatorContact (a URL or email address) so their operators can reach whoever runs this registry
+	OutboundUserAgent string `env:"OUTBOUND_USER_AGENT" envDefault:"mcp-registry/1.0"`
+	OperatorContact   string `env:"OPERATOR_CONTACT" envDefault:""`
+
+	// OutboundMinTLSVersion is the lowest TLS version, "1.2" or "1.3", negotiated with package registries and
+	// publisher domains
+	OutboundMinTLSVersion string `env:"OUTBOUND_MIN_TLS_VERSION" envDefault:"1.2"`
+
+	// WebhookURLs receive a JSON POST for every publish, edit and status change, signed with WebhookSecret.
+	// Deliveries are queued (up to WebhookQueueSize events) and retried WebhookRetryAttempts times.
+	WebhookURLs          []string      `env:"WEBHOOK_URLS" envSeparator:","`
+	WebhookSecret        string        `env:"WEBHOOK_SECRET" envDefault:""`
+	WebhookQueueSize     int           `env:"WEBHOOK_QUEUE_SIZE" envDefault:"100"`
+	WebhookRetryAttempts int           `env:"WEBHOOK_RETRY_ATTEMPTS" envDefault:"3"`
+	WebhookRetryBackoff  time.Duration `env:"WEBHOOK_RETRY_BACKOFF" envDefault:"1s"`
+
+	// Database connection pool tunables; zero keeps the built-in defaults (30 max, 5 min, 30m idle, 2h lifetime)
+	DBMaxConns        int32         `env:"DB_MAX_CONNS" envDefault:"0"`
+	DBMinConns        int32         `env:"DB_MIN_CONNS" envDefault:"0"`
+	DBMaxConnIdleTime time.Duration `env:"DB_MAX_CONN_IDLE_TIME" envDefault:"0s"`
+	DBMaxConnLifetime time.Duration `env:"DB_MAX_CONN_LIFETIME" envDefault:"0s"`
+
+	// DBConnectAttempts is how many times the database is pinged on startup before giving up, so the registry
+	// can start while the database is still coming up, and DBConnectRetryBackoff the delay before the first
+	// retry (doubling each time)
+	DBConnectAttempts     int           `env:"DB_CONNECT_ATTEMPTS" envDefault:"5"`
+	DBConnectRetryBackoff time.Duration `env:"DB_CONNECT_RETRY_BACKOFF" envDefault:"1s"`
+
+	// DBReadReplicaURI is an optional read replica serving list and get queries; empty sends all queries to DATABASE_URL
+	DBReadReplicaURI string `env:"DB_READ_REPLICA_URI" envDefault:""`
+
+	// RemoteURLReuseCooldown is how long a remote URL stays reserved for its previous server after
+	// every version using it has been deprecated or deleted. Zero disables reuse entirely.
+	RemoteURLReuseCooldown time.Duration `env:"REMOTE_URL_REUSE_COOLDOWN" envDefault:"0s"`
+
+	// DeletedVersionReuseCooldown is how long after a version is deleted its version string stays taken.
+	// Once it elapses, publishing the same version replaces the deleted one. Zero never frees deleted versions.
+	DeletedVersionReuseCooldown time.Duration `env:"DELETED_VERSION_REUSE_COOLDOWN" envDefault:"0s"`
+
+	// DefaultListLimit is the page size of list endpoints when the request sets no limit, and MaxListLimit
+	// the largest limit a request may ask for. DefaultListLimit must not exceed MaxListLimit.
+	DefaultListLimit int `env:"DEFAULT_LIST_LIMIT" envDefault:"30"`
+	MaxListLimit     int `env:"MAX_LIST_LIMIT" envDefault:"100"`
+
+	// CursorSigningKey is the secret list cursors are signed with, so clients cannot craft cursors. Instances
+	// serving the same clients must share it; when empty it is derived from JWTPrivateKey.
+	CursorSigningKey string `env:"CURSOR_SIGNING_KEY" envDefault:""`
+	// RequireSignedCursors rejects unsigned cursors issued before cursors were signed, rather than accepting
+	// them while clients migrate. Tampered signed cursors are always rejected.
+	RequireSignedCursors bool `env:"REQUIRE_SIGNED_CURSORS" envDefault:"false"`
+
+	// MaxVersionsPerServer caps how many versions can be published for a single server name
+	MaxVersionsPerServer int `env:"MAX_VERSIONS_PER_SERVER" envDefault:"10000"`
+
+	// MaxPackagesAndRemotes caps the combined number of packages and remotes in a single server.json
+	MaxPackagesAndRemotes int `env:"MAX_PACKAGES_AND_REMOTES" envDefault:"50"`
+
+	// MaxPackages, MaxRemotes and MaxServerJSONBytes cap the packages, remotes and serialized size of a single
+	// server.json. Zero keeps the defaults of 50 packages, 50 remotes and 512KB. The per-kind limits apply on
+	// top of MaxPackagesAndRemotes, so a set limit must not exceed it.
+	MaxPackages        int `env:"MAX_PACKAGES" envDefault:"0"`
+	MaxRemotes         int `env:"MAX_REMOTES" envDefault:"0"`
+	MaxServerJSONBytes int `env:"MAX_SERVER_JSON_BYTES" envDefault:"0"`
+
+	// MaxPublishBodyBytes caps the size of publish and edit request bodies, rejecting larger requests with 413.
+	// Zero keeps the default of 1MB.
+	MaxPublishBodyBytes int64 `env:"MAX_PUBLISH_BODY_BYTES" envDefault:"1048576"`
+
+	// AllowUnknownPublishFields drops fields server.json does not define from publish requests. By default they are
+	// rejected, naming the field, so typos such as "descripton" don't silently lose data.
+	AllowUnknownPublishFields bool `env:"ALLOW_UNKNOWN_PUBLISH_FIELDS" envDefault:"false"`
+
+	// EnableCompression gzips GET responses for clients that accept it once the body reaches CompressionMinBytes.
+	// Zero keeps the default threshold of 1KB.
+	EnableCompression   bool `env:"ENABLE_COMPRESSION" envDefault:"true"`
+	CompressionMinBytes int  `env:"COMPRESSION_MIN_BYTES" envDefault:"1024"`
+
+	// StatsCacheTTL is how long /v0/stats results are reused before being recomputed. Zero disables caching.
+	StatsCacheTTL time.Duration `env:"STATS_CACHE_TTL" envDefault:"30s"`
+
+	// FeedItemCount is the number of recent changes included in the Atom feed
+	FeedItemCount int `env:"FEED_ITEM_COUNT" envDefault:"50"`
+
+	// ReviewRequiredNamespaces lists namespaces (e.g. io.modelcontextprotocol) whose publishes are held as
+	// pending until an admin approves them. Sub-namespaces are covered too.
+	ReviewRequiredNamespaces []string `env:"REVIEW_REQUIRED_NAMESPACES" envSeparator:","`
+
+	// RequiredServerFields lists top-level server.json fields every published server must set, beyond
+	// name, description and version (one of RequirableServerFields)
+	RequiredServerFields []string `env:"REQUIRED_SERVER_FIELDS" envSeparator:","`
+
+	// PublishNamespaceAllowlist restricts publishing to servers whose namespace is one of these reverse-DNS
+	// namespaces or a subdomain of one (e.g. io.github.myorg), for bootstrapping a private registry. Empty
+	// allows any namespace.
+	PublishNamespaceAllowlist []string `env:"PUBLISH_NAMESPACE_ALLOWLIST" envSeparator:","`
+
+	// BlockedNamePatterns rejects publishing servers whose name matches one of these glob patterns, e.g. an exact
+	// name (com.example/server) or a whole namespace (com.example/*). Matching ignores case.
+	BlockedNamePatterns []string `env:"BLOCKED_NAME_PATTERNS" envSeparator:","`
+
+	// KnownCategories restricts server categories to this set; empty allows any well-formed category
+	KnownCategories []string `env:"KNOWN_CATEGORIES" envSeparator:","`
+
+	// StatusTransitions lists the allowed status changes as comma-separated "from:to" pairs.
+	// Setting a version to its current status is always allowed. Approving and rejecting versions pending
+	// review are the pending:active and pending:deleted pairs.
+	StatusTransitions string `env:"STATUS_TRANSITIONS" envDefault:"active:deprecated,active:deleted,deprecated:active,deprecated:deleted,pending:active,pending:deleted"`
+
 	// OIDC Configuration
 	OIDCEnabled      bool   `env:"OIDC_ENABLED" envDefault:"false"`
 	OIDCIssuer       string `env:"OIDC_ISSUER" envDefault:""`
@@ -24,6 +246,7 @@ type Config struct {
 	OIDCExtraClaims  string `env:"OIDC_EXTRA_CLAIMS" envDefault:""`
 	OIDCEditPerms    string `env:"OIDC_EDIT_PERMISSIONS" envDefault:""`
 	OIDCPublishPerms string `env:"OIDC_PUBLISH_PERMISSIONS" envDefault:""`
+	OIDCReadPerms    string `env:"OIDC_READ_PERMISSIONS" envDefault:""`
 }
 
 // NewConfig creates a new configuration with default values
@@ -37,3 +260,253 @@ func NewConfig() *Config {
 	}
 	return &cfg
 }
+
+// DefaultOutboundUserAgent is the User-Agent sent on outbound requests when OutboundUserAgent is unset
+const DefaultOutboundUserAgent = "mcp-registry/1.0"
+
+// UserAgent returns the User-Agent for outbound requests, e.g. "mcp-registry/1.0 (+https://example.com/contact)"
+func (c *Config) UserAgent() string {
+	userAgent := c.OutboundUserAgent
+	if userAgent == "" {
+		userAgent = DefaultOutboundUserAgent
+	}
+	if c.OperatorContact != "" {
+		userAgent += " (+" + c.OperatorContact + ")"
+	}
+	return userAgent
+}
+
+// MinTLSVersion returns the configured OutboundMinTLSVersion as a tls.Config version, TLS 1.2 if it is unset or
+// invalid
+func (c *Config) MinTLSVersion() uint16 {
+	version, err := ParseTLSVersion(c.OutboundMinTLSVersion)
+	if err != nil {
+		return tls.VersionTLS12
+	}
+	return version
+}
+
+// Validate checks that the configuration values are usable, so misconfiguration fails fast at startup
+func (c *Config) Validate() error {
+	if _, err := DecodeJWTPrivateKey(c.JWTPrivateKey); err != nil {
+		return fmt.Errorf("JWT_PRIVATE_KEY is invalid: %w", err)
+	}
+	for i, key := range c.JWTPreviousKeys {
+		if _, err := DecodeJWTPrivateKey(key); err != nil {
+			return fmt.Errorf("JWT_PREVIOUS_KEYS entry %d is invalid: %w", i+1, err)
+		}
+	}
+
+	if c.JWTTokenTTL < 0 {
+		return fmt.Errorf("JWT_TOKEN_TTL must not be negative, got %s", c.JWTTokenTTL)
+	}
+
+	if c.MaxVersionsPerServer <= 0 {
+		return fmt.Errorf("MAX_VERSIONS_PER_SERVER must be positive, got %d", c.MaxVersionsPerServer)
+	}
+	if c.MaxPackagesAndRemotes <= 0 {
+		return fmt.Errorf("MAX_PACKAGES_AND_REMOTES must be positive, got %d", c.MaxPackagesAndRemotes)
+	}
+	if c.MaxPackages < 0 || c.MaxRemotes < 0 || c.MaxServerJSONBytes < 0 {
+		return fmt.Errorf("MAX_PACKAGES, MAX_REMOTES and MAX_SERVER_JSON_BYTES must not be negative")
+	}
+	if c.MaxPackages > c.MaxPackagesAndRemotes {
+		return fmt.Errorf("MAX_PACKAGES (%d) must not exceed MAX_PACKAGES_AND_REMOTES (%d)", c.MaxPackages, c.MaxPackagesAndRemotes)
+	}
+	if c.MaxRemotes > c.MaxPackagesAndRemotes {
+		return fmt.Errorf("MAX_REMOTES (%d) must not exceed MAX_PACKAGES_AND_REMOTES (%d)", c.MaxRemotes, c.MaxPackagesAndRemotes)
+	}
+	if c.MaxPublishBodyBytes < 0 {
+		return fmt.Errorf("MAX_PUBLISH_BODY_BYTES must not be negative, got %d", c.MaxPublishBodyBytes)
+	}
+
+	if c.CompressionMinBytes < 0 {
+		return fmt.Errorf("COMPRESSION_MIN_BYTES must not be negative, got %d", c.CompressionMinBytes)
+	}
+
+	if c.DefaultListLimit < 0 || c.MaxListLimit < 0 {
+		return fmt.Errorf("DEFAULT_LIST_LIMIT and MAX_LIST_LIMIT must not be negative")
+	}
+	if c.MaxListLimit > 0 && c.DefaultListLimit > c.MaxListLimit {
+		return fmt.Errorf("DEFAULT_LIST_LIMIT (%d) must not exceed MAX_LIST_LIMIT (%d)", c.DefaultListLimit, c.MaxListLimit)
+	}
+
+	if c.DBMaxConns < 0 || c.DBMinConns < 0 {
+		return fmt.Errorf("DB_MAX_CONNS and DB_MIN_CONNS must not be negative")
+	}
+	if c.DBMaxConnIdleTime < 0 || c.DBMaxConnLifetime < 0 {
+		return fmt.Errorf("DB_MAX_CONN_IDLE_TIME and DB_MAX_CONN_LIFETIME must not be negative")
+	}
+	if c.DBConnectAttempts < 0 || c.DBConnectRetryBackoff < 0 {
+		return fmt.Errorf("DB_CONNECT_ATTEMPTS and DB_CONNECT_RETRY_BACKOFF must not be negative")
+	}
+
+	if c.MaxConcurrentPublishValidations < 0 || c.PublishValidationQueueTimeout < 0 {
+		return fmt.Errorf("MAX_CONCURRENT_PUBLISH_VALIDATIONS and PUBLISH_VALIDATION_QUEUE_TIMEOUT must not be negative")
+	}
+	if c.OCIRetryAttempts < 0 {
+		return fmt.Errorf("OCI_RETRY_ATTEMPTS must not be negative, got %d", c.OCIRetryAttempts)
+	}
+	if c.OCIRetryBackoff < 0 {
+		return fmt.Errorf("OCI_RETRY_BACKOFF must not be negative, got %s", c.OCIRetryBackoff)
+	}
+
+	if c.RegistryValidationTimeout < 0 || c.PublishDBTimeout < 0 {
+		return fmt.Errorf("REGISTRY_VALIDATION_TIMEOUT and PUBLISH_DB_TIMEOUT must not be negative")
+	}
+	for registryType, timeout := range c.RegistryValidationTimeouts {
+		knownType := slices.Contains([]string{model.RegistryTypeNPM, model.RegistryTypePyPI, model.RegistryTypeNuGet, model.RegistryTypeOCI, model.RegistryTypeMCPB}, registryType)
+		if !knownType || timeout <= 0 {
+			return fmt.Errorf("REGISTRY_VALIDATION_TIMEOUTS has an invalid entry %s:%s", registryType, timeout)
+		}
+	}
+
+	if c.RegistryBreakerThreshold < 0 || c.RegistryBreakerCooldown < 0 {
+		return fmt.Errorf("REGISTRY_BREAKER_THRESHOLD and REGISTRY_BREAKER_COOLDOWN must not be negative")
+	}
+
+	if c.ValidationCacheTTL < 0 {
+		return fmt.Errorf("VALIDATION_CACHE_TTL must not be negative, got %s", c.ValidationCacheTTL)
+	}
+
+	if c.StatsCacheTTL < 0 {
+		return fmt.Errorf("STATS_CACHE_TTL must not be negative, got %s", c.StatsCacheTTL)
+	}
+	if c.FeedItemCount < 0 {
+		return fmt.Errorf("FEED_ITEM_COUNT must not be negative, got %d", c.FeedItemCount)
+	}
+
+	for _, origin := range c.CORSAllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			return fmt.Errorf("CORS_ALLOWED_ORIGINS has an invalid origin %q, expected e.g. https://example.com", origin)
+		}
+	}
+
+	for _, field := range c.RequiredServerFields {
+		if !slices.Contains(RequirableServerFields, field) {
+			return fmt.Errorf("REQUIRED_SERVER_FIELDS contains unsupported field %q (supported: %s)", field, strings.Join(RequirableServerFields, ", "))
+		}
+	}
+
+	if c.HTTPAuthCABundle != "" {
+		if _, err := LoadCABundle(c.HTTPAuthCABundle); err != nil {
+			return fmt.Errorf("HTTP_AUTH_CA_BUNDLE is invalid: %w", err)
+		}
+	}
+
+	for _, pattern := range c.BlockedNamePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("BLOCKED_NAME_PATTERNS contains an invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	if _, err := ParseTLSVersion(c.OutboundMinTLSVersion); err != nil {
+		return fmt.Errorf("OUTBOUND_MIN_TLS_VERSION is invalid: %w", err)
+	}
+
+	if _, err := ParseStatusTransitions(c.StatusTransitions); err != nil {
+		return fmt.Errorf("STATUS_TRANSITIONS is invalid: %w", err)
+	}
+
+	return nil
+}
+
+// DecodeJWTPrivateKey decodes a hex-encoded Ed25519 seed, rejecting keys of the wrong size and trivially weak
+// keys such as all zeros
+func DecodeJWTPrivateKey(key string) ([]byte, error) {
+	seed, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("must be a valid hex-encoded string: %w", err)
+	}
+
+	// Require a valid Ed25519 seed (32 bytes)
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("seed must be exactly %d bytes for Ed25519, got %d bytes", ed25519.SeedSize, len(seed))
+	}
+
+	// A seed made of a single repeated byte (e.g. all zeros) is a placeholder, not a secret
+	if bytes.Count(seed, seed[:1]) == len(seed) {
+		return nil, fmt.Errorf("seed must not be a single repeated byte; generate one with `openssl rand -hex 32`")
+	}
+
+	return seed, nil
+}
+
+// LoadCABundle returns the system root CAs extended with the PEM certificates in the file at path
+func LoadCABundle(path string) (*x509.CertPool, error) {
+	pemData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pemData) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+// ParseTLSVersion parses a minimum TLS version of "1.2" or "1.3", defaulting to TLS 1.2 when empty. Older
+// versions are not accepted.
+func ParseTLSVersion(version string) (uint16, error) {
+	switch strings.TrimSpace(version) {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q: expected 1.2 or 1.3", version)
+	}
+}
+
+// NewOutboundTransport returns an HTTP transport for outbound requests that refuses TLS versions below
+// minVersion and verifies servers against rootCAs, or the system roots when nil. A zero minVersion uses TLS 1.2.
+func NewOutboundTransport(minVersion uint16, rootCAs *x509.CertPool) *http.Transport {
+	if minVersion == 0 {
+		minVersion = tls.VersionTLS12
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: minVersion,
+		RootCAs:    rootCAs,
+	}
+	return transport
+}
+
+// ParseStatusTransitions parses comma-separated "from:to" status pairs into a map of allowed target statuses
+func ParseStatusTransitions(spec string) (map[model.Status][]model.Status, error) {
+	transitions := make(map[model.Status][]model.Status)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("expected \"from:to\", got %q", pair)
+		}
+		fromStatus, toStatus := model.Status(strings.TrimSpace(from)), model.Status(strings.TrimSpace(to))
+		for _, status := range []model.Status{fromStatus, toStatus} {
+			if !isKnownStatus(status) {
+				return nil, fmt.Errorf("unknown status %q in %q", status, pair)
+			}
+		}
+		transitions[fromStatus] = append(transitions[fromStatus], toStatus)
+	}
+	return transitions, nil
+}
+
+func isKnownStatus(status model.Status) bool {
+	switch status {
+	case model.StatusActive, model.StatusDeprecated, model.StatusDeleted, model.StatusPending:
+		return true
+	default:
+		return false
+	}
+}
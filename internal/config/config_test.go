@@ -0,0 +1,217 @@
+package config_test
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestDecodeJWTPrivateKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		expectError string
+	}{
+		{
+			name: "valid key",
+			key:  "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+		},
+		{
+			name:        "not hex",
+			key:         "not-a-hex-key",
+			expectError: "must be a valid hex-encoded string",
+		},
+		{
+			name:        "too short",
+			key:         "0102030405060708",
+			expectError: "seed must be exactly 32 bytes for Ed25519, got 8 bytes",
+		},
+		{
+			name:        "empty",
+			key:         "",
+			expectError: "seed must be exactly 32 bytes",
+		},
+		{
+			name:        "all zeros",
+			key:         strings.Repeat("00", 32),
+			expectError: "must not be a single repeated byte",
+		},
+		{
+			name:        "single repeated byte",
+			key:         strings.Repeat("ab", 32),
+			expectError: "must not be a single repeated byte",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seed, err := config.DecodeJWTPrivateKey(tt.key)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, seed, 32)
+		})
+	}
+}
+
+func TestConfigValidate_JWTPrivateKey(t *testing.T) {
+	cfg := &config.Config{MaxVersionsPerServer: 1, MaxPackagesAndRemotes: 1, JWTPrivateKey: strings.Repeat("00", 32)}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "JWT_PRIVATE_KEY is invalid")
+
+	cfg.JWTPrivateKey = "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfigValidate_RequiredServerFields(t *testing.T) {
+	cfg := &config.Config{
+		MaxVersionsPerServer:  1,
+		MaxPackagesAndRemotes: 1,
+		JWTPrivateKey:         "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+		RequiredServerFields:  []string{"repository", "websiteUrl"},
+	}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.RequiredServerFields = []string{"repository", "homepage"}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported field "homepage"`)
+}
+
+func TestConfigValidate_OutboundMinTLSVersion(t *testing.T) {
+	cfg := &config.Config{
+		MaxVersionsPerServer:  1,
+		MaxPackagesAndRemotes: 1,
+		JWTPrivateKey:         "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+	}
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, uint16(tls.VersionTLS12), cfg.MinTLSVersion())
+
+	cfg.OutboundMinTLSVersion = "1.3"
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, uint16(tls.VersionTLS13), cfg.MinTLSVersion())
+
+	cfg.OutboundMinTLSVersion = "1.0"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "OUTBOUND_MIN_TLS_VERSION is invalid")
+}
+
+func TestConfigValidate_HTTPAuthCABundle(t *testing.T) {
+	cfg := &config.Config{
+		MaxVersionsPerServer:  1,
+		MaxPackagesAndRemotes: 1,
+		JWTPrivateKey:         "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+		HTTPAuthCABundle:      filepath.Join(t.TempDir(), "missing.pem"),
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP_AUTH_CA_BUNDLE is invalid: failed to read CA bundle")
+
+	notPEM := filepath.Join(t.TempDir(), "not.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+	cfg.HTTPAuthCABundle = notPEM
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no PEM certificates found")
+}
+
+func TestConfigValidate_ListLimits(t *testing.T) {
+	cfg := &config.Config{
+		MaxVersionsPerServer:  1,
+		MaxPackagesAndRemotes: 1,
+		JWTPrivateKey:         "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+		DefaultListLimit:      30,
+		MaxListLimit:          100,
+	}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.DefaultListLimit = 200
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DEFAULT_LIST_LIMIT (200) must not exceed MAX_LIST_LIMIT (100)")
+}
+
+func TestConfigValidate_PackageAndRemoteLimits(t *testing.T) {
+	cfg := &config.Config{
+		MaxVersionsPerServer:  1,
+		MaxPackagesAndRemotes: 20,
+		JWTPrivateKey:         "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+		MaxPackages:           20,
+		MaxRemotes:            5,
+	}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxPackages = 21
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MAX_PACKAGES (21) must not exceed MAX_PACKAGES_AND_REMOTES (20)")
+
+	cfg.MaxPackages = 0
+	cfg.MaxRemotes = 30
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MAX_REMOTES (30) must not exceed MAX_PACKAGES_AND_REMOTES (20)")
+}
+
+func TestConfigValidate_CORSAllowedOrigins(t *testing.T) {
+	for _, tt := range []struct {
+		origin      string
+		expectError bool
+	}{
+		{"*", false},
+		{"https://explorer.example.com", false},
+		{"http://localhost:3000/", false},
+		{"explorer.example.com", true},
+		{"https://explorer.example.com/app", true},
+	} {
+		t.Run(tt.origin, func(t *testing.T) {
+			cfg := &config.Config{
+				MaxVersionsPerServer:  1,
+				MaxPackagesAndRemotes: 1,
+				JWTPrivateKey:         "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+				CORSAllowedOrigins:    []string{tt.origin},
+			}
+			err := cfg.Validate()
+			if tt.expectError {
+				assert.ErrorContains(t, err, "CORS_ALLOWED_ORIGINS")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigUserAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.Config
+		expected string
+	}{
+		{name: "unset", cfg: config.Config{}, expected: "mcp-registry/1.0"},
+		{name: "custom", cfg: config.Config{OutboundUserAgent: "example-mirror/2.0"}, expected: "example-mirror/2.0"},
+		{
+			name:     "with contact",
+			cfg:      config.Config{OutboundUserAgent: "example-mirror/2.0", OperatorContact: "https://example.com/contact"},
+			expected: "example-mirror/2.0 (+https://example.com/contact)",
+		},
+		{name: "contact only", cfg: config.Config{OperatorContact: "ops@example.com"}, expected: "mcp-registry/1.0 (+ops@example.com)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.cfg.UserAgent())
+		})
+	}
+}
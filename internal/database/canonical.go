@@ -0,0 +1,97 @@
+package database
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// CanonicalJSON serializes v into canonical JSON for storage and hashing: object keys are sorted, there is
+// no insignificant whitespace, HTML characters are not escaped and numbers use their shortest form
+// (1.0 and 1e0 both become 1). Logically equal documents always produce identical bytes, unlike API
+// responses, which keep struct field order.
+func CanonicalJSON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode into generic values, keeping numbers as written so they can be normalized without losing precision
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	generic, err = canonicalizeNumbers(generic)
+	if err != nil {
+		return nil, err
+	}
+
+	// Maps are encoded with sorted keys
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(generic); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// DocumentDigest returns the hex-encoded SHA-256 digest of v's canonical JSON
+func DocumentDigest(v any) (string, error) {
+	data, err := CanonicalJSON(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalizeNumbers rewrites every number in a decoded document into its shortest form
+func canonicalizeNumbers(v any) (any, error) {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, item := range value {
+			canonical, err := canonicalizeNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = canonical
+		}
+	case []any:
+		for i, item := range value {
+			canonical, err := canonicalizeNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = canonical
+		}
+	case json.Number:
+		return canonicalNumber(value)
+	}
+	return v, nil
+}
+
+// canonicalNumber formats integers without a fraction or exponent, and other numbers in their shortest
+// round-tripping form
+func canonicalNumber(n json.Number) (json.Number, error) {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return json.Number(strconv.FormatInt(i, 10)), nil
+	}
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid number %q: %w", n, err)
+	}
+	if f == 0 {
+		return "0", nil
+	}
+	if f == math.Trunc(f) && math.Abs(f) < 1e15 {
+		return json.Number(strconv.FormatFloat(f, 'f', -1, 64)), nil
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+}
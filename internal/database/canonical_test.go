@@ -0,0 +1,60 @@
+package database_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	decode := func(t *testing.T, data string) any {
+		t.Helper()
+		var v any
+		require.NoError(t, json.Unmarshal([]byte(data), &v))
+		return v
+	}
+
+	t.Run("sorts keys regardless of input order", func(t *testing.T) {
+		first, err := database.CanonicalJSON(decode(t, `{"name":"com.example/server","version":"1.0.0","_meta":{"b":1,"a":[{"y":true,"x":null}]}}`))
+		require.NoError(t, err)
+		second, err := database.CanonicalJSON(decode(t, `{"_meta":{"a":[{"x":null,"y":true}],"b":1},"version":"1.0.0","name":"com.example/server"}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, string(first), string(second))
+		assert.Equal(t, `{"_meta":{"a":[{"x":null,"y":true}],"b":1},"name":"com.example/server","version":"1.0.0"}`, string(first))
+	})
+
+	t.Run("normalizes numbers", func(t *testing.T) {
+		data, err := database.CanonicalJSON(json.RawMessage(`{"a":1.0,"b":1e2,"c":-0.0,"d":0.50,"e":12345678901234567890}`))
+		require.NoError(t, err)
+		assert.Equal(t, `{"a":1,"b":100,"c":0,"d":0.5,"e":1.2345678901234567e+19}`, string(data))
+	})
+
+	t.Run("does not escape HTML", func(t *testing.T) {
+		data, err := database.CanonicalJSON(map[string]string{"description": "Tools <for> A & B"})
+		require.NoError(t, err)
+		assert.Equal(t, `{"description":"Tools <for> A & B"}`, string(data))
+	})
+
+	t.Run("digests identify content", func(t *testing.T) {
+		server := apiv0.ServerJSON{Name: "com.example/server", Description: "A server", Version: "1.0.0"}
+		data, err := json.Marshal(server)
+		require.NoError(t, err)
+
+		fromStruct, err := database.DocumentDigest(server)
+		require.NoError(t, err)
+		fromDocument, err := database.DocumentDigest(decode(t, string(data)))
+		require.NoError(t, err)
+		assert.Equal(t, fromStruct, fromDocument)
+
+		server.Version = "1.0.1"
+		changed, err := database.DocumentDigest(server)
+		require.NoError(t, err)
+		assert.NotEqual(t, fromStruct, changed)
+	})
+}
@@ -0,0 +1,69 @@
+//nolint:testpackage
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPingWithRetry(t *testing.T) {
+	errNotReady := errors.New("connection refused")
+
+	// flakyPing fails the first failures times it is called
+	flakyPing := func(failures int) (func(context.Context) error, *int) {
+		calls := 0
+		return func(context.Context) error {
+			calls++
+			if calls <= failures {
+				return errNotReady
+			}
+			return nil
+		}, &calls
+	}
+
+	t.Run("connects once the database is ready", func(t *testing.T) {
+		ping, calls := flakyPing(2)
+		err := pingWithRetry(context.Background(), ping, 5, time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("gives up after the configured attempts", func(t *testing.T) {
+		ping, calls := flakyPing(10)
+		err := pingWithRetry(context.Background(), ping, 3, time.Millisecond)
+		assert.ErrorIs(t, err, errNotReady)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("zero attempts pings once", func(t *testing.T) {
+		ping, calls := flakyPing(1)
+		err := pingWithRetry(context.Background(), ping, 0, time.Millisecond)
+		assert.ErrorIs(t, err, errNotReady)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("stops waiting when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		ping, calls := flakyPing(10)
+		start := time.Now()
+		err := pingWithRetry(ctx, ping, 5, time.Minute)
+		assert.ErrorIs(t, err, errNotReady)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, *calls)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+}
+
+func TestPoolOptionsConnectTimeout(t *testing.T) {
+	assert.Equal(t, 10*time.Second, PoolOptions{}.ConnectTimeout(10*time.Second))
+
+	// Three attempts of 10s, with 1s and then 2s of backoff between them
+	opts := PoolOptions{ConnectAttempts: 3, ConnectRetryBackoff: time.Second}
+	assert.Equal(t, 33*time.Second, opts.ConnectTimeout(10*time.Second))
+}
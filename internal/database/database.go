@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -11,22 +12,101 @@ import (
 
 // Common database errors
 var (
-	ErrNotFound          = errors.New("record not found")
-	ErrAlreadyExists     = errors.New("record already exists")
-	ErrInvalidInput      = errors.New("invalid input")
-	ErrDatabase          = errors.New("database error")
-	ErrInvalidVersion    = errors.New("invalid version: cannot publish duplicate version")
-	ErrMaxServersReached = errors.New("maximum number of versions for this server reached (10000): please reach out at https://github.com/modelcontextprotocol/registry to explain your use case")
+	ErrNotFound           = errors.New("record not found")
+	ErrAlreadyExists      = errors.New("record already exists")
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrDatabase           = errors.New("database error")
+	ErrInvalidVersion     = errors.New("invalid version: cannot publish duplicate version")
+	ErrMaxServersReached  = errors.New("maximum number of versions for this server reached")
+	ErrDuplicateRemoteURL = errors.New("remote URL is already used by another server")
 )
 
+// MaxVersionsError reports that a server already has the maximum number of versions allowed
+type MaxVersionsError struct {
+	Count int
+	Limit int
+}
+
+func (e *MaxVersionsError) Error() string {
+	return fmt.Sprintf("%s (%d of %d allowed): please reach out at https://github.com/modelcontextprotocol/registry to explain your use case", ErrMaxServersReached, e.Count, e.Limit)
+}
+
+// Unwrap allows errors.Is(err, ErrMaxServersReached) to match a MaxVersionsError
+func (e *MaxVersionsError) Unwrap() error {
+	return ErrMaxServersReached
+}
+
+// DuplicateRemoteURLError reports that a remote URL is already used by a different server
+type DuplicateRemoteURLError struct {
+	URL        string
+	ServerName string
+}
+
+func (e *DuplicateRemoteURLError) Error() string {
+	return fmt.Sprintf("remote URL %s is already used by server %s", e.URL, e.ServerName)
+}
+
+// Unwrap allows errors.Is(err, ErrDuplicateRemoteURL) to match a DuplicateRemoteURLError
+func (e *DuplicateRemoteURLError) Unwrap() error {
+	return ErrDuplicateRemoteURL
+}
+
+// ServerSort defines the ordering of server list results
+type ServerSort string
+
+const (
+	// SortByName orders by server name then version (the default)
+	SortByName ServerSort = "name"
+	// SortByVersionCount orders servers with the most versions first
+	SortByVersionCount ServerSort = "version_count"
+	// SortByRecent orders the most recently published versions first
+	SortByRecent ServerSort = "recent"
+	// SortByChange orders versions by when their last change was committed, oldest first, for change feeds.
+	// Versions written by transactions that have not ended yet are left out until they have.
+	SortByChange ServerSort = "change"
+	// SortByRecentlyUpdated orders the most recently updated versions first
+	SortByRecentlyUpdated ServerSort = "recently_updated"
+)
+
+// IsValid reports whether the sort is one of the allowed orderings
+func (s ServerSort) IsValid() bool {
+	switch s {
+	case "", SortByName, SortByVersionCount, SortByRecent, SortByChange, SortByRecentlyUpdated:
+		return true
+	}
+	return false
+}
+
 // ServerFilter defines filtering options for server queries
 type ServerFilter struct {
-	Name          *string    // for finding versions of same server
-	RemoteURL     *string    // for duplicate URL detection
-	UpdatedSince  *time.Time // for incremental sync filtering
-	SubstringName *string    // for substring search on name
-	Version       *string    // for exact version matching
-	IsLatest      *bool      // for filtering latest versions only
+	Name              *string    // for finding versions of same server
+	RemoteURL         *string    // for duplicate URL detection (ignores case and trailing slashes, so callers should compare exactly)
+	UpdatedSince      *time.Time // for incremental sync filtering
+	UpdatedBefore     *time.Time // for bounding incremental sync to a time window
+	Status            *string    // for filtering versions by status (active, deprecated, deleted or pending)
+	SubstringName     *string    // for substring search on name
+	Version           *string    // for exact version matching
+	IsLatest          *bool      // for filtering latest versions only
+	Category          *string    // for filtering servers declaring a category
+	MaintainerEmail   *string    // for finding servers listing a maintainer with this email address (ignores case)
+	RepositoryURL     *string    // for finding servers hosted in the same repository
+	HasRepository     *bool      // for filtering servers by whether they declare a source repository URL
+	OriginRegistry    *string    // for filtering by the registry servers were imported from ("" for native servers)
+	PublishedBy       *string    // for filtering by the subject of the token versions were published with
+	RegistryBaseURL   *string    // for filtering servers with a package hosted on this registry (OCI packages default to Docker Hub)
+	PackageIdentifier *string    // for finding servers with a package with this identifier, e.g. an npm package name
+	Verified          *bool      // for filtering versions by whether package ownership was proven at publish
+	Sort              ServerSort // for ordering results (empty means SortByName)
+	IncludeUnlisted   bool       // for including servers hidden from list results
+	ExcludeDeleted    bool       // for hiding deleted servers from public list results
+}
+
+// ServerPage is one page of list results, with the cursors of the pages after and before it. The cursors are
+// empty when there is no such page.
+type ServerPage struct {
+	Servers    []*apiv0.ServerResponse
+	NextCursor string
+	PrevCursor string
 }
 
 // Database defines the interface for database operations
@@ -37,23 +117,57 @@ type Database interface {
 	UpdateServer(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
 	// SetServerStatus updates the status of a specific server version
 	SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status string) (*apiv0.ServerResponse, error)
+	// SetServerUnlisted updates whether a specific server version is hidden from list results
+	SetServerUnlisted(ctx context.Context, tx pgx.Tx, serverName, version string, unlisted bool) (*apiv0.ServerResponse, error)
+	// SetServerVerified records when package ownership of a specific server version was proven, or clears it when nil
+	SetServerVerified(ctx context.Context, tx pgx.Tx, serverName, version string, verifiedAt *time.Time) (*apiv0.ServerResponse, error)
+	// SetServerDeprecation updates the deprecation message and successor of a specific server version
+	SetServerDeprecation(ctx context.Context, tx pgx.Tx, serverName, version, message, supersededBy string) (*apiv0.ServerResponse, error)
 	// ListServers retrieve server entries with optional filtering
 	ListServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
+	// ListServersPage retrieve a page of server entries with optional filtering, paging backwards for cursors
+	// taken from a page's PrevCursor
+	ListServersPage(ctx context.Context, tx pgx.Tx, filter *ServerFilter, cursor string, limit int) (*ServerPage, error)
 	// GetServerByName retrieve a single server by its name
 	GetServerByName(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error)
 	// GetServerByNameAndVersion retrieve specific version of a server by server name and version
 	GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx, serverName string, version string) (*apiv0.ServerResponse, error)
+	// GetRawServer retrieve specific version of a server as stored, with its undecoded value
+	GetRawServer(ctx context.Context, tx pgx.Tx, serverName string, version string) (*apiv0.RawServer, error)
 	// GetAllVersionsByServerName retrieve all versions of a server by server name
 	GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx, serverName string) ([]*apiv0.ServerResponse, error)
 	// GetCurrentLatestVersion retrieve the current latest version of a server by server name
 	GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error)
+	// GetServerStats counts servers by the status of their latest version, along with the total number of versions
+	GetServerStats(ctx context.Context, tx pgx.Tx) (*apiv0.ServerStats, error)
+	// ListServerNames retrieve the names of all servers, in name order
+	ListServerNames(ctx context.Context, tx pgx.Tx) ([]string, error)
 	// CountServerVersions count the number of versions for a server
 	CountServerVersions(ctx context.Context, tx pgx.Tx, serverName string) (int, error)
 	// CheckVersionExists check if a specific version exists for a server
 	CheckVersionExists(ctx context.Context, tx pgx.Tx, serverName, version string) (bool, error)
+	// FindServerNameIgnoringCase retrieve the stored name of a server whose name matches regardless of case
+	FindServerNameIgnoringCase(ctx context.Context, tx pgx.Tx, serverName string) (string, error)
+	// CheckVersionsExist report which of the given versions exist for a server
+	CheckVersionsExist(ctx context.Context, tx pgx.Tx, serverName string, versions []string) (map[string]bool, error)
+	// DeleteServerVersion permanently removes a specific server version
+	DeleteServerVersion(ctx context.Context, tx pgx.Tx, serverName, version string) error
+	// PurgeDeleted permanently removes deleted versions last updated before a cutoff, returning the server name of
+	// each removed version
+	PurgeDeleted(ctx context.Context, tx pgx.Tx, before time.Time) ([]string, error)
 	// UnmarkAsLatest marks the current latest version of a server as no longer latest
 	UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error
-	// AcquirePublishLock acquires an exclusive advisory lock for publishing a server
+	// MarkAsLatest marks a specific server version as the latest version
+	MarkAsLatest(ctx context.Context, tx pgx.Tx, serverName, version string) (*apiv0.ServerResponse, error)
+	// CreateAlias stores an alias resolving an old server name to another server name
+	CreateAlias(ctx context.Context, tx pgx.Tx, alias *apiv0.ServerAlias) error
+	// GetAliasTarget retrieve the server name an alias resolves to
+	GetAliasTarget(ctx context.Context, tx pgx.Tx, alias string) (string, error)
+	// CreateAuditEntry appends an entry to the audit log
+	CreateAuditEntry(ctx context.Context, tx pgx.Tx, entry *apiv0.AuditEntry) error
+	// ListAuditEntries retrieve audit log entries, newest first, optionally only those for one server
+	ListAuditEntries(ctx context.Context, tx pgx.Tx, serverName string, cursor string, limit int) ([]*apiv0.AuditEntry, string, error)
+	// AcquirePublishLock acquires an exclusive advisory lock for publishing a server, shared by names differing only in case
 	// This prevents race conditions when multiple versions are published concurrently
 	AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverName string) error
 	// InTransaction executes a function within a database transaction
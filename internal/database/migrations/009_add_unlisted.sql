@@ -0,0 +1,2 @@
+-- Allow servers to be resolvable by name while hidden from list and search results
+ALTER TABLE servers ADD COLUMN unlisted BOOLEAN NOT NULL DEFAULT FALSE;
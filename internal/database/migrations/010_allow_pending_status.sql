@@ -0,0 +1,4 @@
+-- Allow the pending status, held by versions in namespaces that require review until an admin approves them
+ALTER TABLE servers DROP CONSTRAINT IF EXISTS check_status_valid;
+ALTER TABLE servers ADD CONSTRAINT check_status_valid
+CHECK (status IN ('active', 'deprecated', 'deleted', 'pending'));
@@ -0,0 +1,2 @@
+-- Record which registry a mirrored server was imported from; empty means this registry is the origin
+ALTER TABLE servers ADD COLUMN origin_registry TEXT NOT NULL DEFAULT '';
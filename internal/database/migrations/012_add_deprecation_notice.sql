@@ -0,0 +1,3 @@
+-- Let deprecated versions explain why they were deprecated and name the server that replaces them
+ALTER TABLE servers ADD COLUMN deprecation_message TEXT NOT NULL DEFAULT '';
+ALTER TABLE servers ADD COLUMN superseded_by TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,12 @@
+-- Append-only record of mutating operations: who changed which server version, and whether it succeeded
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    server_name TEXT NOT NULL,
+    version TEXT NOT NULL DEFAULT '',
+    result TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_server_name ON audit_log (server_name, id DESC);
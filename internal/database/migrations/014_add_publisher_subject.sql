@@ -0,0 +1,4 @@
+-- Record the subject of the token each version was published with; empty for imports and versions published earlier
+ALTER TABLE servers ADD COLUMN publisher_subject TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_servers_publisher_subject ON servers (publisher_subject) WHERE publisher_subject <> '';
@@ -0,0 +1,3 @@
+-- Record when package ownership of each version was last proven against its registries; NULL when it never was,
+-- e.g. because registry validation was disabled or skipped
+ALTER TABLE servers ADD COLUMN verified_at TIMESTAMPTZ;
@@ -0,0 +1,6 @@
+-- Old server names that resolve to a renamed server, e.g. after a GitHub organization rename
+CREATE TABLE aliases (
+    alias TEXT PRIMARY KEY,
+    target TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,9 @@
+-- Serve package containment filters (value->'packages' @> '[{"identifier": ...}]') with a jsonb_path_ops GIN
+-- index, which is smaller and faster for @> than the default jsonb_ops index it replaces. Package filters only
+-- use containment, so the jsonb_ops index is no longer needed.
+--
+-- To revert:
+--   CREATE INDEX IF NOT EXISTS idx_servers_json_packages ON servers USING GIN ((value->'packages'));
+--   DROP INDEX IF EXISTS idx_servers_packages_path_ops;
+CREATE INDEX IF NOT EXISTS idx_servers_packages_path_ops ON servers USING GIN ((value->'packages') jsonb_path_ops);
+DROP INDEX IF EXISTS idx_servers_json_packages;
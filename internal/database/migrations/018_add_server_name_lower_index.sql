@@ -0,0 +1,3 @@
+-- Serve case-insensitive server name lookups, used at publish time to treat names differing only in case as
+-- the same server
+CREATE INDEX IF NOT EXISTS idx_servers_name_lower ON servers (lower(server_name));
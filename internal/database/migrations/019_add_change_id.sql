@@ -0,0 +1,38 @@
+-- Stamp every insert and update of a version with the transaction that wrote it and a change sequence number,
+-- for the changes feed. Sequence numbers are allocated before commit, so on their own a version committed late
+-- could fall behind a cursor; the feed therefore only returns versions written by transactions that have ended,
+-- ordered by (change_xid, change_id).
+CREATE SEQUENCE servers_change_id_seq;
+
+ALTER TABLE servers ADD COLUMN change_xid xid8;
+ALTER TABLE servers ADD COLUMN change_id BIGINT;
+
+-- Number existing versions in the order they were last updated
+UPDATE servers
+SET change_xid = pg_current_xact_id(), change_id = ordered.change_id
+FROM (
+    SELECT server_name, version, ROW_NUMBER() OVER (ORDER BY updated_at, server_name, version) AS change_id
+    FROM servers
+) ordered
+WHERE servers.server_name = ordered.server_name AND servers.version = ordered.version;
+
+SELECT setval('servers_change_id_seq', COALESCE((SELECT MAX(change_id) FROM servers), 0) + 1, false);
+
+ALTER TABLE servers ALTER COLUMN change_xid SET NOT NULL;
+ALTER TABLE servers ALTER COLUMN change_id SET NOT NULL;
+
+CREATE OR REPLACE FUNCTION stamp_server_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.change_xid = pg_current_xact_id();
+    NEW.change_id = nextval('servers_change_id_seq');
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER stamp_server_change
+    BEFORE INSERT OR UPDATE ON servers
+    FOR EACH ROW
+    EXECUTE FUNCTION stamp_server_change();
+
+CREATE INDEX idx_servers_change ON servers (change_xid, change_id);
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +22,8 @@ import (
 // PostgreSQL is an implementation of the Database interface using PostgreSQL
 type PostgreSQL struct {
 	pool *pgxpool.Pool
+	// replica serves reads made outside a transaction; it is the primary pool when no read replica is configured
+	replica *pgxpool.Pool
 }
 
 // Executor is an interface for executing queries (satisfied by both pgx.Tx and pgxpool.Pool)
@@ -37,19 +41,164 @@ func (db *PostgreSQL) getExecutor(tx pgx.Tx) Executor {
 	return db.pool
 }
 
-// NewPostgreSQL creates a new instance of the PostgreSQL database
-func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, error) {
-	// Parse connection config for pool settings
+// getReadExecutor returns the executor for read-only queries: the transaction if there is one, so reads inside
+// it see its own writes, and the read replica pool otherwise
+func (db *PostgreSQL) getReadExecutor(tx pgx.Tx) Executor {
+	if tx != nil {
+		return tx
+	}
+	return db.replica
+}
+
+// serverColumns are the columns of a full server row, in the order scanServer expects them
+const serverColumns = "server_name, version, status, published_at, updated_at, is_latest, unlisted, origin_registry, publisher_subject, verified_at, deprecation_message, superseded_by, value"
+
+// scanServer scans a row selected with serverColumns, followed by any extra columns, into a ServerResponse
+func scanServer(row pgx.Row, extra ...any) (*apiv0.ServerResponse, error) {
+	var serverName, version, status, originRegistry, publisherSubject, deprecationMessage, supersededBy string
+	var publishedAt, updatedAt time.Time
+	var verifiedAt *time.Time
+	var isLatest, unlisted bool
+	var valueJSON []byte
+
+	dest := append([]any{&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &unlisted, &originRegistry, &publisherSubject, &verifiedAt, &deprecationMessage, &supersededBy, &valueJSON}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	// Parse the ServerJSON from JSONB
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+
+	// Build ServerResponse with separated metadata
+	return &apiv0.ServerResponse{
+		Server: serverJSON,
+		Meta: apiv0.ResponseMeta{
+			Official: &apiv0.RegistryExtensions{
+				Status:             model.Status(status),
+				PublishedAt:        publishedAt,
+				UpdatedAt:          updatedAt,
+				IsLatest:           isLatest,
+				Unlisted:           unlisted,
+				OriginRegistry:     originRegistry,
+				PublisherSubject:   publisherSubject,
+				Verified:           verifiedAt != nil,
+				VerifiedAt:         verifiedAt,
+				DeprecationMessage: deprecationMessage,
+				SupersededBy:       supersededBy,
+			},
+		},
+	}, nil
+}
+
+// Default connection pool settings, used when PoolOptions leaves a value unset
+const (
+	defaultMaxConns        = 30               // Handle good concurrent load
+	defaultMinConns        = 5                // Keep connections warm for fast response
+	defaultMaxConnIdleTime = 30 * time.Minute // Keep connections available for bursts
+	defaultMaxConnLifetime = 2 * time.Hour    // Refresh connections regularly for stability
+)
+
+// PoolOptions overrides the connection pool defaults; zero values keep the default
+type PoolOptions struct {
+	MaxConns        int32
+	MinConns        int32
+	MaxConnIdleTime time.Duration
+	MaxConnLifetime time.Duration
+	// ReadReplicaURI, when set, opens a second pool with the same settings for reads made outside a transaction
+	ReadReplicaURI string
+	// ConnectAttempts is how many times the database is pinged on startup before giving up, waiting
+	// ConnectRetryBackoff before the first retry (doubling each time), so the registry can start before the
+	// database is ready. Zero pings once.
+	ConnectAttempts     int
+	ConnectRetryBackoff time.Duration
+}
+
+// defaultConnectRetryBackoff is used when PoolOptions sets ConnectAttempts but not ConnectRetryBackoff
+const defaultConnectRetryBackoff = time.Second
+
+// pingWithRetry calls ping until it succeeds or has been tried attempts times, waiting an exponentially growing
+// backoff between tries, and giving up early if the context is cancelled
+func pingWithRetry(ctx context.Context, ping func(context.Context) error, attempts int, backoff time.Duration) error {
+	if backoff <= 0 {
+		backoff = defaultConnectRetryBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := ping(ctx)
+		if err == nil || attempt >= attempts || ctx.Err() != nil {
+			return err
+		}
+
+		delay := backoff << (attempt - 1)
+		log.Printf("Database not ready (attempt %d of %d), retrying in %s: %v", attempt, attempts, delay, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (gave up waiting for the database: %w)", err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// ConnectTimeout returns how long connecting may take when each ping is allowed perAttempt, including the
+// backoff between retries
+func (opts PoolOptions) ConnectTimeout(perAttempt time.Duration) time.Duration {
+	backoff := opts.ConnectRetryBackoff
+	if backoff <= 0 {
+		backoff = defaultConnectRetryBackoff
+	}
+
+	timeout := perAttempt
+	for attempt := 1; attempt < opts.ConnectAttempts; attempt++ {
+		timeout += perAttempt + backoff<<(attempt-1)
+	}
+	return timeout
+}
+
+// NewPoolConfig parses the connection URI and applies the pool settings
+func NewPoolConfig(connectionURI string, opts PoolOptions) (*pgxpool.Config, error) {
 	config, err := pgxpool.ParseConfig(connectionURI)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse PostgreSQL config: %w", err)
 	}
 
 	// Configure pool for stability-focused defaults
-	config.MaxConns = 30                      // Handle good concurrent load
-	config.MinConns = 5                       // Keep connections warm for fast response
-	config.MaxConnIdleTime = 30 * time.Minute // Keep connections available for bursts
-	config.MaxConnLifetime = 2 * time.Hour    // Refresh connections regularly for stability
+	config.MaxConns = defaultMaxConns
+	config.MinConns = defaultMinConns
+	config.MaxConnIdleTime = defaultMaxConnIdleTime
+	config.MaxConnLifetime = defaultMaxConnLifetime
+
+	if opts.MaxConns > 0 {
+		config.MaxConns = opts.MaxConns
+	}
+	if opts.MinConns > 0 {
+		config.MinConns = opts.MinConns
+	}
+	if opts.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = opts.MaxConnIdleTime
+	}
+	if opts.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = opts.MaxConnLifetime
+	}
+
+	if config.MinConns > config.MaxConns {
+		return nil, fmt.Errorf("minimum connections (%d) cannot exceed maximum connections (%d)", config.MinConns, config.MaxConns)
+	}
+
+	return config, nil
+}
+
+// NewPostgreSQL creates a new instance of the PostgreSQL database
+func NewPostgreSQL(ctx context.Context, connectionURI string, opts PoolOptions) (*PostgreSQL, error) {
+	// Parse connection config for pool settings
+	config, err := NewPoolConfig(connectionURI, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create connection pool with configured settings
 	pool, err := pgxpool.NewWithConfig(ctx, config)
@@ -57,28 +206,72 @@ func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, erro
 		return nil, fmt.Errorf("failed to create PostgreSQL pool: %w", err)
 	}
 
-	// Test the connection
-	if err = pool.Ping(ctx); err != nil {
+	// Test the connection, waiting for the database if it is still starting up
+	if err = pingWithRetry(ctx, pool.Ping, opts.ConnectAttempts, opts.ConnectRetryBackoff); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
 	}
 
-	// Run migrations using a single connection from the pool
+	// Run migrations using a single connection from the pool. It must be released before the pool can be
+	// closed, as closing waits for every acquired connection.
 	conn, err := pool.Acquire(ctx)
 	if err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("failed to acquire connection for migrations: %w", err)
 	}
-	defer conn.Release()
-
-	migrator := NewMigrator(conn.Conn())
-	if err := migrator.Migrate(ctx); err != nil {
+	err = NewMigrator(conn.Conn()).Migrate(ctx)
+	conn.Release()
+	if err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("failed to run database migrations: %w", err)
 	}
 
+	replica := pool
+	if opts.ReadReplicaURI != "" {
+		replica, err = newReadReplicaPool(ctx, opts)
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+	}
+
 	return &PostgreSQL{
-		pool: pool,
+		pool:    pool,
+		replica: replica,
 	}, nil
 }
 
+// newReadReplicaPool connects to the read replica; migrations are not run, as they replicate from the primary
+func newReadReplicaPool(ctx context.Context, opts PoolOptions) (*pgxpool.Pool, error) {
+	config, err := NewPoolConfig(opts.ReadReplicaURI, opts)
+	if err != nil {
+		return nil, fmt.Errorf("read replica: %w", err)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PostgreSQL read replica pool: %w", err)
+	}
+
+	if err = pingWithRetry(ctx, pool.Ping, opts.ConnectAttempts, opts.ConnectRetryBackoff); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to ping PostgreSQL read replica: %w", err)
+	}
+
+	return pool, nil
+}
+
+// PoolStats returns a snapshot of connection pool usage
+func (db *PostgreSQL) PoolStats() *pgxpool.Stat {
+	return db.pool.Stat()
+}
+
+// ReadReplicaPoolStats returns a snapshot of read replica pool usage, which is the primary pool's when no read
+// replica is configured
+func (db *PostgreSQL) ReadReplicaPoolStats() *pgxpool.Stat {
+	return db.replica.Stat()
+}
+
 func (db *PostgreSQL) ListServers(
 	ctx context.Context,
 	tx pgx.Tx,
@@ -86,14 +279,35 @@ func (db *PostgreSQL) ListServers(
 	cursor string,
 	limit int,
 ) ([]*apiv0.ServerResponse, string, error) {
+	page, err := db.ListServersPage(ctx, tx, filter, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return page.Servers, page.NextCursor, nil
+}
+
+// backwardCursorPrefix marks cursors that page backwards, as handed out in a page's PrevCursor
+const backwardCursorPrefix = "<"
+
+// ListServersPage lists a page of servers following the cursor, or preceding it for backward cursors. Backward
+// pages are queried in the mirrored order and reversed, so both directions present results in the same order.
+func (db *PostgreSQL) ListServersPage(
+	ctx context.Context,
+	tx pgx.Tx,
+	filter *ServerFilter,
+	cursor string,
+	limit int,
+) (*ServerPage, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 
 	if ctx.Err() != nil {
-		return nil, "", ctx.Err()
+		return nil, ctx.Err()
 	}
 
+	cursor, backward := strings.CutPrefix(cursor, backwardCursorPrefix)
+
 	// Build WHERE clause for filtering using dedicated columns
 	var whereConditions []string
 	args := []any{}
@@ -107,8 +321,9 @@ func (db *PostgreSQL) ListServers(
 			argIndex++
 		}
 		if filter.RemoteURL != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements(value->'remotes') AS remote WHERE remote->>'url' = $%d)", argIndex))
-			args = append(args, *filter.RemoteURL)
+			// Match loosely so URLs differing only in host case or trailing slashes are found
+			whereConditions = append(whereConditions, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements(value->'remotes') AS remote WHERE lower(rtrim(remote->>'url', '/')) = lower($%d))", argIndex))
+			args = append(args, strings.TrimRight(*filter.RemoteURL, "/"))
 			argIndex++
 		}
 		if filter.UpdatedSince != nil {
@@ -116,6 +331,16 @@ func (db *PostgreSQL) ListServers(
 			args = append(args, *filter.UpdatedSince)
 			argIndex++
 		}
+		if filter.UpdatedBefore != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("updated_at < $%d", argIndex))
+			args = append(args, *filter.UpdatedBefore)
+			argIndex++
+		}
+		if filter.Status != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+			args = append(args, *filter.Status)
+			argIndex++
+		}
 		if filter.SubstringName != nil {
 			whereConditions = append(whereConditions, fmt.Sprintf("server_name ILIKE $%d", argIndex))
 			args = append(args, "%"+*filter.SubstringName+"%")
@@ -131,26 +356,95 @@ func (db *PostgreSQL) ListServers(
 			args = append(args, *filter.IsLatest)
 			argIndex++
 		}
+		if filter.RepositoryURL != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("value->'repository'->>'url' = $%d", argIndex))
+			args = append(args, *filter.RepositoryURL)
+			argIndex++
+		}
+		if filter.HasRepository != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("(COALESCE(value->'repository'->>'url', '') <> '') = $%d", argIndex))
+			args = append(args, *filter.HasRepository)
+			argIndex++
+		}
+		if filter.Category != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("value->'categories' ? $%d", argIndex))
+			args = append(args, *filter.Category)
+			argIndex++
+		}
+		if filter.MaintainerEmail != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM jsonb_array_elements(COALESCE(value->'maintainers', '[]'::jsonb)) AS maintainer WHERE lower(maintainer->>'email') = lower($%d))",
+				argIndex))
+			args = append(args, *filter.MaintainerEmail)
+			argIndex++
+		}
+		if filter.OriginRegistry != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("origin_registry = $%d", argIndex))
+			args = append(args, *filter.OriginRegistry)
+			argIndex++
+		}
+		if filter.PublishedBy != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("publisher_subject = $%d", argIndex))
+			args = append(args, *filter.PublishedBy)
+			argIndex++
+		}
+		if filter.Verified != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("(verified_at IS NOT NULL) = $%d", argIndex))
+			args = append(args, *filter.Verified)
+			argIndex++
+		}
+		if filter.PackageIdentifier != nil {
+			// Containment is served by the jsonb_path_ops index on value->'packages'
+			containment, err := json.Marshal([]map[string]string{{"identifier": *filter.PackageIdentifier}})
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode package identifier filter: %w", err)
+			}
+			whereConditions = append(whereConditions, fmt.Sprintf("value->'packages' @> $%d::jsonb", argIndex))
+			args = append(args, string(containment))
+			argIndex++
+		}
+		if filter.RegistryBaseURL != nil {
+			// OCI packages without a registry base URL are hosted on Docker Hub, as the OCI validator assumes
+			whereConditions = append(whereConditions, fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM jsonb_array_elements(value->'packages') AS pkg WHERE COALESCE(NULLIF(pkg->>'registryBaseUrl', ''), CASE WHEN pkg->>'registryType' = $%d THEN $%d END) = $%d)",
+				argIndex, argIndex+1, argIndex+2))
+			args = append(args, model.RegistryTypeOCI, model.RegistryURLDocker, *filter.RegistryBaseURL)
+			argIndex += 3
+		}
 	}
 
-	// Add cursor pagination using compound serverName:version cursor
-	if cursor != "" {
-		// Parse cursor format: "serverName:version"
-		parts := strings.SplitN(cursor, ":", 2)
-		if len(parts) == 2 {
-			cursorServerName := parts[0]
-			cursorVersion := parts[1]
+	// Unlisted servers and versions pending review are resolvable by name but hidden from list results
+	// unless explicitly requested
+	if filter == nil || !filter.IncludeUnlisted {
+		whereConditions = append(whereConditions, "unlisted = false", "status <> 'pending'")
+	}
+	if filter != nil && filter.ExcludeDeleted {
+		whereConditions = append(whereConditions, "status <> 'deleted'")
+	}
 
-			// Use compound condition: (server_name > cursor_name) OR (server_name = cursor_name AND version > cursor_version)
-			whereConditions = append(whereConditions, fmt.Sprintf("(server_name > $%d OR (server_name = $%d AND version > $%d))", argIndex, argIndex+1, argIndex+2))
-			args = append(args, cursorServerName, cursorServerName, cursorVersion)
-			argIndex += 3
-		} else {
-			// Fallback for malformed cursor - treat as server name only for backwards compatibility
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name > $%d", argIndex))
-			args = append(args, cursor)
-			argIndex++
+	sort := SortByName
+	if filter != nil && filter.Sort != "" {
+		sort = filter.Sort
+	}
+	if !sort.IsValid() {
+		return nil, fmt.Errorf("%w: unsupported sort %q", ErrInvalidInput, sort)
+	}
+
+	// Transactions still running when this query started may commit changes that sort before the ones it sees,
+	// so change feeds stop at the oldest of them rather than page past changes that are not visible yet
+	if sort == SortByChange {
+		whereConditions = append(whereConditions, "change_xid < pg_snapshot_xmin(pg_current_snapshot())")
+	}
+
+	// Add cursor pagination matching the requested ordering
+	if cursor != "" {
+		condition, cursorArgs, err := buildCursorCondition(sort, cursor, argIndex, backward)
+		if err != nil {
+			return nil, err
 		}
+		whereConditions = append(whereConditions, condition)
+		args = append(args, cursorArgs...)
+		argIndex += len(cursorArgs)
 	}
 
 	// Build the WHERE clause
@@ -159,68 +453,231 @@ func (db *PostgreSQL) ListServers(
 		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
+	// Sorting by version count needs the per-server count joined onto each row
+	fromClause := "servers"
+	versionCountColumn := "0"
+	orderClause := "server_name, version"
+	switch sort {
+	case SortByVersionCount:
+		fromClause = "servers JOIN (SELECT server_name, COUNT(*) AS version_count FROM servers GROUP BY server_name) counts USING (server_name)"
+		versionCountColumn = "version_count"
+		orderClause = "version_count DESC, server_name, version"
+	case SortByRecent:
+		orderClause = "published_at DESC, server_name, version"
+	case SortByChange:
+		orderClause = "change_xid, change_id"
+	case SortByRecentlyUpdated:
+		orderClause = "updated_at DESC, server_name, version"
+	case SortByName:
+	}
+	if backward {
+		orderClause = reverseOrderClause(orderClause)
+	}
+
 	// Query servers table with hybrid column/JSON data
 	query := fmt.Sprintf(`
-        SELECT server_name, version, status, published_at, updated_at, is_latest, value
-        FROM servers
+        SELECT %s, %s, change_xid::text, change_id
+        FROM %s
         %s
-        ORDER BY server_name, version
+        ORDER BY %s
         LIMIT $%d
-    `, whereClause, argIndex)
+    `, serverColumns, versionCountColumn, fromClause, whereClause, orderClause, argIndex)
 	args = append(args, limit)
 
-	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	rows, err := db.getReadExecutor(tx).Query(ctx, query, args...)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to query servers: %w", err)
+		return nil, fmt.Errorf("failed to query servers: %w", err)
 	}
 	defer rows.Close()
 
 	var results []*apiv0.ServerResponse
+	var keys []sortKeys
 	for rows.Next() {
-		var serverName, version, status string
-		var publishedAt, updatedAt time.Time
-		var isLatest bool
-		var valueJSON []byte
+		var key sortKeys
+		serverResponse, err := scanServer(rows, &key.versionCount, &key.changeXID, &key.changeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan server row: %w", err)
+		}
+		results = append(results, serverResponse)
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if backward {
+		slices.Reverse(results)
+		slices.Reverse(keys)
+	}
+
+	// A full page may have more results beyond it in the direction it was read. Paging forward from a cursor
+	// left results behind it, and paging backward left the cursor's own result and those after it.
+	// Change feeds always get a next cursor so clients can resume polling from the last change seen.
+	page := &ServerPage{Servers: results}
+	if len(results) == 0 {
+		return page, nil
+	}
+	full := len(results) >= limit
+	hasNext := full || sort == SortByChange
+	hasPrev := cursor != ""
+	if backward {
+		hasNext, hasPrev = true, full
+	}
 
-		err := rows.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	if hasNext {
+		last := len(results) - 1
+		page.NextCursor, err = pageCursor(sort, results[last], keys[last])
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to scan server row: %w", err)
+			return nil, err
+		}
+	}
+	if hasPrev {
+		prevCursor, err := pageCursor(sort, results[0], keys[0])
+		if err != nil {
+			return nil, err
+		}
+		page.PrevCursor = backwardCursorPrefix + prevCursor
+	}
+
+	return page, nil
+}
+
+// sortKeys are the columns a result can be ordered by that are not part of the server response
+type sortKeys struct {
+	versionCount int
+	changeXID    string
+	changeID     int64
+}
+
+// pageCursor encodes the sort tuple of a result as the cursor to page from it in the given ordering
+func pageCursor(sort ServerSort, result *apiv0.ServerResponse, keys sortKeys) (string, error) {
+	cursorParts := []string{result.Server.Name, result.Server.Version}
+	switch sort {
+	case SortByVersionCount:
+		cursorParts = append([]string{strconv.Itoa(keys.versionCount)}, cursorParts...)
+	case SortByRecent:
+		cursorParts = append([]string{strconv.FormatInt(result.Meta.Official.PublishedAt.UnixNano(), 10)}, cursorParts...)
+	case SortByChange:
+		cursorParts = []string{keys.changeXID, strconv.FormatInt(keys.changeID, 10)}
+	case SortByRecentlyUpdated:
+		cursorParts = append([]string{strconv.FormatInt(result.Meta.Official.UpdatedAt.UnixNano(), 10)}, cursorParts...)
+	case SortByName:
+	}
+	return encodeCursor(cursorParts)
+}
+
+// reverseOrderClause flips the direction of every column of an ORDER BY clause, for reading a page backwards
+func reverseOrderClause(orderClause string) string {
+	columns := strings.Split(orderClause, ", ")
+	for i, column := range columns {
+		if name, descending := strings.CutSuffix(column, " DESC"); descending {
+			columns[i] = name
+		} else {
+			columns[i] = column + " DESC"
 		}
+	}
+	return strings.Join(columns, ", ")
+}
 
-		// Parse the ServerJSON from JSONB
-		var serverJSON apiv0.ServerJSON
-		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-			return nil, "", fmt.Errorf("failed to unmarshal server JSON: %w", err)
+// encodeCursor encodes the sort tuple of the last result of a page as a JSON array, which unlike a
+// separator-joined string round-trips server names and versions containing any character
+func encodeCursor(parts []string) (string, error) {
+	data, err := json.Marshal(parts)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeCursor splits a cursor into its sort tuple. Cursors are JSON arrays as written by encodeCursor;
+// earlier, colon-separated cursors are still accepted.
+func decodeCursor(cursor string, size int) []string {
+	if strings.HasPrefix(cursor, "[") {
+		var parts []string
+		if err := json.Unmarshal([]byte(cursor), &parts); err == nil {
+			return parts
 		}
+	}
+	return strings.SplitN(cursor, ":", size)
+}
 
-		// Build ServerResponse with separated metadata
-		serverResponse := &apiv0.ServerResponse{
-			Server: serverJSON,
-			Meta: apiv0.ResponseMeta{
-				Official: &apiv0.RegistryExtensions{
-					Status:      model.Status(status),
-					PublishedAt: publishedAt,
-					UpdatedAt:   updatedAt,
-					IsLatest:    isLatest,
-				},
-			},
+// buildCursorCondition builds the keyset pagination condition for a cursor in the given ordering, matching the
+// rows after the cursor, or before it when paging backward.
+// Name-sorted cursors are [serverName, version] and change cursors are [changeXID, changeID]; other orderings
+// prefix the sort key, e.g. [versionCount, serverName, version] or [publishedAtUnixNano, serverName, version].
+// Timestamps are encoded as Unix nanoseconds, which round-trip PostgreSQL's microsecond precision exactly.
+func buildCursorCondition(sort ServerSort, cursor string, argIndex int, backward bool) (string, []any, error) {
+	// Ascending columns continue with greater values and descending ones with smaller values, mirrored backwards
+	ascending, descending := ">", "<"
+	if backward {
+		ascending, descending = descending, ascending
+	}
+
+	if sort == SortByChange {
+		parts := decodeCursor(cursor, 2)
+		if len(parts) != 2 {
+			return "", nil, fmt.Errorf("%w: malformed cursor for sort %q", ErrInvalidInput, sort)
+		}
+		changeXID := parts[0]
+		changeID, err := strconv.ParseInt(parts[1], 10, 64)
+		if _, xidErr := strconv.ParseUint(changeXID, 10, 64); xidErr != nil || err != nil {
+			return "", nil, fmt.Errorf("%w: malformed cursor for sort %q", ErrInvalidInput, sort)
 		}
+		// Transaction IDs are passed as text, which pgx encodes without knowing the xid8 type
+		condition := fmt.Sprintf("(change_xid %s $%d::text::xid8 OR (change_xid = $%d::text::xid8 AND change_id %s $%d))", ascending, argIndex, argIndex+1, ascending, argIndex+2)
+		return condition, []any{changeXID, changeXID, changeID}, nil
+	}
 
-		results = append(results, serverResponse)
+	if sort == SortByName || sort == "" {
+		parts := decodeCursor(cursor, 2)
+		if len(parts) == 2 {
+			cursorServerName := parts[0]
+			cursorVersion := parts[1]
+
+			// Use compound condition: (server_name > cursor_name) OR (server_name = cursor_name AND version > cursor_version)
+			condition := fmt.Sprintf("(server_name %s $%d OR (server_name = $%d AND version %s $%d))", ascending, argIndex, argIndex+1, ascending, argIndex+2)
+			return condition, []any{cursorServerName, cursorServerName, cursorVersion}, nil
+		}
+		// Fallback for malformed cursor - treat as server name only for backwards compatibility
+		return fmt.Sprintf("server_name %s $%d", ascending, argIndex), []any{cursor}, nil
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, "", fmt.Errorf("error iterating rows: %w", err)
+	parts := decodeCursor(cursor, 3)
+	if len(parts) != 3 {
+		return "", nil, fmt.Errorf("%w: malformed cursor for sort %q", ErrInvalidInput, sort)
 	}
+	sortKey, cursorServerName, cursorVersion := parts[0], parts[1], parts[2]
+	nameCondition := fmt.Sprintf("(server_name %s $%d OR (server_name = $%d AND version %s $%d))", ascending, argIndex+2, argIndex+2, ascending, argIndex+3)
 
-	// Determine next cursor using compound serverName:version format
-	nextCursor := ""
-	if len(results) > 0 && len(results) >= limit {
-		lastResult := results[len(results)-1]
-		nextCursor = lastResult.Server.Name + ":" + lastResult.Server.Version
+	switch sort {
+	case SortByVersionCount:
+		count, err := strconv.Atoi(sortKey)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: malformed cursor for sort %q", ErrInvalidInput, sort)
+		}
+		condition := fmt.Sprintf("(version_count %s $%d OR (version_count = $%d AND %s))", descending, argIndex, argIndex+1, nameCondition)
+		return condition, []any{count, count, cursorServerName, cursorVersion}, nil
+	case SortByRecent:
+		nanos, err := strconv.ParseInt(sortKey, 10, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: malformed cursor for sort %q", ErrInvalidInput, sort)
+		}
+		publishedAt := time.Unix(0, nanos)
+		condition := fmt.Sprintf("(published_at %s $%d OR (published_at = $%d AND %s))", descending, argIndex, argIndex+1, nameCondition)
+		return condition, []any{publishedAt, publishedAt, cursorServerName, cursorVersion}, nil
+	case SortByRecentlyUpdated:
+		nanos, err := strconv.ParseInt(sortKey, 10, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: malformed cursor for sort %q", ErrInvalidInput, sort)
+		}
+		updatedAt := time.Unix(0, nanos)
+		condition := fmt.Sprintf("(updated_at %s $%d OR (updated_at = $%d AND %s))", descending, argIndex, argIndex+1, nameCondition)
+		return condition, []any{updatedAt, updatedAt, cursorServerName, cursorVersion}, nil
+	case SortByName, SortByChange:
 	}
 
-	return results, nextCursor, nil
+	return "", nil, fmt.Errorf("%w: unsupported sort %q", ErrInvalidInput, sort)
 }
 
 // GetServerByName retrieves the latest version of a server by server name
@@ -230,19 +687,14 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT ` + serverColumns + `
 		FROM servers
 		WHERE server_name = $1 AND is_latest = true
 		ORDER BY published_at DESC
 		LIMIT 1
 	`
 
-	var name, version, status string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-	var valueJSON []byte
-
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	serverResponse, err := scanServer(db.getReadExecutor(tx).QueryRow(ctx, query, serverName))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -250,25 +702,6 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 		return nil, fmt.Errorf("failed to get server by name: %w", err)
 	}
 
-	// Parse the ServerJSON from JSONB
-	var serverJSON apiv0.ServerJSON
-	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
-	}
-
-	// Build ServerResponse with separated metadata
-	serverResponse := &apiv0.ServerResponse{
-		Server: serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
-	}
-
 	return serverResponse, nil
 }
 
@@ -279,18 +712,13 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT ` + serverColumns + `
 		FROM servers
 		WHERE server_name = $1 AND version = $2
 		LIMIT 1
 	`
 
-	var name, vers, status string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-	var valueJSON []byte
-
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	serverResponse, err := scanServer(db.getReadExecutor(tx).QueryRow(ctx, query, serverName, version))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -298,26 +726,38 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 		return nil, fmt.Errorf("failed to get server by name and version: %w", err)
 	}
 
-	// Parse the ServerJSON from JSONB
-	var serverJSON apiv0.ServerJSON
-	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	return serverResponse, nil
+}
+
+// GetRawServer retrieves a specific version of a server as stored, without decoding its value. It reads from the
+// primary, as it is used to debug what was actually written.
+func (db *PostgreSQL) GetRawServer(ctx context.Context, tx pgx.Tx, serverName string, version string) (*apiv0.RawServer, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 
-	// Build ServerResponse with separated metadata
-	serverResponse := &apiv0.ServerResponse{
-		Server: serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
+	query := `
+		SELECT ` + serverColumns + `
+		FROM servers
+		WHERE server_name = $1 AND version = $2
+	`
+
+	var raw apiv0.RawServer
+	columns := &raw.Columns
+	var value []byte
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(
+		&columns.ServerName, &columns.Version, &columns.Status, &columns.PublishedAt, &columns.UpdatedAt,
+		&columns.IsLatest, &columns.Unlisted, &columns.OriginRegistry, &columns.PublisherSubject, &columns.VerifiedAt,
+		&columns.DeprecationMessage, &columns.SupersededBy, &value)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get raw server: %w", err)
 	}
+	raw.Value = value
 
-	return serverResponse, nil
+	return &raw, nil
 }
 
 // GetAllVersionsByServerName retrieves all versions of a server by server name
@@ -327,13 +767,13 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT ` + serverColumns + `
 		FROM servers
 		WHERE server_name = $1
 		ORDER BY published_at DESC
 	`
 
-	rows, err := db.getExecutor(tx).Query(ctx, query, serverName)
+	rows, err := db.getReadExecutor(tx).Query(ctx, query, serverName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query server versions: %w", err)
 	}
@@ -341,35 +781,10 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 
 	var results []*apiv0.ServerResponse
 	for rows.Next() {
-		var name, version, status string
-		var publishedAt, updatedAt time.Time
-		var isLatest bool
-		var valueJSON []byte
-
-		err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		serverResponse, err := scanServer(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan server row: %w", err)
 		}
-
-		// Parse the ServerJSON from JSONB
-		var serverJSON apiv0.ServerJSON
-		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
-		}
-
-		// Build ServerResponse with separated metadata
-		serverResponse := &apiv0.ServerResponse{
-			Server: serverJSON,
-			Meta: apiv0.ResponseMeta{
-				Official: &apiv0.RegistryExtensions{
-					Status:      model.Status(status),
-					PublishedAt: publishedAt,
-					UpdatedAt:   updatedAt,
-					IsLatest:    isLatest,
-				},
-			},
-		}
-
 		results = append(results, serverResponse)
 	}
 
@@ -399,16 +814,16 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 		return nil, fmt.Errorf("server name and version are required")
 	}
 
-	// Marshal the ServerJSON to JSONB
-	valueJSON, err := json.Marshal(serverJSON)
+	// Serialize the ServerJSON as canonical JSON for JSONB
+	valueJSON, err := CanonicalJSON(serverJSON)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
 	}
 
 	// Insert the new server version using composite primary key
 	insertQuery := `
-		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, value)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, unlisted, origin_registry, publisher_subject, verified_at, value)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	_, err = db.getExecutor(tx).Exec(ctx, insertQuery,
@@ -418,6 +833,10 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 		officialMeta.PublishedAt,
 		officialMeta.UpdatedAt,
 		officialMeta.IsLatest,
+		officialMeta.Unlisted,
+		officialMeta.OriginRegistry,
+		officialMeta.PublisherSubject,
+		officialMeta.VerifiedAt,
 		valueJSON,
 	)
 
@@ -453,7 +872,7 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 	}
 
 	// Marshal updated ServerJSON
-	valueJSON, err := json.Marshal(serverJSON)
+	valueJSON, err := CanonicalJSON(serverJSON)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal updated server: %w", err)
 	}
@@ -463,14 +882,9 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		UPDATE servers
 		SET value = $1, updated_at = NOW()
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, published_at, updated_at, is_latest
-	`
-
-	var name, vers, status string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
+		RETURNING ` + serverColumns
 
-	err = db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest)
+	serverResponse, err := scanServer(db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -478,19 +892,6 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		return nil, fmt.Errorf("failed to update server: %w", err)
 	}
 
-	// Return the updated ServerResponse
-	serverResponse := &apiv0.ServerResponse{
-		Server: *serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
-	}
-
 	return serverResponse, nil
 }
 
@@ -500,20 +901,16 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 		return nil, ctx.Err()
 	}
 
-	// Update the status column
+	// Update the status column, clearing any deprecation notice once the version is no longer deprecated
 	query := `
 		UPDATE servers
-		SET status = $1, updated_at = NOW()
+		SET status = $1::text, updated_at = NOW(),
+			deprecation_message = CASE WHEN $1::text = 'deprecated' THEN deprecation_message ELSE '' END,
+			superseded_by = CASE WHEN $1::text = 'deprecated' THEN superseded_by ELSE '' END
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, value, published_at, updated_at, is_latest
-	`
+		RETURNING ` + serverColumns
 
-	var name, vers, currentStatus string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-	var valueJSON []byte
-
-	err := db.getExecutor(tx).QueryRow(ctx, query, status, serverName, version).Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest)
+	serverResponse, err := scanServer(db.getExecutor(tx).QueryRow(ctx, query, status, serverName, version))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -521,23 +918,73 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 		return nil, fmt.Errorf("failed to update server status: %w", err)
 	}
 
-	// Unmarshal the JSON data
-	var serverJSON apiv0.ServerJSON
-	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	return serverResponse, nil
+}
+
+// SetServerUnlisted updates whether a specific server version is hidden from list results
+func (db *PostgreSQL) SetServerUnlisted(ctx context.Context, tx pgx.Tx, serverName, version string, unlisted bool) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 
-	// Return the updated ServerResponse
-	serverResponse := &apiv0.ServerResponse{
-		Server: serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(currentStatus),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
+	query := `
+		UPDATE servers
+		SET unlisted = $1, updated_at = NOW()
+		WHERE server_name = $2 AND version = $3
+		RETURNING ` + serverColumns
+
+	serverResponse, err := scanServer(db.getExecutor(tx).QueryRow(ctx, query, unlisted, serverName, version))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update server visibility: %w", err)
+	}
+
+	return serverResponse, nil
+}
+
+// SetServerVerified records when package ownership of a specific server version was proven, or clears it when nil
+func (db *PostgreSQL) SetServerVerified(ctx context.Context, tx pgx.Tx, serverName, version string, verifiedAt *time.Time) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE servers
+		SET verified_at = $1
+		WHERE server_name = $2 AND version = $3
+		RETURNING ` + serverColumns
+
+	serverResponse, err := scanServer(db.getExecutor(tx).QueryRow(ctx, query, verifiedAt, serverName, version))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update server verification: %w", err)
+	}
+
+	return serverResponse, nil
+}
+
+// SetServerDeprecation updates the deprecation message and successor of a specific server version
+func (db *PostgreSQL) SetServerDeprecation(ctx context.Context, tx pgx.Tx, serverName, version, message, supersededBy string) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE servers
+		SET deprecation_message = $1, superseded_by = $2, updated_at = NOW()
+		WHERE server_name = $3 AND version = $4
+		RETURNING ` + serverColumns
+
+	serverResponse, err := scanServer(db.getExecutor(tx).QueryRow(ctx, query, message, supersededBy, serverName, version))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update server deprecation: %w", err)
 	}
 
 	return serverResponse, nil
@@ -576,12 +1023,13 @@ func (db *PostgreSQL) InTransaction(ctx context.Context, fn func(ctx context.Con
 // AcquirePublishLock acquires an exclusive advisory lock for publishing a server
 // This prevents race conditions when multiple versions are published concurrently
 // Using pg_advisory_xact_lock which auto-releases on transaction end
+// Names differing only in case share a lock, as publishing either resolves to the same server
 func (db *PostgreSQL) AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverName string) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
 
-	lockID := hashServerName(serverName)
+	lockID := hashServerName(strings.ToLower(serverName))
 
 	if _, err := db.getExecutor(tx).Exec(ctx, "SELECT pg_advisory_xact_lock($1)", lockID); err != nil {
 		return fmt.Errorf("failed to acquire publish lock: %w", err)
@@ -615,19 +1063,12 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 	executor := db.getExecutor(tx)
 
 	query := `
-		SELECT server_name, version, status, value, published_at, updated_at, is_latest
+		SELECT ` + serverColumns + `
 		FROM servers
 		WHERE server_name = $1 AND is_latest = true
 	`
 
-	row := executor.QueryRow(ctx, query, serverName)
-
-	var name, version, status string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-	var jsonValue []byte
-
-	err := row.Scan(&name, &version, &status, &jsonValue, &publishedAt, &updatedAt, &isLatest)
+	serverResponse, err := scanServer(executor.QueryRow(ctx, query, serverName))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -635,24 +1076,6 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 		return nil, fmt.Errorf("failed to scan server row: %w", err)
 	}
 
-	// Parse the JSON value to get the server details
-	var serverJSON apiv0.ServerJSON
-	if err := json.Unmarshal(jsonValue, &serverJSON); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
-	}
-
-	// Build ServerResponse with separated metadata
-	serverResponse := &apiv0.ServerResponse{
-		Server: serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
-	}
-
 	return serverResponse, nil
 }
 
@@ -675,6 +1098,111 @@ func (db *PostgreSQL) CountServerVersions(ctx context.Context, tx pgx.Tx, server
 	return count, nil
 }
 
+// GetServerStats counts servers by the status of their latest version, along with the total number of versions
+func (db *PostgreSQL) GetServerStats(ctx context.Context, tx pgx.Tx) (*apiv0.ServerStats, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+
+	query := `
+		SELECT status, COUNT(*) FILTER (WHERE is_latest), COUNT(*)
+		FROM servers
+		GROUP BY status
+	`
+
+	rows, err := executor.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &apiv0.ServerStats{}
+	for rows.Next() {
+		var status string
+		var latestCount, versionCount int
+		if err := rows.Scan(&status, &latestCount, &versionCount); err != nil {
+			return nil, fmt.Errorf("failed to scan server stats row: %w", err)
+		}
+
+		switch model.Status(status) {
+		case model.StatusActive:
+			stats.Active = latestCount
+		case model.StatusDeprecated:
+			stats.Deprecated = latestCount
+		case model.StatusDeleted:
+			stats.Deleted = latestCount
+		}
+		stats.TotalServers += latestCount
+		stats.TotalVersions += versionCount
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server stats rows: %w", err)
+	}
+
+	return stats, nil
+}
+
+// ListServerNames returns the names of all servers, including those whose versions are all unlisted or
+// pending, in name order
+func (db *PostgreSQL) ListServerNames(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT DISTINCT server_name FROM servers ORDER BY server_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server names: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan server name: %w", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server names: %w", err)
+	}
+
+	return names, nil
+}
+
+// PurgeDeleted permanently removes deleted versions last updated before a cutoff, for data retention. It returns
+// the server name of each removed version, so callers can repair the latest flag of the affected servers.
+func (db *PostgreSQL) PurgeDeleted(ctx context.Context, tx pgx.Tx, before time.Time) ([]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `DELETE FROM servers WHERE status = 'deleted' AND updated_at < $1 RETURNING server_name`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted servers: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan purged server name: %w", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating purged servers: %w", err)
+	}
+
+	return names, nil
+}
+
 // CheckVersionExists checks if a specific version exists for a server
 func (db *PostgreSQL) CheckVersionExists(ctx context.Context, tx pgx.Tx, serverName, version string) (bool, error) {
 	if ctx.Err() != nil {
@@ -694,6 +1222,90 @@ func (db *PostgreSQL) CheckVersionExists(ctx context.Context, tx pgx.Tx, serverN
 	return exists, nil
 }
 
+// FindServerNameIgnoringCase returns the stored name of a server whose name equals serverName regardless of case,
+// preferring an exact match, or ErrNotFound if there is none
+func (db *PostgreSQL) FindServerNameIgnoringCase(ctx context.Context, tx pgx.Tx, serverName string) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	query := `
+		SELECT server_name FROM servers
+		WHERE lower(server_name) = lower($1)
+		ORDER BY server_name = $1 DESC, published_at
+		LIMIT 1
+	`
+
+	var name string
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).Scan(&name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("failed to find server name: %w", err)
+	}
+
+	return name, nil
+}
+
+// CheckVersionsExist reports which of the given versions exist for a server, in a single query. Every requested
+// version is present in the result.
+func (db *PostgreSQL) CheckVersionsExist(ctx context.Context, tx pgx.Tx, serverName string, versions []string) (map[string]bool, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	existing := make(map[string]bool, len(versions))
+	for _, version := range versions {
+		existing[version] = false
+	}
+	if len(versions) == 0 {
+		return existing, nil
+	}
+
+	executor := db.getExecutor(tx)
+
+	query := `SELECT version FROM servers WHERE server_name = $1 AND version = ANY($2)`
+
+	rows, err := executor.Query(ctx, query, serverName, versions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check versions existence: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to scan version: %w", err)
+		}
+		existing[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating versions: %w", err)
+	}
+
+	return existing, nil
+}
+
+// DeleteServerVersion permanently removes a specific server version
+func (db *PostgreSQL) DeleteServerVersion(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `DELETE FROM servers WHERE server_name = $1 AND version = $2`
+
+	result, err := db.getExecutor(tx).Exec(ctx, query, serverName, version)
+	if err != nil {
+		return fmt.Errorf("failed to delete server version: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
 // UnmarkAsLatest marks the current latest version of a server as no longer latest
 func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error {
 	if ctx.Err() != nil {
@@ -712,8 +1324,160 @@ func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName
 	return nil
 }
 
+// MarkAsLatest marks a specific server version as the latest version
+func (db *PostgreSQL) MarkAsLatest(ctx context.Context, tx pgx.Tx, serverName, version string) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE servers
+		SET is_latest = true
+		WHERE server_name = $1 AND version = $2
+		RETURNING ` + serverColumns
+
+	serverResponse, err := scanServer(db.getExecutor(tx).QueryRow(ctx, query, serverName, version))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to mark latest version: %w", err)
+	}
+
+	return serverResponse, nil
+}
+
+// CreateAlias stores an alias resolving an old server name to another server name, setting its creation time.
+// It returns ErrAlreadyExists if the alias is already defined.
+func (db *PostgreSQL) CreateAlias(ctx context.Context, tx pgx.Tx, alias *apiv0.ServerAlias) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO aliases (alias, target)
+		VALUES ($1, $2)
+		ON CONFLICT (alias) DO NOTHING
+		RETURNING created_at
+	`
+
+	err := db.getExecutor(tx).QueryRow(ctx, query, alias.Alias, alias.Target).Scan(&alias.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to insert alias: %w", err)
+	}
+
+	return nil
+}
+
+// GetAliasTarget retrieves the server name an alias resolves to
+func (db *PostgreSQL) GetAliasTarget(ctx context.Context, tx pgx.Tx, alias string) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	var target string
+	err := db.getExecutor(tx).QueryRow(ctx, `SELECT target FROM aliases WHERE alias = $1`, alias).Scan(&target)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("failed to get alias: %w", err)
+	}
+
+	return target, nil
+}
+
+// CreateAuditEntry appends an entry to the audit log, setting its ID and timestamp
+func (db *PostgreSQL) CreateAuditEntry(ctx context.Context, tx pgx.Tx, entry *apiv0.AuditEntry) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO audit_log (actor, action, server_name, version, result)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	err := db.getExecutor(tx).QueryRow(ctx, query, entry.Actor, entry.Action, entry.ServerName, entry.Version, entry.Result).
+		Scan(&entry.ID, &entry.Timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to insert audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuditEntries retrieves audit log entries newest first, optionally only those for one server.
+// The cursor is the ID of the last entry of the previous page.
+func (db *PostgreSQL) ListAuditEntries(ctx context.Context, tx pgx.Tx, serverName string, cursor string, limit int) ([]*apiv0.AuditEntry, string, error) {
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	var whereConditions []string
+	var args []any
+	if serverName != "" {
+		args = append(args, serverName)
+		whereConditions = append(whereConditions, fmt.Sprintf("server_name = $%d", len(args)))
+	}
+	if cursor != "" {
+		cursorID, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: invalid cursor %q", ErrInvalidInput, cursor)
+		}
+		args = append(args, cursorID)
+		whereConditions = append(whereConditions, fmt.Sprintf("id < $%d", len(args)))
+	}
+
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT id, actor, action, server_name, version, result, created_at
+		FROM audit_log
+		%s
+		ORDER BY id DESC
+		LIMIT $%d
+	`, whereClause, len(args))
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*apiv0.AuditEntry
+	for rows.Next() {
+		var entry apiv0.AuditEntry
+		if err := rows.Scan(&entry.ID, &entry.Actor, &entry.Action, &entry.ServerName, &entry.Version, &entry.Result, &entry.Timestamp); err != nil {
+			return nil, "", fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating audit log rows: %w", err)
+	}
+
+	nextCursor := ""
+	if len(entries) > 0 && len(entries) >= limit {
+		nextCursor = strconv.FormatInt(entries[len(entries)-1].ID, 10)
+	}
+
+	return entries, nextCursor, nil
+}
+
 // Close closes the database connection
 func (db *PostgreSQL) Close() error {
+	if db.replica != db.pool {
+		db.replica.Close()
+	}
 	db.pool.Close()
 	return nil
 }
@@ -0,0 +1,235 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// tracingDatabase records a span for every call to the wrapped database, as a child of the caller's span
+type tracingDatabase struct {
+	db Database
+}
+
+// WithTracing wraps a database so that each of its operations is traced
+func WithTracing(db Database) Database {
+	return &tracingDatabase{db: db}
+}
+
+func startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return telemetry.StartSpan(ctx, "db."+operation, semconv.DBSystemPostgreSQL, semconv.DBOperationKey.String(operation))
+}
+
+func (t *tracingDatabase) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error) {
+	ctx, span := startSpan(ctx, "CreateServer")
+	server, err := t.db.CreateServer(ctx, tx, serverJSON, officialMeta)
+	telemetry.EndSpan(span, err)
+	return server, err
+}
+
+func (t *tracingDatabase) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	ctx, span := startSpan(ctx, "UpdateServer")
+	server, err := t.db.UpdateServer(ctx, tx, serverName, version, serverJSON)
+	telemetry.EndSpan(span, err)
+	return server, err
+}
+
+func (t *tracingDatabase) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status string) (*apiv0.ServerResponse, error) {
+	ctx, span := startSpan(ctx, "SetServerStatus")
+	server, err := t.db.SetServerStatus(ctx, tx, serverName, version, status)
+	telemetry.EndSpan(span, err)
+	return server, err
+}
+
+func (t *tracingDatabase) SetServerUnlisted(ctx context.Context, tx pgx.Tx, serverName, version string, unlisted bool) (*apiv0.ServerResponse, error) {
+	ctx, span := startSpan(ctx, "SetServerUnlisted")
+	server, err := t.db.SetServerUnlisted(ctx, tx, serverName, version, unlisted)
+	telemetry.EndSpan(span, err)
+	return server, err
+}
+
+func (t *tracingDatabase) SetServerVerified(ctx context.Context, tx pgx.Tx, serverName, version string, verifiedAt *time.Time) (*apiv0.ServerResponse, error) {
+	ctx, span := startSpan(ctx, "SetServerVerified")
+	server, err := t.db.SetServerVerified(ctx, tx, serverName, version, verifiedAt)
+	telemetry.EndSpan(span, err)
+	return server, err
+}
+
+func (t *tracingDatabase) SetServerDeprecation(ctx context.Context, tx pgx.Tx, serverName, version, message, supersededBy string) (*apiv0.ServerResponse, error) {
+	ctx, span := startSpan(ctx, "SetServerDeprecation")
+	server, err := t.db.SetServerDeprecation(ctx, tx, serverName, version, message, supersededBy)
+	telemetry.EndSpan(span, err)
+	return server, err
+}
+
+func (t *tracingDatabase) ListServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
+	ctx, span := startSpan(ctx, "ListServers")
+	servers, nextCursor, err := t.db.ListServers(ctx, tx, filter, cursor, limit)
+	telemetry.EndSpan(span, err)
+	return servers, nextCursor, err
+}
+
+func (t *tracingDatabase) ListServersPage(ctx context.Context, tx pgx.Tx, filter *ServerFilter, cursor string, limit int) (*ServerPage, error) {
+	ctx, span := startSpan(ctx, "ListServersPage")
+	page, err := t.db.ListServersPage(ctx, tx, filter, cursor, limit)
+	telemetry.EndSpan(span, err)
+	return page, err
+}
+
+func (t *tracingDatabase) GetServerByName(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error) {
+	ctx, span := startSpan(ctx, "GetServerByName")
+	server, err := t.db.GetServerByName(ctx, tx, serverName)
+	telemetry.EndSpan(span, err)
+	return server, err
+}
+
+func (t *tracingDatabase) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx, serverName string, version string) (*apiv0.ServerResponse, error) {
+	ctx, span := startSpan(ctx, "GetServerByNameAndVersion")
+	server, err := t.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+	telemetry.EndSpan(span, err)
+	return server, err
+}
+
+func (t *tracingDatabase) GetRawServer(ctx context.Context, tx pgx.Tx, serverName string, version string) (*apiv0.RawServer, error) {
+	ctx, span := startSpan(ctx, "GetRawServer")
+	raw, err := t.db.GetRawServer(ctx, tx, serverName, version)
+	telemetry.EndSpan(span, err)
+	return raw, err
+}
+
+func (t *tracingDatabase) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx, serverName string) ([]*apiv0.ServerResponse, error) {
+	ctx, span := startSpan(ctx, "GetAllVersionsByServerName")
+	servers, err := t.db.GetAllVersionsByServerName(ctx, tx, serverName)
+	telemetry.EndSpan(span, err)
+	return servers, err
+}
+
+func (t *tracingDatabase) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error) {
+	ctx, span := startSpan(ctx, "GetCurrentLatestVersion")
+	server, err := t.db.GetCurrentLatestVersion(ctx, tx, serverName)
+	telemetry.EndSpan(span, err)
+	return server, err
+}
+
+func (t *tracingDatabase) GetServerStats(ctx context.Context, tx pgx.Tx) (*apiv0.ServerStats, error) {
+	ctx, span := startSpan(ctx, "GetServerStats")
+	stats, err := t.db.GetServerStats(ctx, tx)
+	telemetry.EndSpan(span, err)
+	return stats, err
+}
+
+func (t *tracingDatabase) ListServerNames(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	ctx, span := startSpan(ctx, "ListServerNames")
+	names, err := t.db.ListServerNames(ctx, tx)
+	telemetry.EndSpan(span, err)
+	return names, err
+}
+
+func (t *tracingDatabase) CountServerVersions(ctx context.Context, tx pgx.Tx, serverName string) (int, error) {
+	ctx, span := startSpan(ctx, "CountServerVersions")
+	count, err := t.db.CountServerVersions(ctx, tx, serverName)
+	telemetry.EndSpan(span, err)
+	return count, err
+}
+
+func (t *tracingDatabase) CheckVersionExists(ctx context.Context, tx pgx.Tx, serverName, version string) (bool, error) {
+	ctx, span := startSpan(ctx, "CheckVersionExists")
+	exists, err := t.db.CheckVersionExists(ctx, tx, serverName, version)
+	telemetry.EndSpan(span, err)
+	return exists, err
+}
+
+func (t *tracingDatabase) FindServerNameIgnoringCase(ctx context.Context, tx pgx.Tx, serverName string) (string, error) {
+	ctx, span := startSpan(ctx, "FindServerNameIgnoringCase")
+	name, err := t.db.FindServerNameIgnoringCase(ctx, tx, serverName)
+	telemetry.EndSpan(span, err)
+	return name, err
+}
+
+func (t *tracingDatabase) CheckVersionsExist(ctx context.Context, tx pgx.Tx, serverName string, versions []string) (map[string]bool, error) {
+	ctx, span := startSpan(ctx, "CheckVersionsExist")
+	existing, err := t.db.CheckVersionsExist(ctx, tx, serverName, versions)
+	telemetry.EndSpan(span, err)
+	return existing, err
+}
+
+func (t *tracingDatabase) PurgeDeleted(ctx context.Context, tx pgx.Tx, before time.Time) ([]string, error) {
+	ctx, span := startSpan(ctx, "PurgeDeleted")
+	names, err := t.db.PurgeDeleted(ctx, tx, before)
+	telemetry.EndSpan(span, err)
+	return names, err
+}
+
+func (t *tracingDatabase) DeleteServerVersion(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	ctx, span := startSpan(ctx, "DeleteServerVersion")
+	err := t.db.DeleteServerVersion(ctx, tx, serverName, version)
+	telemetry.EndSpan(span, err)
+	return err
+}
+
+func (t *tracingDatabase) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error {
+	ctx, span := startSpan(ctx, "UnmarkAsLatest")
+	err := t.db.UnmarkAsLatest(ctx, tx, serverName)
+	telemetry.EndSpan(span, err)
+	return err
+}
+
+func (t *tracingDatabase) MarkAsLatest(ctx context.Context, tx pgx.Tx, serverName, version string) (*apiv0.ServerResponse, error) {
+	ctx, span := startSpan(ctx, "MarkAsLatest")
+	server, err := t.db.MarkAsLatest(ctx, tx, serverName, version)
+	telemetry.EndSpan(span, err)
+	return server, err
+}
+
+func (t *tracingDatabase) CreateAlias(ctx context.Context, tx pgx.Tx, alias *apiv0.ServerAlias) error {
+	ctx, span := startSpan(ctx, "CreateAlias")
+	err := t.db.CreateAlias(ctx, tx, alias)
+	telemetry.EndSpan(span, err)
+	return err
+}
+
+func (t *tracingDatabase) GetAliasTarget(ctx context.Context, tx pgx.Tx, alias string) (string, error) {
+	ctx, span := startSpan(ctx, "GetAliasTarget")
+	target, err := t.db.GetAliasTarget(ctx, tx, alias)
+	telemetry.EndSpan(span, err)
+	return target, err
+}
+
+func (t *tracingDatabase) CreateAuditEntry(ctx context.Context, tx pgx.Tx, entry *apiv0.AuditEntry) error {
+	ctx, span := startSpan(ctx, "CreateAuditEntry")
+	err := t.db.CreateAuditEntry(ctx, tx, entry)
+	telemetry.EndSpan(span, err)
+	return err
+}
+
+func (t *tracingDatabase) ListAuditEntries(ctx context.Context, tx pgx.Tx, serverName string, cursor string, limit int) ([]*apiv0.AuditEntry, string, error) {
+	ctx, span := startSpan(ctx, "ListAuditEntries")
+	entries, nextCursor, err := t.db.ListAuditEntries(ctx, tx, serverName, cursor, limit)
+	telemetry.EndSpan(span, err)
+	return entries, nextCursor, err
+}
+
+func (t *tracingDatabase) AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverName string) error {
+	ctx, span := startSpan(ctx, "AcquirePublishLock")
+	err := t.db.AcquirePublishLock(ctx, tx, serverName)
+	telemetry.EndSpan(span, err)
+	return err
+}
+
+// InTransaction traces the whole transaction, so operations run within it become its children
+func (t *tracingDatabase) InTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error {
+	ctx, span := startSpan(ctx, "InTransaction")
+	err := t.db.InTransaction(ctx, fn)
+	telemetry.EndSpan(span, err)
+	return err
+}
+
+func (t *tracingDatabase) Close() error {
+	return t.db.Close()
+}
@@ -0,0 +1,109 @@
+// Package events provides an in-process bus for registry change events
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Type identifies the kind of registry change an event describes
+type Type string
+
+const (
+	// TypeServerPublished is emitted when a new server version is published
+	TypeServerPublished Type = "server.published"
+	// TypeServerUpdated is emitted when an existing server version is edited
+	TypeServerUpdated Type = "server.updated"
+	// TypeServerStatusChanged is emitted when an edit changes the status of a server version
+	TypeServerStatusChanged Type = "server.status_changed"
+)
+
+// subscriberBuffer is how many events may queue up for a subscriber before it is dropped
+const subscriberBuffer = 64
+
+// Event describes a change made to the registry
+type Event struct {
+	ID         int          `json:"id" doc:"Event ID, increasing within a single registry instance"`
+	Type       Type         `json:"type" doc:"Kind of change" enum:"server.published,server.updated,server.status_changed"`
+	ServerName string       `json:"serverName" doc:"Name of the changed server"`
+	Version    string       `json:"version" doc:"Version of the changed server"`
+	Status     model.Status `json:"status" doc:"Status of the version after the change"`
+	Timestamp  time.Time    `json:"timestamp" doc:"When the change was made"`
+}
+
+// Bus fans events out to subscribers, keeping recent events so that reconnecting subscribers can catch up
+type Bus struct {
+	mu          sync.Mutex
+	lastID      int
+	history     []Event
+	historySize int
+	subscribers map[chan Event]struct{}
+}
+
+// NewBus creates a bus that retains up to historySize events for replay
+func NewBus(historySize int) *Bus {
+	return &Bus{
+		historySize: historySize,
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Publish assigns the event an ID and delivers it to all subscribers. Subscribers that have fallen too far
+// behind are dropped, which closes their channel; they can resubscribe from the last event they received.
+func (b *Bus) Publish(event Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	b.history = append(b.history, event)
+	if len(b.history) > b.historySize {
+		b.history = append([]Event(nil), b.history[len(b.history)-b.historySize:]...)
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return event
+}
+
+// Subscribe returns a channel receiving retained events with an ID greater than afterID followed by all
+// newly published events, and a function that cancels the subscription
+func (b *Bus) Subscribe(afterID int) (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var missed []Event
+	for _, event := range b.history {
+		if event.ID > afterID {
+			missed = append(missed, event)
+		}
+	}
+
+	ch := make(chan Event, len(missed)+subscriberBuffer)
+	for _, event := range missed {
+		ch <- event
+	}
+	b.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/events"
+)
+
+func TestBus_PublishAndSubscribe(t *testing.T) {
+	bus := events.NewBus(10)
+
+	ch, cancel := bus.Subscribe(0)
+	defer cancel()
+
+	published := bus.Publish(events.Event{Type: events.TypeServerPublished, ServerName: "com.example/server", Version: "1.0.0"})
+	assert.Equal(t, 1, published.ID)
+	assert.False(t, published.Timestamp.IsZero())
+
+	received := <-ch
+	assert.Equal(t, published, received)
+}
+
+func TestBus_ReplaysAfterLastEventID(t *testing.T) {
+	bus := events.NewBus(2)
+	for _, version := range []string{"1.0.0", "1.1.0", "1.2.0"} {
+		bus.Publish(events.Event{Type: events.TypeServerPublished, ServerName: "com.example/server", Version: version})
+	}
+
+	// Only the last two events are retained, and only those after ID 2 are replayed
+	ch, cancel := bus.Subscribe(2)
+	defer cancel()
+
+	require.Len(t, ch, 1)
+	assert.Equal(t, "1.2.0", (<-ch).Version)
+}
+
+func TestBus_DropsSlowSubscribers(t *testing.T) {
+	bus := events.NewBus(1)
+	ch, cancel := bus.Subscribe(0)
+	defer cancel()
+
+	// Publish more events than the subscriber buffer holds without reading any
+	for range 100 {
+		bus.Publish(events.Event{Type: events.TypeServerUpdated})
+	}
+
+	count := 0
+	for range ch {
+		count++
+	}
+	assert.Less(t, count, 100, "channel should be closed once the subscriber falls behind")
+}
+
+func TestBus_CancelClosesChannel(t *testing.T) {
+	bus := events.NewBus(1)
+	ch, cancel := bus.Subscribe(0)
+	cancel()
+	cancel() // cancelling twice is safe
+
+	_, ok := <-ch
+	assert.False(t, ok)
+}
@@ -0,0 +1,152 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the hex-encoded HMAC-SHA256 of the request body, prefixed with "sha256="
+	SignatureHeader = "X-Registry-Signature"
+	// EventTypeHeader carries the type of the delivered event
+	EventTypeHeader = "X-Registry-Event"
+)
+
+// webhookWorkers is how many deliveries run concurrently
+const webhookWorkers = 4
+
+// WebhookOptions configures a WebhookDispatcher
+type WebhookOptions struct {
+	// URLs receive every event as a JSON POST
+	URLs []string
+	// Secret signs request bodies; empty sends no signature header
+	Secret string
+	// QueueSize bounds how many events wait for delivery; events are dropped when the queue is full
+	QueueSize int
+	// RetryAttempts is how many times a delivery is tried, and RetryBackoff the delay before the first retry (doubling each time)
+	RetryAttempts int
+	RetryBackoff  time.Duration
+	// Client sends the requests; nil uses a client with a 10 second timeout
+	Client *http.Client
+}
+
+// WebhookDispatcher delivers events to webhook URLs from a bounded queue, so slow receivers never block publishing
+type WebhookDispatcher struct {
+	opts  WebhookOptions
+	queue chan Event
+	wg    sync.WaitGroup
+	once  sync.Once
+}
+
+// NewWebhookDispatcher creates a dispatcher and starts its delivery workers
+func NewWebhookDispatcher(opts WebhookOptions) *WebhookDispatcher {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 100
+	}
+	if opts.RetryAttempts <= 0 {
+		opts.RetryAttempts = 1
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	d := &WebhookDispatcher{
+		opts:  opts,
+		queue: make(chan Event, opts.QueueSize),
+	}
+	for range webhookWorkers {
+		d.wg.Add(1)
+		go d.work()
+	}
+	return d
+}
+
+// Enqueue queues the event for delivery without blocking. It returns false if the queue is full and the event was dropped.
+func (d *WebhookDispatcher) Enqueue(event Event) bool {
+	select {
+	case d.queue <- event:
+		return true
+	default:
+		log.Printf("Dropping webhook event %d (%s): delivery queue is full", event.ID, event.Type)
+		return false
+	}
+}
+
+// Close stops accepting events and waits for queued deliveries to finish
+func (d *WebhookDispatcher) Close() {
+	d.once.Do(func() {
+		close(d.queue)
+	})
+	d.wg.Wait()
+}
+
+func (d *WebhookDispatcher) work() {
+	defer d.wg.Done()
+	for event := range d.queue {
+		body, err := json.Marshal(event)
+		if err != nil {
+			log.Printf("Failed to encode webhook event %d: %v", event.ID, err)
+			continue
+		}
+		for _, url := range d.opts.URLs {
+			if err := d.deliver(url, event.Type, body); err != nil {
+				log.Printf("Failed to deliver webhook event %d to %s: %v", event.ID, url, err)
+			}
+		}
+	}
+}
+
+// deliver posts the body to url, retrying on connection errors and non-2xx responses
+func (d *WebhookDispatcher) deliver(url string, eventType Type, body []byte) error {
+	backoff := d.opts.RetryBackoff
+	var err error
+	for attempt := 1; attempt <= d.opts.RetryAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if err = d.post(url, eventType, body); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (d *WebhookDispatcher) post(url string, eventType Type, body []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, string(eventType))
+	if d.opts.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(d.opts.Secret, body))
+	}
+
+	resp, err := d.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the signature header value for body, so receivers can verify deliveries with the shared secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
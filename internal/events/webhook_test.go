@@ -0,0 +1,125 @@
+package events_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+type delivery struct {
+	eventType string
+	signature string
+	body      []byte
+}
+
+func newReceiver(t *testing.T, status func() int) (*httptest.Server, <-chan delivery) {
+	t.Helper()
+	deliveries := make(chan delivery, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		code := status()
+		if code == http.StatusOK {
+			deliveries <- delivery{
+				eventType: r.Header.Get(events.EventTypeHeader),
+				signature: r.Header.Get(events.SignatureHeader),
+				body:      body,
+			}
+		}
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(server.Close)
+	return server, deliveries
+}
+
+func TestWebhookDispatcher_DeliversSignedEvents(t *testing.T) {
+	receiver, deliveries := newReceiver(t, func() int { return http.StatusOK })
+	dispatcher := events.NewWebhookDispatcher(events.WebhookOptions{URLs: []string{receiver.URL}, Secret: "s3cret"})
+	defer dispatcher.Close()
+
+	bus := events.NewBus(10)
+	for _, event := range []events.Event{
+		{Type: events.TypeServerPublished, ServerName: "com.example/server", Version: "1.0.0", Status: model.StatusActive},
+		{Type: events.TypeServerStatusChanged, ServerName: "com.example/server", Version: "1.0.0", Status: model.StatusDeprecated},
+	} {
+		published := bus.Publish(event)
+		require.True(t, dispatcher.Enqueue(published))
+
+		select {
+		case got := <-deliveries:
+			assert.Equal(t, string(event.Type), got.eventType)
+			assert.Equal(t, events.Sign("s3cret", got.body), got.signature)
+
+			var payload events.Event
+			require.NoError(t, json.Unmarshal(got.body, &payload))
+			assert.Equal(t, published.ID, payload.ID)
+			assert.Equal(t, event.Type, payload.Type)
+			assert.Equal(t, "com.example/server", payload.ServerName)
+			assert.Equal(t, "1.0.0", payload.Version)
+			assert.Equal(t, event.Status, payload.Status)
+			assert.WithinDuration(t, published.Timestamp, payload.Timestamp, time.Millisecond)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("webhook for %s was not delivered", event.Type)
+		}
+	}
+}
+
+func TestWebhookDispatcher_RetriesFailedDeliveries(t *testing.T) {
+	var calls atomic.Int32
+	receiver, deliveries := newReceiver(t, func() int {
+		if calls.Add(1) < 3 {
+			return http.StatusBadGateway
+		}
+		return http.StatusOK
+	})
+	dispatcher := events.NewWebhookDispatcher(events.WebhookOptions{
+		URLs:          []string{receiver.URL},
+		RetryAttempts: 3,
+		RetryBackoff:  time.Millisecond,
+	})
+	defer dispatcher.Close()
+
+	dispatcher.Enqueue(events.Event{ID: 1, Type: events.TypeServerUpdated})
+
+	select {
+	case got := <-deliveries:
+		assert.Empty(t, got.signature, "no signature without a secret")
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered after retries")
+	}
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestWebhookDispatcher_DropsWhenQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	dispatcher := events.NewWebhookDispatcher(events.WebhookOptions{URLs: []string{receiver.URL}, QueueSize: 1})
+
+	// Workers block on the receiver, so the queue fills up without blocking the caller
+	dropped := false
+	for i := range 20 {
+		if !dispatcher.Enqueue(events.Event{ID: i + 1, Type: events.TypeServerPublished}) {
+			dropped = true
+			break
+		}
+	}
+	assert.True(t, dropped)
+
+	close(release)
+	dispatcher.Close()
+}
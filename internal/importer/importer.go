@@ -3,26 +3,57 @@ package importer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"reflect"
+	"slices"
 	"strings"
 
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 // Service handles importing seed data into the registry
 type Service struct {
 	registry service.RegistryService
+	opts     Options
+}
+
+// Options configures how the importer publishes servers
+type Options struct {
+	// BulkLoad skips the per-server publish lock for cold-start loads into a registry that nothing else is
+	// writing to. Imports running alongside live publishes must leave it off so latest versions stay consistent.
+	BulkLoad bool
 }
 
 // NewService creates a new importer service
 func NewService(registry service.RegistryService) *Service {
-	return &Service{registry: registry}
+	return NewServiceWithOptions(registry, Options{})
+}
+
+// NewServiceWithOptions creates a new importer service with the given options
+func NewServiceWithOptions(registry service.RegistryService, opts Options) *Service {
+	return &Service{registry: registry, opts: opts}
+}
+
+// Conflict describes an imported server version that already exists with different content
+type Conflict struct {
+	Name    string
+	Version string
+	// Fields lists the top-level server.json fields whose content differs
+	Fields []string
+}
+
+// ConflictReport lists the server versions an import left untouched because stored content differs
+type ConflictReport struct {
+	Conflicts []Conflict
 }
 
 // ImportFromPath imports seed data from various sources:
@@ -30,17 +61,73 @@ func NewService(registry service.RegistryService) *Service {
 // 2. Direct HTTP URLs to seed.json files - expects ServerJSON array format
 // 3. Registry root URLs (automatically appends /v0/servers and paginates)
 func (s *Service) ImportFromPath(ctx context.Context, path string) error {
+	_, err := s.ImportFromPathWithReport(ctx, path)
+	return err
+}
+
+// ImportFromPathWithReport imports seed data like ImportFromPath, also returning the server versions that
+// already exist with different content. Versions that already exist with identical content are skipped.
+func (s *Service) ImportFromPathWithReport(ctx context.Context, path string) (*ConflictReport, error) {
 	servers, err := readSeedFile(ctx, path)
 	if err != nil {
-		return fmt.Errorf("failed to read seed data: %w", err)
+		return nil, fmt.Errorf("failed to read seed data: %w", err)
 	}
 
-	// Import each server using registry service CreateServer
+	// Import each server through the regular publish path, so latest versions are computed under the same
+	// lock as live publishes, recording where it was mirrored from
+	opts := service.PublishOptions{
+		OriginRegistry:  originRegistry(path),
+		SkipPublishLock: s.opts.BulkLoad,
+	}
+	report := &ConflictReport{}
 	var successfullyCreated []string
+	var unchanged []string
+	var statusUpdated []string
 	var failedCreations []string
 
 	for _, server := range servers {
-		_, err := s.registry.CreateServer(ctx, server)
+		status := mirroredStatus(server)
+		existing, err := s.registry.GetServerByNameAndVersion(ctx, server.Name, server.Version)
+		switch {
+		case err == nil:
+			// Mirror deletions and deprecations of versions imported before
+			if status != "" && existing.Meta.Official != nil && existing.Meta.Official.Status != status {
+				if _, err := s.registry.SetServerStatus(ctx, server.Name, []string{server.Version}, status, ""); err != nil {
+					failedCreations = append(failedCreations, fmt.Sprintf("%s: %v", server.Name, err))
+					log.Printf("Failed to set status of server %s@%s to %s: %v", server.Name, server.Version, status, err)
+					continue
+				}
+				statusUpdated = append(statusUpdated, server.Name)
+			}
+
+			fields, err := diffServerJSON(existing.Server, *server)
+			if err != nil {
+				failedCreations = append(failedCreations, fmt.Sprintf("%s: %v", server.Name, err))
+				continue
+			}
+			if len(fields) == 0 {
+				unchanged = append(unchanged, server.Name)
+				continue
+			}
+			report.Conflicts = append(report.Conflicts, Conflict{Name: server.Name, Version: server.Version, Fields: fields})
+			log.Printf("Conflict importing server %s@%s: stored version differs in %v", server.Name, server.Version, fields)
+			continue
+		case !errors.Is(err, database.ErrNotFound):
+			failedCreations = append(failedCreations, fmt.Sprintf("%s: %v", server.Name, err))
+			log.Printf("Failed to look up server %s: %v", server.Name, err)
+			continue
+		}
+
+		// Deleted versions that were never imported have nothing to delete
+		if status == model.StatusDeleted {
+			unchanged = append(unchanged, server.Name)
+			continue
+		}
+
+		_, err = s.registry.PublishServer(ctx, withoutDeclaredStatus(server), opts)
+		if err == nil && status != "" {
+			_, err = s.registry.SetServerStatus(ctx, server.Name, []string{server.Version}, status, "")
+		}
 		if err != nil {
 			failedCreations = append(failedCreations, fmt.Sprintf("%s: %v", server.Name, err))
 			log.Printf("Failed to create server %s: %v", server.Name, err)
@@ -50,15 +137,101 @@ func (s *Service) ImportFromPath(ctx context.Context, path string) error {
 	}
 
 	// Report import results after actual creation attempts
-	if len(failedCreations) > 0 {
-		log.Printf("Import completed with errors: %d servers created successfully, %d servers failed",
-			len(successfullyCreated), len(failedCreations))
-		log.Printf("Failed servers: %v", failedCreations)
-		return fmt.Errorf("failed to import %d servers", len(failedCreations))
+	if len(failedCreations) > 0 || len(report.Conflicts) > 0 {
+		log.Printf("Import completed with errors: %d servers created successfully, %d status changes, %d unchanged, %d conflicting, %d failed",
+			len(successfullyCreated), len(statusUpdated), len(unchanged), len(report.Conflicts), len(failedCreations))
+		if len(failedCreations) > 0 {
+			log.Printf("Failed servers: %v", failedCreations)
+		}
+		return report, fmt.Errorf("failed to import %d servers", len(failedCreations)+len(report.Conflicts))
+	}
+
+	log.Printf("Import completed successfully: %d servers created, %d status changes, %d unchanged",
+		len(successfullyCreated), len(statusUpdated), len(unchanged))
+	return report, nil
+}
+
+// mirroredStatus returns the deleted or deprecated status a seed entry declares in its official registry
+// metadata, which the import applies locally, or "" for entries to import as active
+func mirroredStatus(server *apiv0.ServerJSON) model.Status {
+	if server.Meta == nil || server.Meta.Official == nil {
+		return ""
 	}
+	switch status := server.Meta.Official.Status; status {
+	case model.StatusDeleted, model.StatusDeprecated:
+		return status
+	default:
+		return ""
+	}
+}
 
-	log.Printf("Import completed successfully: all %d servers created", len(successfullyCreated))
-	return nil
+// withoutDeclaredStatus returns a copy of the server without official registry metadata, which is applied
+// after publishing rather than declared in the publish request
+func withoutDeclaredStatus(server *apiv0.ServerJSON) *apiv0.ServerJSON {
+	if server.Meta == nil || server.Meta.Official == nil {
+		return server
+	}
+	stripped := *server
+	meta := *server.Meta
+	meta.Official = nil
+	stripped.Meta = &meta
+	if meta.PublisherProvided == nil {
+		stripped.Meta = nil
+	}
+	return &stripped
+}
+
+// diffServerJSON returns the top-level fields whose content differs between two servers, comparing their
+// normalized JSON and ignoring _meta, which holds registry and publisher metadata
+func diffServerJSON(stored, incoming apiv0.ServerJSON) ([]string, error) {
+	storedFields, err := normalizedFields(stored)
+	if err != nil {
+		return nil, err
+	}
+	incomingFields, err := normalizedFields(incoming)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	for field, value := range incomingFields {
+		if !reflect.DeepEqual(storedFields[field], value) {
+			fields = append(fields, field)
+		}
+	}
+	for field := range storedFields {
+		if _, ok := incomingFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+	return fields, nil
+}
+
+// normalizedFields decodes a server's JSON into generic values keyed by top-level field, without _meta
+func normalizedFields(server apiv0.ServerJSON) (map[string]any, error) {
+	server.Meta = nil
+	data, err := json.Marshal(server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode server: %w", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode server: %w", err)
+	}
+	return fields, nil
+}
+
+// originRegistry returns the registry servers imported from path were mirrored from: the registry root for
+// registry API URLs, the URL itself for seed files served over HTTP, and empty for local files
+func originRegistry(path string) string {
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		return ""
+	}
+	if root, _, found := strings.Cut(path, "/v0/servers"); found {
+		return root
+	}
+	return path
 }
 
 // readSeedFile reads seed data from various sources
@@ -174,8 +347,14 @@ func fetchFromRegistryAPI(ctx context.Context, baseURL string) ([]*apiv0.ServerJ
 			return nil, fmt.Errorf("failed to parse registry API response: %w", err)
 		}
 
-		// Extract ServerJSON from each ServerResponse
+		// Extract ServerJSON from each ServerResponse, keeping the status it has in the source registry
 		for _, serverResponse := range response.Servers {
+			if serverResponse.Meta.Official != nil {
+				if serverResponse.Server.Meta == nil {
+					serverResponse.Server.Meta = &apiv0.ServerMeta{}
+				}
+				serverResponse.Server.Meta.Official = serverResponse.Meta.Official
+			}
 			allRecords = append(allRecords, &serverResponse.Server)
 		}
 
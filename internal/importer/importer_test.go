@@ -2,12 +2,21 @@ package importer_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/importer"
@@ -18,6 +27,16 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// testPackages is a minimal runnable package, for servers that would otherwise declare no packages or remotes
+var testPackages = []model.Package{
+	{
+		RegistryType: model.RegistryTypeNPM,
+		Identifier:   "test-package",
+		Version:      "1.0.0",
+		Transport:    model.Transport{Type: model.TransportTypeStdio},
+	},
+}
+
 func TestImportService_LocalFile(t *testing.T) {
 	// Create a temporary seed file
 	tempFile := "/tmp/test_import_seed.json"
@@ -30,7 +49,8 @@ func TestImportService_LocalFile(t *testing.T) {
 				Source: "github",
 				ID:     "123",
 			},
-			Version: "1.0.0",
+			Version:  "1.0.0",
+			Packages: testPackages,
 		},
 	}
 
@@ -43,7 +63,7 @@ func TestImportService_LocalFile(t *testing.T) {
 
 	// Create registry service
 	testDB := database.NewTestDB(t)
-	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 
 	// Create importer service and test import
 	importerService := importer.NewService(registryService)
@@ -59,6 +79,7 @@ func TestImportService_LocalFile(t *testing.T) {
 	assert.Equal(t, "Test server 1", servers[0].Server.Description)
 	assert.NotNil(t, servers[0].Meta.Official)
 	assert.Equal(t, model.StatusActive, servers[0].Meta.Official.Status)
+	assert.Empty(t, servers[0].Meta.Official.OriginRegistry, "local seed files have no origin registry")
 }
 
 func TestImportService_HTTPFile(t *testing.T) {
@@ -72,7 +93,8 @@ func TestImportService_HTTPFile(t *testing.T) {
 				Source: "github",
 				ID:     "456",
 			},
-			Version: "2.0.0",
+			Version:  "2.0.0",
+			Packages: testPackages,
 		},
 	}
 
@@ -88,7 +110,7 @@ func TestImportService_HTTPFile(t *testing.T) {
 
 	// Create registry service
 	testDB := database.NewTestDB(t)
-	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 
 	// Create importer service and test import
 	importerService := importer.NewService(registryService)
@@ -102,7 +124,8 @@ func TestImportService_HTTPFile(t *testing.T) {
 	assert.Equal(t, "io.github.test/http-test-server", servers[0].Server.Name)
 	assert.Equal(t, "2.0.0", servers[0].Server.Version)
 	assert.Equal(t, "HTTP test server", servers[0].Server.Description)
-	assert.NotNil(t, servers[0].Meta.Official)
+	require.NotNil(t, servers[0].Meta.Official)
+	assert.Equal(t, httpServer.URL+"/seed.json", servers[0].Meta.Official.OriginRegistry)
 }
 
 func TestImportService_RegistryPagination(t *testing.T) {
@@ -110,7 +133,7 @@ func TestImportService_RegistryPagination(t *testing.T) {
 
 	// Create registry service with test data
 	testDB := database.NewTestDB(t)
-	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 
 	// Setup source registry with test data
 	sourceServers := []*apiv0.ServerJSON{
@@ -118,11 +141,13 @@ func TestImportService_RegistryPagination(t *testing.T) {
 			Name:        "com.source/server-1",
 			Description: "Source server 1",
 			Version:     "1.0.0",
+			Packages:    testPackages,
 		},
 		{
 			Name:        "com.source/server-2",
 			Description: "Source server 2",
 			Version:     "1.0.0",
+			Packages:    testPackages,
 		},
 	}
 
@@ -155,7 +180,7 @@ func TestImportService_RegistryPagination(t *testing.T) {
 
 	// Create target registry for import
 	targetDB := database.NewTestDB(t)
-	targetRegistryService := service.NewRegistryService(targetDB, &config.Config{EnableRegistryValidation: false})
+	targetRegistryService := service.NewRegistryService(targetDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 
 	// Create importer service and test registry import
 	importerService := importer.NewService(targetRegistryService)
@@ -174,12 +199,32 @@ func TestImportService_RegistryPagination(t *testing.T) {
 	}
 	assert.Contains(t, serverNames, "com.source/server-1")
 	assert.Contains(t, serverNames, "com.source/server-2")
+
+	// Imported servers record the source registry, while the natively published source servers don't
+	for _, server := range importedServers {
+		assert.Equal(t, httpServer.URL, server.Meta.Official.OriginRegistry)
+	}
+	sourceList, _, err := registryService.ListServers(ctx, nil, "", 10)
+	require.NoError(t, err)
+	for _, server := range sourceList {
+		assert.Empty(t, server.Meta.Official.OriginRegistry)
+	}
+
+	// Imported servers can be filtered by origin
+	origin := httpServer.URL
+	filtered, _, err := targetRegistryService.ListServers(ctx, &database.ServerFilter{OriginRegistry: &origin}, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, filtered, 2)
+	otherOrigin := "https://other.example.com"
+	filtered, _, err = targetRegistryService.ListServers(ctx, &database.ServerFilter{OriginRegistry: &otherOrigin}, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, filtered)
 }
 
 func TestImportService_ErrorHandling(t *testing.T) {
 	// Create registry service
 	testDB := database.NewTestDB(t)
-	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 	importerService := importer.NewService(registryService)
 
 	tests := []struct {
@@ -239,3 +284,228 @@ func TestImportService_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestImportService_ConcurrentWithLivePublish(t *testing.T) {
+	ctx := context.Background()
+	const serverName = "com.example/contended-server"
+
+	// Seed file with many versions of a server that is also being published live
+	var seedData []*apiv0.ServerJSON
+	for i := range 20 {
+		seedData = append(seedData, &apiv0.ServerJSON{
+			Name:        serverName,
+			Description: "Imported version",
+			Version:     fmt.Sprintf("1.0.%d", i),
+			Packages:    testPackages,
+		})
+	}
+	jsonData, err := json.Marshal(seedData)
+	require.NoError(t, err)
+	seedFile := filepath.Join(t.TempDir(), "seed.json")
+	require.NoError(t, os.WriteFile(seedFile, jsonData, 0600))
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+	importerService := importer.NewService(registryService)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, importerService.ImportFromPath(ctx, seedFile))
+	}()
+	go func() {
+		defer wg.Done()
+		for i := range 5 {
+			_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+				Name:        serverName,
+				Description: "Live version",
+				Version:     fmt.Sprintf("2.0.%d", i),
+				Packages:    testPackages,
+			})
+			assert.NoError(t, err)
+		}
+	}()
+	wg.Wait()
+
+	versions, err := registryService.GetAllVersionsByServerName(ctx, serverName)
+	require.NoError(t, err)
+	require.Len(t, versions, 25)
+
+	var latest []string
+	for _, version := range versions {
+		if version.Meta.Official.IsLatest {
+			latest = append(latest, version.Server.Version)
+		}
+	}
+	assert.Equal(t, []string{"2.0.4"}, latest, "exactly one version, the highest, should be latest")
+}
+
+func TestImportService_ConflictReport(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+	importerService := importer.NewService(registryService)
+
+	writeSeed := func(servers []*apiv0.ServerJSON) string {
+		jsonData, err := json.Marshal(servers)
+		require.NoError(t, err)
+		seedFile := filepath.Join(t.TempDir(), "seed.json")
+		require.NoError(t, os.WriteFile(seedFile, jsonData, 0600))
+		return seedFile
+	}
+
+	original := []*apiv0.ServerJSON{
+		{Name: "com.example/stable-server", Description: "Stable server", Version: "1.0.0", Packages: testPackages},
+		{Name: "com.example/changed-server", Description: "Original description", Version: "1.0.0", Packages: testPackages},
+	}
+	report, err := importerService.ImportFromPathWithReport(ctx, writeSeed(original))
+	require.NoError(t, err)
+	assert.Empty(t, report.Conflicts)
+
+	// Re-importing identical content is a no-op
+	report, err = importerService.ImportFromPathWithReport(ctx, writeSeed(original))
+	require.NoError(t, err)
+	assert.Empty(t, report.Conflicts)
+
+	// Importing a modified body for an existing version is reported and leaves the stored version untouched
+	modified := []*apiv0.ServerJSON{
+		{Name: "com.example/stable-server", Description: "Stable server", Version: "1.0.0", Packages: testPackages},
+		{Name: "com.example/changed-server", Description: "Modified description", Version: "1.0.0", Packages: testPackages, WebsiteURL: "https://example.com"},
+	}
+	report, err = importerService.ImportFromPathWithReport(ctx, writeSeed(modified))
+	assert.Error(t, err)
+	require.NotNil(t, report)
+	assert.Equal(t, []importer.Conflict{
+		{Name: "com.example/changed-server", Version: "1.0.0", Fields: []string{"description", "websiteUrl"}},
+	}, report.Conflicts)
+
+	stored, err := registryService.GetServerByNameAndVersion(ctx, "com.example/changed-server", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Original description", stored.Server.Description)
+}
+
+func TestImportService_RegistryIncremental(t *testing.T) {
+	ctx := context.Background()
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), EnableRegistryValidation: false, MaxVersionsPerServer: 10000}
+
+	// Serve the source registry's real API, recording the changes feed cursors requested
+	sourceService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	mux := http.NewServeMux()
+	v0.RegisterServersEndpoints(humago.New(mux, huma.DefaultConfig("Test API", "1.0.0")), sourceService, cfg)
+	var requestedCursors []string
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedCursors = append(requestedCursors, r.URL.Query().Get("cursor"))
+		mux.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	for _, name := range []string{"com.source/kept-server", "com.source/deleted-server"} {
+		_, err := sourceService.CreateServer(ctx, &apiv0.ServerJSON{Name: name, Description: "Source server", Version: "1.0.0", Packages: testPackages})
+		require.NoError(t, err)
+	}
+
+	targetService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	importerService := importer.NewService(targetService)
+	checkpoints := &importer.FileCheckpointStore{Path: filepath.Join(t.TempDir(), "checkpoint")}
+
+	// The first run has no checkpoint, so it syncs everything
+	require.NoError(t, importerService.ImportFromRegistryIncremental(ctx, httpServer.URL, checkpoints))
+	assert.Equal(t, "", requestedCursors[0])
+	imported, _, err := targetService.ListServers(ctx, nil, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, imported, 2)
+	for _, server := range imported {
+		assert.Equal(t, httpServer.URL, server.Meta.Official.OriginRegistry)
+	}
+	checkpoint, err := checkpoints.LoadCheckpoint(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, checkpoint)
+
+	// Change the source: publish a new server, delete one and edit the other
+	_, err = sourceService.CreateServer(ctx, &apiv0.ServerJSON{Name: "com.source/new-server", Description: "New server", Version: "1.0.0", Packages: testPackages})
+	require.NoError(t, err)
+	_, err = sourceService.SetServerStatus(ctx, "com.source/deleted-server", nil, model.StatusDeleted, "")
+	require.NoError(t, err)
+	_, err = sourceService.UpdateServer(ctx, "com.source/kept-server", "1.0.0", &apiv0.ServerJSON{
+		Name: "com.source/kept-server", Description: "Edited description", Version: "1.0.0", Packages: testPackages,
+	}, nil)
+	require.NoError(t, err)
+
+	// The second run resumes from the checkpoint and only applies the new changes
+	requestedCursors = nil
+	require.NoError(t, importerService.ImportFromRegistryIncremental(ctx, httpServer.URL, checkpoints))
+	require.NotEmpty(t, requestedCursors)
+	assert.Equal(t, checkpoint, requestedCursors[0])
+
+	newServer, err := targetService.GetServerByNameAndVersion(ctx, "com.source/new-server", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusActive, newServer.Meta.Official.Status)
+	deleted, err := targetService.GetServerByNameAndVersion(ctx, "com.source/deleted-server", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDeleted, deleted.Meta.Official.Status)
+	kept, err := targetService.GetServerByNameAndVersion(ctx, "com.source/kept-server", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Edited description", kept.Server.Description)
+
+	newCheckpoint, err := checkpoints.LoadCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, checkpoint, newCheckpoint)
+
+	// With nothing new, a third run applies nothing and keeps the checkpoint
+	requestedCursors = nil
+	require.NoError(t, importerService.ImportFromRegistryIncremental(ctx, httpServer.URL, checkpoints))
+	assert.Equal(t, []string{newCheckpoint}, requestedCursors)
+}
+
+func TestImportService_PropagatesStatus(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+	importerService := importer.NewService(registryService)
+
+	writeSeed := func(servers []*apiv0.ServerJSON) string {
+		jsonData, err := json.Marshal(servers)
+		require.NoError(t, err)
+		seedFile := filepath.Join(t.TempDir(), "seed.json")
+		require.NoError(t, os.WriteFile(seedFile, jsonData, 0600))
+		return seedFile
+	}
+	withStatus := func(name string, status model.Status) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Name:        name,
+			Description: "Mirrored server",
+			Version:     "1.0.0",
+			Packages:    testPackages,
+			Meta:        &apiv0.ServerMeta{Official: &apiv0.RegistryExtensions{Status: status}},
+		}
+	}
+
+	require.NoError(t, importerService.ImportFromPath(ctx, writeSeed([]*apiv0.ServerJSON{
+		withStatus("com.example/deleted-upstream", model.StatusActive),
+		withStatus("com.example/deprecated-upstream", model.StatusActive),
+	})))
+
+	// The servers were deleted and deprecated upstream since the last import
+	require.NoError(t, importerService.ImportFromPath(ctx, writeSeed([]*apiv0.ServerJSON{
+		withStatus("com.example/deleted-upstream", model.StatusDeleted),
+		withStatus("com.example/deprecated-upstream", model.StatusDeprecated),
+		withStatus("com.example/new-deprecated", model.StatusDeprecated),
+		withStatus("com.example/never-imported", model.StatusDeleted),
+	})))
+
+	expected := map[string]model.Status{
+		"com.example/deleted-upstream":    model.StatusDeleted,
+		"com.example/deprecated-upstream": model.StatusDeprecated,
+		"com.example/new-deprecated":      model.StatusDeprecated,
+	}
+	for name, status := range expected {
+		server, err := registryService.GetServerByNameAndVersion(ctx, name, "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, status, server.Meta.Official.Status, name)
+	}
+
+	// Deleted entries that were never imported are not created
+	_, err := registryService.GetServerByNameAndVersion(ctx, "com.example/never-imported", "1.0.0")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
@@ -0,0 +1,205 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// changesPageSize is the number of changes requested per page of the source registry's changes feed
+const changesPageSize = 100
+
+// CheckpointStore persists the changes feed cursor between incremental imports
+type CheckpointStore interface {
+	// LoadCheckpoint returns the cursor saved by the previous import, or "" if there is none
+	LoadCheckpoint(ctx context.Context) (string, error)
+	// SaveCheckpoint records the cursor the next import resumes from
+	SaveCheckpoint(ctx context.Context, cursor string) error
+}
+
+// FileCheckpointStore keeps the checkpoint cursor in a local file
+type FileCheckpointStore struct {
+	Path string
+}
+
+// LoadCheckpoint reads the cursor from the file, treating a missing file as no checkpoint
+func (f *FileCheckpointStore) LoadCheckpoint(_ context.Context) (string, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// SaveCheckpoint writes the cursor to a temporary file and renames it into place, so a crash never leaves a
+// partially written checkpoint
+func (f *FileCheckpointStore) SaveCheckpoint(_ context.Context, cursor string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(cursor); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.Path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// ImportFromRegistryIncremental syncs servers from another registry's changes feed, starting after the cursor in
+// checkpoints and saving the new cursor after each fully applied page, so later runs only pull newer changes.
+// New versions are published, changed versions are updated, and status changes, including deletions, are
+// mirrored. Versions that exist locally but were not imported from baseURL are left untouched.
+func (s *Service) ImportFromRegistryIncremental(ctx context.Context, baseURL string, checkpoints CheckpointStore) error {
+	origin := strings.TrimSuffix(baseURL, "/")
+	cursor, err := checkpoints.LoadCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	var applied, unchanged int
+	for {
+		page, err := fetchChangesPage(ctx, origin, cursor)
+		if err != nil {
+			return err
+		}
+
+		var failed []string
+		for _, change := range page.Servers {
+			changed, err := s.applyChange(ctx, origin, change)
+			switch {
+			case err != nil:
+				failed = append(failed, fmt.Sprintf("%s@%s: %v", change.Server.Name, change.Server.Version, err))
+				log.Printf("Failed to apply change to server %s@%s: %v", change.Server.Name, change.Server.Version, err)
+			case changed:
+				applied++
+			default:
+				unchanged++
+			}
+		}
+
+		// Keep the checkpoint before a page with failures, so the next run retries it; reapplying the changes
+		// that did succeed is a no-op
+		if len(failed) > 0 {
+			log.Printf("Incremental import stopped: %d changes applied, %d unchanged, %d failed", applied, unchanged, len(failed))
+			log.Printf("Failed changes: %v", failed)
+			return fmt.Errorf("failed to apply %d changes", len(failed))
+		}
+
+		nextCursor := page.Metadata.NextCursor
+		if len(page.Servers) == 0 || nextCursor == "" || nextCursor == cursor {
+			break
+		}
+		if err := checkpoints.SaveCheckpoint(ctx, nextCursor); err != nil {
+			return err
+		}
+		cursor = nextCursor
+	}
+
+	log.Printf("Incremental import completed: %d changes applied, %d unchanged", applied, unchanged)
+	return nil
+}
+
+// applyChange brings the local copy of a server version in line with the source registry, reporting whether
+// anything changed
+func (s *Service) applyChange(ctx context.Context, origin string, change apiv0.ServerResponse) (bool, error) {
+	server := change.Server
+	status := model.StatusActive
+	unlisted := false
+	if change.Meta.Official != nil {
+		status = change.Meta.Official.Status
+		unlisted = change.Meta.Official.Unlisted
+	}
+
+	existing, err := s.registry.GetServerByNameAndVersion(ctx, server.Name, server.Version)
+	if errors.Is(err, database.ErrNotFound) {
+		// Deleted versions that were never imported have nothing to delete
+		if status == model.StatusDeleted {
+			return false, nil
+		}
+		if _, err := s.registry.PublishServer(ctx, &server, service.PublishOptions{
+			Unlisted:        unlisted,
+			OriginRegistry:  origin,
+			SkipPublishLock: s.opts.BulkLoad,
+		}); err != nil {
+			return false, err
+		}
+		if status != model.StatusActive {
+			if _, err := s.registry.SetServerStatus(ctx, server.Name, []string{server.Version}, status, ""); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if existing.Meta.Official == nil || existing.Meta.Official.OriginRegistry != origin {
+		return false, fmt.Errorf("version exists locally but was not imported from %s", origin)
+	}
+
+	fields, err := diffServerJSON(existing.Server, server)
+	if err != nil {
+		return false, err
+	}
+	statusChanged := existing.Meta.Official.Status != status
+	unlistedChanged := existing.Meta.Official.Unlisted != unlisted
+	switch {
+	case len(fields) > 0 || unlistedChanged:
+		opts := service.UpdateOptions{Unlisted: &unlisted}
+		if statusChanged {
+			statusValue := string(status)
+			opts.Status = &statusValue
+		}
+		_, err = s.registry.EditServer(ctx, server.Name, server.Version, &server, opts)
+	case statusChanged:
+		_, err = s.registry.SetServerStatus(ctx, server.Name, []string{server.Version}, status, "")
+	default:
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// fetchChangesPage fetches the page of the changes feed following cursor
+func fetchChangesPage(ctx context.Context, origin, cursor string) (*apiv0.ServerListResponse, error) {
+	query := url.Values{"limit": {fmt.Sprint(changesPageSize)}}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	data, err := fetchFromHTTP(ctx, origin+"/v0/servers/changes?"+query.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch changes from registry API: %w", err)
+	}
+
+	var page apiv0.ServerListResponse
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse registry API changes: %w", err)
+	}
+	return &page, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// maxAliasDepth bounds how many aliases are followed to resolve a name, e.g. a server renamed several times
+const maxAliasDepth = 8
+
+// ErrInvalidAlias is returned when an alias names an existing server or does not resolve to a server
+var ErrInvalidAlias = errors.New("invalid alias")
+
+// ErrAliasLoop is returned when an alias would resolve back to itself
+var ErrAliasLoop = errors.New("alias loop")
+
+// CreateAlias makes an old server name resolve to another server, e.g. after a namespace was renamed. The alias
+// must not be the name of an existing server, and the target must be a server or an alias resolving to one.
+func (s *registryServiceImpl) CreateAlias(ctx context.Context, alias, target string) (_ *apiv0.ServerAlias, err error) {
+	ctx, span := startSpan(ctx, "CreateAlias", serverNameKey.String(alias))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerAlias, error) {
+		count, err := s.db.CountServerVersions(ctx, tx, alias)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("%w: server %s already exists", ErrInvalidAlias, alias)
+		}
+
+		// Follow the target's aliases to the server it resolves to, rejecting chains leading back to the alias
+		name := target
+		for depth := 0; ; depth++ {
+			if name == alias {
+				return nil, fmt.Errorf("%w: %s would resolve to itself through %s", ErrAliasLoop, alias, target)
+			}
+			if depth == maxAliasDepth {
+				return nil, fmt.Errorf("%w: %s resolves through more than %d aliases", ErrInvalidAlias, target, maxAliasDepth)
+			}
+			count, err := s.db.CountServerVersions(ctx, tx, name)
+			if err != nil {
+				return nil, err
+			}
+			if count > 0 {
+				break
+			}
+			next, err := s.db.GetAliasTarget(ctx, tx, name)
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, fmt.Errorf("%w: server %s does not exist", ErrInvalidAlias, target)
+			}
+			if err != nil {
+				return nil, err
+			}
+			name = next
+		}
+
+		created := &apiv0.ServerAlias{Alias: alias, Target: target}
+		if err := s.db.CreateAlias(ctx, tx, created); err != nil {
+			return nil, err
+		}
+		return created, nil
+	})
+}
+
+// resolveAlias returns the name of the server an alias resolves to, following chains of aliases. It returns
+// database.ErrNotFound if the name is not an alias.
+func (s *registryServiceImpl) resolveAlias(ctx context.Context, tx pgx.Tx, name string) (string, error) {
+	target, err := s.db.GetAliasTarget(ctx, tx, name)
+	if err != nil {
+		return "", err
+	}
+	for depth := 1; depth < maxAliasDepth; depth++ {
+		next, err := s.db.GetAliasTarget(ctx, tx, target)
+		if errors.Is(err, database.ErrNotFound) {
+			return target, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		target = next
+	}
+	return target, nil
+}
@@ -0,0 +1,87 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestCreateAlias(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	for _, name := range []string{"io.github.new-org/server", "io.github.other-org/server"} {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "Alias test server",
+			Version:     "1.0.0",
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+	}
+
+	alias, err := service.CreateAlias(ctx, "io.github.old-org/server", "io.github.new-org/server")
+	require.NoError(t, err)
+	assert.False(t, alias.CreatedAt.IsZero())
+
+	t.Run("old name resolves to the target", func(t *testing.T) {
+		server, err := service.GetServerByName(ctx, "io.github.old-org/server")
+		require.NoError(t, err)
+		assert.Equal(t, "io.github.new-org/server", server.Server.Name)
+	})
+
+	t.Run("chained aliases resolve to the final target", func(t *testing.T) {
+		_, err := service.CreateAlias(ctx, "io.github.older-org/server", "io.github.old-org/server")
+		require.NoError(t, err)
+
+		server, err := service.GetServerByName(ctx, "io.github.older-org/server")
+		require.NoError(t, err)
+		assert.Equal(t, "io.github.new-org/server", server.Server.Name)
+	})
+
+	t.Run("unknown names are still not found", func(t *testing.T) {
+		_, err := service.GetServerByName(ctx, "io.github.unknown-org/server")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("alias must not shadow an existing server", func(t *testing.T) {
+		_, err := service.CreateAlias(ctx, "io.github.other-org/server", "io.github.new-org/server")
+		assert.ErrorIs(t, err, ErrInvalidAlias)
+	})
+
+	t.Run("target must exist", func(t *testing.T) {
+		_, err := service.CreateAlias(ctx, "io.github.stale-org/server", "io.github.missing-org/server")
+		assert.ErrorIs(t, err, ErrInvalidAlias)
+	})
+
+	t.Run("duplicate alias is rejected", func(t *testing.T) {
+		_, err := service.CreateAlias(ctx, "io.github.old-org/server", "io.github.other-org/server")
+		assert.ErrorIs(t, err, database.ErrAlreadyExists)
+	})
+
+	t.Run("self alias is a loop", func(t *testing.T) {
+		_, err := service.CreateAlias(ctx, "io.github.self-org/server", "io.github.self-org/server")
+		assert.ErrorIs(t, err, ErrAliasLoop)
+	})
+
+	t.Run("alias resolving back to itself is a loop", func(t *testing.T) {
+		// Remove the target server, as a purge would, so its name is free to become an alias
+		err := testDB.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			_, err := tx.Exec(ctx, `DELETE FROM servers WHERE server_name = 'io.github.new-org/server'`)
+			return err
+		})
+		require.NoError(t, err)
+
+		_, err = service.CreateAlias(ctx, "io.github.new-org/server", "io.github.older-org/server")
+		assert.ErrorIs(t, err, ErrAliasLoop)
+	})
+}
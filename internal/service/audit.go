@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Audit log actions
+const (
+	AuditActionPublish   = "publish"
+	AuditActionEdit      = "edit"
+	AuditActionSetStatus = "set_status"
+)
+
+// Audit log results
+const (
+	AuditResultSuccess = "success"
+	AuditResultFailure = "failure"
+)
+
+// systemActor is recorded for changes made without an authenticated actor, such as imports
+const systemActor = "system"
+
+// ListAuditEntries returns audit log entries newest first, optionally only those for one server
+func (s *registryServiceImpl) ListAuditEntries(ctx context.Context, serverName string, cursor string, limit int) (_ []*apiv0.AuditEntry, _ string, err error) {
+	ctx, span := startSpan(ctx, "ListAuditEntries")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if limit <= 0 {
+		limit, _ = s.ListLimits()
+	}
+	dbCursor, err := s.cursors.verify(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	entries, nextCursor, err := s.db.ListAuditEntries(ctx, nil, serverName, dbCursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return entries, s.cursors.sign(nextCursor), nil
+}
+
+// recordAudit appends an entry for a mutating operation to the audit log, recording whether opErr reports a
+// failure. It runs after the operation's transaction, so failed operations are recorded too, and it is not
+// cancelled with the request. A failure to record is logged rather than failing the operation, which has
+// already been committed.
+func (s *registryServiceImpl) recordAudit(ctx context.Context, actor, action, serverName, version string, opErr error) {
+	entry := &apiv0.AuditEntry{
+		Actor:      actor,
+		Action:     action,
+		ServerName: serverName,
+		Version:    version,
+		Result:     AuditResultSuccess,
+	}
+	if entry.Actor == "" {
+		entry.Actor = systemActor
+	}
+	if opErr != nil {
+		entry.Result = AuditResultFailure
+	}
+
+	if err := s.db.CreateAuditEntry(context.WithoutCancel(ctx), nil, entry); err != nil {
+		log.Printf("Failed to record audit entry for %s of %s %s by %s: %v", action, serverName, version, entry.Actor, err)
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// ErrInvalidCursor is returned for list cursors that were not issued by this registry or were modified
+var ErrInvalidCursor = fmt.Errorf("%w: invalid cursor", database.ErrInvalidInput)
+
+// errUnsignedCursor reports a cursor that is not in the signed format at all, as issued before signing
+var errUnsignedCursor = errors.New("cursor is not signed")
+
+// cursorSigner turns the database's pagination cursors into opaque cursors carrying an HMAC-SHA256
+// signature, as "base64url(cursor).base64url(signature)", so clients cannot craft cursors to probe the table
+type cursorSigner struct {
+	key            []byte
+	requireSigned  bool
+	signatureBytes int
+}
+
+// newCursorSigner creates a signer keyed with the configured cursor key. Without one the key is derived
+// from the JWT private key, so instances sharing that key accept each other's cursors, and failing that it
+// is random, so cursors only work against this process.
+func newCursorSigner(cfg *config.Config) *cursorSigner {
+	var key []byte
+	switch {
+	case cfg.CursorSigningKey != "":
+		key = []byte(cfg.CursorSigningKey)
+	case cfg.JWTPrivateKey != "":
+		mac := hmac.New(sha256.New, []byte(cfg.JWTPrivateKey))
+		mac.Write([]byte("mcp-registry list cursor signing key"))
+		key = mac.Sum(nil)
+	default:
+		key = make([]byte, sha256.Size)
+		_, _ = rand.Read(key)
+	}
+	return &cursorSigner{key: key, requireSigned: cfg.RequireSignedCursors, signatureBytes: sha256.Size}
+}
+
+// sign returns the opaque form of a database cursor; the empty cursor stays empty
+func (c *cursorSigner) sign(cursor string) string {
+	if cursor == "" {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor)) + "." + base64.RawURLEncoding.EncodeToString(c.signature(cursor))
+}
+
+// verify returns the database cursor of an opaque cursor, rejecting cursors with an invalid signature.
+// Unsigned cursors from before signing are passed through unless signed cursors are required.
+func (c *cursorSigner) verify(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	decoded, err := c.decode(cursor)
+	if errors.Is(err, errUnsignedCursor) && !c.requireSigned {
+		return cursor, nil
+	}
+	if err != nil {
+		return "", ErrInvalidCursor
+	}
+	return decoded, nil
+}
+
+// decode splits and checks a signed cursor
+func (c *cursorSigner) decode(cursor string) (string, error) {
+	encodedCursor, encodedSignature, found := strings.Cut(cursor, ".")
+	if !found {
+		return "", errUnsignedCursor
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(encodedCursor)
+	if err != nil {
+		return "", errUnsignedCursor
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || len(signature) != c.signatureBytes {
+		return "", errUnsignedCursor
+	}
+	if !hmac.Equal(signature, c.signature(string(decoded))) {
+		return "", ErrInvalidCursor
+	}
+	return string(decoded), nil
+}
+
+func (c *cursorSigner) signature(cursor string) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(cursor))
+	return mac.Sum(nil)
+}
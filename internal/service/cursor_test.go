@@ -0,0 +1,80 @@
+//nolint:testpackage
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+func TestCursorSigner_RoundTrip(t *testing.T) {
+	signer := newCursorSigner(&config.Config{CursorSigningKey: "test-key"})
+
+	for _, cursor := range []string{
+		"com.example/server:1.0.0",
+		`["com.example/server:with:colons","1.0.0:build","42"]`,
+	} {
+		signed := signer.sign(cursor)
+		assert.NotContains(t, signed, cursor, "signed cursors should be opaque")
+
+		decoded, err := signer.verify(signed)
+		require.NoError(t, err)
+		assert.Equal(t, cursor, decoded)
+	}
+
+	assert.Empty(t, signer.sign(""))
+	decoded, err := signer.verify("")
+	require.NoError(t, err)
+	assert.Empty(t, decoded)
+}
+
+func TestCursorSigner_RejectsForgedCursors(t *testing.T) {
+	signer := newCursorSigner(&config.Config{CursorSigningKey: "test-key"})
+	signed := signer.sign("com.example/server-a:1.0.0")
+	payload, signature, _ := strings.Cut(signed, ".")
+
+	t.Run("tampered payload", func(t *testing.T) {
+		forged := newCursorSigner(&config.Config{CursorSigningKey: "test-key"}).sign("com.example/server-z:1.0.0")
+		forgedPayload, _, _ := strings.Cut(forged, ".")
+		_, err := signer.verify(forgedPayload + "." + signature)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("signed with another key", func(t *testing.T) {
+		other := newCursorSigner(&config.Config{CursorSigningKey: "other-key"})
+		_, err := signer.verify(other.sign("com.example/server-a:1.0.0"))
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("truncated signature", func(t *testing.T) {
+		_, err := newCursorSigner(&config.Config{CursorSigningKey: "test-key", RequireSignedCursors: true}).
+			verify(payload + "." + signature[:10])
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestCursorSigner_UnsignedCursors(t *testing.T) {
+	legacy := "com.example/server-alpha:1.0.0"
+
+	decoded, err := newCursorSigner(&config.Config{}).verify(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, legacy, decoded, "unsigned cursors are accepted while signing is not required")
+
+	_, err = newCursorSigner(&config.Config{RequireSignedCursors: true}).verify(legacy)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestCursorSigner_DerivesKeyFromJWTKey(t *testing.T) {
+	cfg := &config.Config{JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"}
+	signed := newCursorSigner(cfg).sign("com.example/server:1.0.0")
+
+	decoded, err := newCursorSigner(cfg).verify(signed)
+	require.NoError(t, err, "instances sharing a JWT key should accept each other's cursors")
+	assert.Equal(t, "com.example/server:1.0.0", decoded)
+}
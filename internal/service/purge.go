@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// PurgeDeleted permanently removes deleted versions last updated before a cutoff, for data retention. The
+// versions are removed in a single transaction, after which the latest version of each affected server is
+// recomputed, since a purged deleted version may have been its latest.
+func (s *registryServiceImpl) PurgeDeleted(ctx context.Context, before time.Time) (_ *apiv0.PurgeDeletedResult, err error) {
+	ctx, span := startSpan(ctx, "PurgeDeleted")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	names, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) ([]string, error) {
+		return s.db.PurgeDeleted(ctx, tx, before)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Purge deleted: removed %d versions deleted before %s", len(names), before.UTC().Format(time.RFC3339))
+
+	affected := slices.Clone(names)
+	slices.Sort(affected)
+	for _, name := range slices.Compact(affected) {
+		_, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (bool, error) {
+			return s.reindexLatestInTransaction(ctx, tx, name)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to reindex latest version of %s: %w", name, err)
+		}
+	}
+
+	return &apiv0.PurgeDeletedResult{VersionsPurged: len(names)}, nil
+}
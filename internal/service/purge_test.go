@@ -0,0 +1,84 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestPurgeDeleted(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	for _, v := range []struct{ name, version string }{
+		{"com.example/old-deleted", "1.0.0"},
+		{"com.example/recent-deleted", "1.0.0"},
+		{"com.example/old-active", "1.0.0"},
+		{"com.example/mixed", "1.0.0"},
+		{"com.example/mixed", "2.0.0"},
+	} {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Name:        v.name,
+			Description: "Purge test server",
+			Version:     v.version,
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+	}
+
+	// Backdate the versions, as if they were deleted or last changed long ago
+	err := testDB.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		for _, statement := range []string{
+			`UPDATE servers SET status = 'deleted', updated_at = NOW() - INTERVAL '40 days' WHERE server_name = 'com.example/old-deleted'`,
+			`UPDATE servers SET status = 'deleted', updated_at = NOW() - INTERVAL '1 day' WHERE server_name = 'com.example/recent-deleted'`,
+			`UPDATE servers SET updated_at = NOW() - INTERVAL '40 days' WHERE server_name = 'com.example/old-active'`,
+			`UPDATE servers SET status = 'deleted', updated_at = NOW() - INTERVAL '40 days' WHERE server_name = 'com.example/mixed' AND version = '2.0.0'`,
+		} {
+			if _, err := tx.Exec(ctx, statement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	result, err := service.PurgeDeleted(ctx, time.Now().AddDate(0, 0, -30))
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.VersionsPurged)
+
+	exists := func(serverName, version string) bool {
+		t.Helper()
+		_, err := service.GetServerByNameAndVersion(ctx, serverName, version)
+		if errors.Is(err, database.ErrNotFound) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+	assert.False(t, exists("com.example/old-deleted", "1.0.0"))
+	assert.False(t, exists("com.example/mixed", "2.0.0"))
+	assert.True(t, exists("com.example/recent-deleted", "1.0.0"))
+	assert.True(t, exists("com.example/old-active", "1.0.0"))
+	assert.True(t, exists("com.example/mixed", "1.0.0"))
+
+	// The purged version was the latest, so the remaining version takes over
+	latest, err := service.GetServerByName(ctx, "com.example/mixed")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", latest.Server.Version)
+
+	// Purging again finds nothing left to remove
+	result, err = service.PurgeDeleted(ctx, time.Now().AddDate(0, 0, -30))
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.VersionsPurged)
+}
@@ -4,51 +4,142 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
 	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-const maxServerVersionsPerServer = 10000
+// defaultPublishDBTimeout is used when the config does not set PublishDBTimeout
+const defaultPublishDBTimeout = 5 * time.Second
+
+// eventHistorySize is how many recent change events are kept for subscribers catching up after a reconnect
+const eventHistorySize = 1000
+
+// Span attributes identifying the server version a registry service operation works on
+const (
+	serverNameKey    = attribute.Key("mcp.server.name")
+	serverVersionKey = attribute.Key("mcp.server.version")
+)
+
+// startSpan starts a span for a registry service operation; database calls made with the returned context
+// become its children
+func startSpan(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return telemetry.StartSpan(ctx, "RegistryService."+operation, attrs...)
+}
 
 // registryServiceImpl implements the RegistryService interface using our Database
 type registryServiceImpl struct {
-	db  database.Database
-	cfg *config.Config
+	db         database.Database
+	cfg        *config.Config
+	statuses   *statusMachine
+	statsCache statsCache
+	events     *events.Bus
+	webhooks   *events.WebhookDispatcher
+	cursors    *cursorSigner
 }
 
 // NewRegistryService creates a new registry service with the provided database
 func NewRegistryService(db database.Database, cfg *config.Config) RegistryService {
-	return &registryServiceImpl{
-		db:  db,
-		cfg: cfg,
+	s := &registryServiceImpl{
+		db:       db,
+		cfg:      cfg,
+		statuses: newStatusMachine(cfg),
+		events:   events.NewBus(eventHistorySize),
+		cursors:  newCursorSigner(cfg),
 	}
+	if len(cfg.WebhookURLs) > 0 {
+		s.webhooks = events.NewWebhookDispatcher(events.WebhookOptions{
+			URLs:          cfg.WebhookURLs,
+			Secret:        cfg.WebhookSecret,
+			QueueSize:     cfg.WebhookQueueSize,
+			RetryAttempts: cfg.WebhookRetryAttempts,
+			RetryBackoff:  cfg.WebhookRetryBackoff,
+		})
+	}
+	return s
+}
+
+// Built-in list page sizes, used when the config leaves them unset
+const (
+	defaultListLimit    = 30
+	defaultMaxListLimit = 100
+)
+
+// ListLimits returns the configured default and maximum page size of list requests
+func (s *registryServiceImpl) ListLimits() (int, int) {
+	defaultLimit, maxLimit := defaultListLimit, defaultMaxListLimit
+	if s.cfg.DefaultListLimit > 0 {
+		defaultLimit = s.cfg.DefaultListLimit
+	}
+	if s.cfg.MaxListLimit > 0 {
+		maxLimit = s.cfg.MaxListLimit
+	}
+	return defaultLimit, maxLimit
 }
 
 // ListServers returns registry entries with cursor-based pagination and optional filtering
 func (s *registryServiceImpl) ListServers(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
-	// If limit is not set or negative, use a default limit
+	page, err := s.ListServersPage(ctx, filter, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return page.Servers, page.NextCursor, nil
+}
+
+// ListServersPage returns a page of registry entries with optional filtering, along with signed cursors for the
+// next page and, when paging from a cursor, the previous page
+func (s *registryServiceImpl) ListServersPage(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) (_ *database.ServerPage, err error) {
+	ctx, span := startSpan(ctx, "ListServers")
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	if limit <= 0 {
-		limit = 30
+		limit, _ = s.ListLimits()
 	}
 
-	// Use the database's ListServers method with pagination and filtering
-	serverRecords, nextCursor, err := s.db.ListServers(ctx, nil, filter, cursor, limit)
+	dbCursor, err := s.cursors.verify(cursor)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
-	return serverRecords, nextCursor, nil
+	// Use the database's ListServersPage method with pagination and filtering
+	page, err := s.db.ListServersPage(ctx, nil, filter, dbCursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page.NextCursor = s.cursors.sign(page.NextCursor)
+	page.PrevCursor = s.cursors.sign(page.PrevCursor)
+	return page, nil
 }
 
 // GetServerByName retrieves the latest version of a server by its server name
-func (s *registryServiceImpl) GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error) {
+func (s *registryServiceImpl) GetServerByName(ctx context.Context, serverName string) (_ *apiv0.ServerResponse, err error) {
+	ctx, span := startSpan(ctx, "GetServerByName", serverNameKey.String(serverName))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	serverRecord, err := s.db.GetServerByName(ctx, nil, serverName)
+	if errors.Is(err, database.ErrNotFound) {
+		// Renamed servers are still found by their old names, through aliases
+		target, aliasErr := s.resolveAlias(ctx, nil, serverName)
+		if aliasErr != nil {
+			if errors.Is(aliasErr, database.ErrNotFound) {
+				return nil, err
+			}
+			return nil, aliasErr
+		}
+		serverRecord, err = s.db.GetServerByName(ctx, nil, target)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +148,10 @@ func (s *registryServiceImpl) GetServerByName(ctx context.Context, serverName st
 }
 
 // GetServerByNameAndVersion retrieves a specific version of a server by server name and version
-func (s *registryServiceImpl) GetServerByNameAndVersion(ctx context.Context, serverName string, version string) (*apiv0.ServerResponse, error) {
+func (s *registryServiceImpl) GetServerByNameAndVersion(ctx context.Context, serverName string, version string) (_ *apiv0.ServerResponse, err error) {
+	ctx, span := startSpan(ctx, "GetServerByNameAndVersion", serverNameKey.String(serverName), serverVersionKey.String(version))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	serverRecord, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version)
 	if err != nil {
 		return nil, err
@@ -66,8 +160,20 @@ func (s *registryServiceImpl) GetServerByNameAndVersion(ctx context.Context, ser
 	return serverRecord, nil
 }
 
+// GetRawServer retrieves a specific version of a server as stored, with its value undecoded and its metadata
+// columns separate, for debugging data issues
+func (s *registryServiceImpl) GetRawServer(ctx context.Context, serverName string, version string) (_ *apiv0.RawServer, err error) {
+	ctx, span := startSpan(ctx, "GetRawServer", serverNameKey.String(serverName), serverVersionKey.String(version))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.db.GetRawServer(ctx, nil, serverName, version)
+}
+
 // GetAllVersionsByServerName retrieves all versions of a server by server name
-func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, serverName string) ([]*apiv0.ServerResponse, error) {
+func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, serverName string) (_ []*apiv0.ServerResponse, err error) {
+	ctx, span := startSpan(ctx, "GetAllVersionsByServerName", serverNameKey.String(serverName))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	serverRecords, err := s.db.GetAllVersionsByServerName(ctx, nil, serverName)
 	if err != nil {
 		return nil, err
@@ -76,50 +182,217 @@ func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, se
 	return serverRecords, nil
 }
 
+// CheckVersionsExist reports which of the given versions of a server exist, so publishing tools can skip
+// versions already uploaded
+func (s *registryServiceImpl) CheckVersionsExist(ctx context.Context, serverName string, versions []string) (_ map[string]bool, err error) {
+	ctx, span := startSpan(ctx, "CheckVersionsExist", serverNameKey.String(serverName))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.db.CheckVersionsExist(ctx, nil, serverName, versions)
+}
+
+// GetLatestServerVersionForMajor retrieves the newest semver version of a server whose major version is major,
+// ordered like latest version selection, for consumers pinned to a major version. Non-semver versions have no
+// major version and are skipped, as are versions pending review, which are never latest.
+func (s *registryServiceImpl) GetLatestServerVersionForMajor(ctx context.Context, serverName string, major int) (_ *apiv0.ServerResponse, err error) {
+	ctx, span := startSpan(ctx, "GetLatestServerVersionForMajor", serverNameKey.String(serverName))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	versions, err := s.db.GetAllVersionsByServerName(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *apiv0.ServerResponse
+	for _, server := range versions {
+		if statusOf(server) == model.StatusPending {
+			continue
+		}
+		if versionMajor, ok := majorVersion(server.Server.Version); !ok || versionMajor != major {
+			continue
+		}
+		if latest == nil || CompareVersions(server.Server.Version, latest.Server.Version, publishedAt(server), publishedAt(latest)) > 0 {
+			latest = server
+		}
+	}
+	if latest == nil {
+		return nil, database.ErrNotFound
+	}
+	return latest, nil
+}
+
 // CreateServer creates a new server version
 func (s *registryServiceImpl) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
-	// Wrap the entire operation in a transaction
-	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.createServerInTransaction(ctx, tx, req)
+	return s.PublishServer(ctx, req, PublishOptions{})
+}
+
+// PublishServer creates a new server version with publisher-controlled registry metadata
+func (s *registryServiceImpl) PublishServer(ctx context.Context, req *apiv0.ServerJSON, opts PublishOptions) (_ *apiv0.ServerResponse, err error) {
+	ctx, span := startSpan(ctx, "PublishServer", serverNameKey.String(req.Name), serverVersionKey.String(req.Version))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	published, err := s.publishServer(ctx, req, opts)
+	if opts.DryRun {
+		return published, err
+	}
+	// Audit under the stored name, which may differ in case from the requested one
+	serverName := req.Name
+	if published != nil {
+		serverName = published.Server.Name
+	}
+	s.recordAudit(ctx, opts.Actor, AuditActionPublish, serverName, req.Version, err)
+	if err != nil {
+		return nil, err
+	}
+
+	s.emitEvent(events.TypeServerPublished, published)
+	return published, nil
+}
+
+// publishServer validates and stores a new server version
+func (s *registryServiceImpl) publishServer(ctx context.Context, req *apiv0.ServerJSON, opts PublishOptions) (*apiv0.ServerResponse, error) {
+	// Validate the request before starting the transaction, so slow registry ownership checks run under their
+	// own timeout and don't hold a database transaction open
+	verified, err := validators.VerifyPublishRequest(ctx, *req, s.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.publishDBTimeout())
+	defer cancel()
+
+	// Wrap the database operations in a transaction
+	return database.InTransactionT(dbCtx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		return s.createServerInTransaction(ctx, tx, req, opts, verified)
 	})
 }
 
-// createServerInTransaction contains the actual CreateServer logic within a transaction
-func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
-	// Validate the request
-	if err := validators.ValidatePublishRequest(ctx, *req, s.cfg); err != nil {
+// ErrInvalidBatch is returned when a batch publish is empty or does not contain versions of a single server
+var ErrInvalidBatch = errors.New("invalid publish batch")
+
+// PublishBatch publishes several versions of the same server in one transaction, acquiring the publish lock
+// once. Every version is validated before anything is written, and if any version fails the whole batch is
+// rolled back. At most one version of the server is latest afterwards.
+func (s *registryServiceImpl) PublishBatch(ctx context.Context, servers []apiv0.ServerJSON) (_ []*apiv0.ServerResponse, err error) {
+	ctx, span := startSpan(ctx, "PublishBatch")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	published, err := s.publishBatch(ctx, servers)
+	if err != nil {
+		if len(servers) > 0 {
+			s.recordAudit(ctx, "", AuditActionPublish, servers[0].Name, "", err)
+		}
 		return nil, err
 	}
 
+	for _, server := range published {
+		s.recordAudit(ctx, "", AuditActionPublish, server.Server.Name, server.Server.Version, nil)
+		s.emitEvent(events.TypeServerPublished, server)
+	}
+	return published, nil
+}
+
+// publishBatch validates and stores the versions of a batch in one transaction
+func (s *registryServiceImpl) publishBatch(ctx context.Context, servers []apiv0.ServerJSON) ([]*apiv0.ServerResponse, error) {
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("%w: no versions to publish", ErrInvalidBatch)
+	}
+	serverName := servers[0].Name
+	seenVersions := make(map[string]bool, len(servers))
+	for _, server := range servers {
+		if server.Name != serverName {
+			return nil, fmt.Errorf("%w: versions span multiple servers (%s and %s)", ErrInvalidBatch, serverName, server.Name)
+		}
+		if seenVersions[server.Version] {
+			return nil, fmt.Errorf("%w: version %s appears more than once in the batch", database.ErrInvalidVersion, server.Version)
+		}
+		seenVersions[server.Version] = true
+	}
+
+	// Validate every version before starting the transaction, as for single publishes
+	verified := make([]bool, len(servers))
+	for i := range servers {
+		var err error
+		if verified[i], err = validators.VerifyPublishRequest(ctx, servers[i], s.cfg); err != nil {
+			return nil, fmt.Errorf("version %s: %w", servers[i].Version, err)
+		}
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.publishDBTimeout())
+	defer cancel()
+
+	return database.InTransactionT(dbCtx, s.db, func(ctx context.Context, tx pgx.Tx) ([]*apiv0.ServerResponse, error) {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		results := make([]*apiv0.ServerResponse, 0, len(servers))
+		for i := range servers {
+			result, err := s.createServerInTransaction(ctx, tx, &servers[i], PublishOptions{SkipPublishLock: true}, verified[i])
+			if err != nil {
+				return nil, fmt.Errorf("version %s: %w", servers[i].Version, err)
+			}
+			results = append(results, result)
+		}
+
+		// Versions are inserted one at a time, so an earlier version of the batch may have been latest when it
+		// was inserted; report the latest flag as it stands once the whole batch is in
+		latest, err := s.db.GetCurrentLatestVersion(ctx, tx, serverName)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+		for _, result := range results {
+			result.Meta.Official.IsLatest = latest != nil && latest.Server.Version == result.Server.Version
+		}
+		return results, nil
+	})
+}
+
+// createServerInTransaction contains the actual CreateServer logic within a transaction. verified records that
+// validation proved ownership of the server's packages.
+func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON, opts PublishOptions, verified bool) (*apiv0.ServerResponse, error) {
 	publishTime := time.Now()
-	serverJSON := *req
+	serverJSON := withoutOfficialMeta(*req)
 
 	// Acquire advisory lock to prevent concurrent publishes of the same server
-	if err := s.db.AcquirePublishLock(ctx, tx, serverJSON.Name); err != nil {
+	if !opts.SkipPublishLock {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverJSON.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Server names are unique regardless of case, so a name differing only in case from an existing server's
+	// publishes a version of that server, under its stored name
+	existingName, err := s.db.FindServerNameIgnoringCase(ctx, tx, serverJSON.Name)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
 		return nil, err
 	}
+	if existingName != "" {
+		serverJSON.Name = existingName
+	}
 
 	// Check for duplicate remote URLs
 	if err := s.validateNoDuplicateRemoteURLs(ctx, tx, serverJSON); err != nil {
 		return nil, err
 	}
 
-	// Check we haven't exceeded the maximum versions allowed for a server
-	versionCount, err := s.db.CountServerVersions(ctx, tx, serverJSON.Name)
-	if err != nil && !errors.Is(err, database.ErrNotFound) {
+	// Check this isn't a duplicate version. A deleted version whose cooldown has elapsed is replaced instead.
+	replacesDeleted, err := s.checkDuplicateVersion(ctx, tx, serverJSON.Name, serverJSON.Version, publishTime)
+	if err != nil {
 		return nil, err
 	}
-	if versionCount >= maxServerVersionsPerServer {
-		return nil, database.ErrMaxServersReached
-	}
 
-	// Check this isn't a duplicate version
-	versionExists, err := s.db.CheckVersionExists(ctx, tx, serverJSON.Name, serverJSON.Version)
-	if err != nil {
+	// Check we haven't exceeded the maximum versions allowed for a server, not counting a replaced version
+	versionCount, err := s.db.CountServerVersions(ctx, tx, serverJSON.Name)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
 		return nil, err
 	}
-	if versionExists {
-		return nil, database.ErrInvalidVersion
+	if replacesDeleted {
+		versionCount--
+	}
+	maxVersions := s.cfg.MaxVersionsPerServer
+	if versionCount >= maxVersions {
+		return nil, &database.MaxVersionsError{Count: versionCount, Limit: maxVersions}
 	}
 
 	// Get current latest version to determine if new version should be latest
@@ -135,12 +408,46 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 		if currentLatest.Meta.Official != nil {
 			existingPublishedAt = currentLatest.Meta.Official.PublishedAt
 		}
-		isNewLatest = CompareVersions(
+		// A replaced deleted version hands its latest flag over to its replacement
+		isNewLatest = currentLatest.Server.Version == serverJSON.Version || CompareVersions(
 			serverJSON.Version,
 			currentLatest.Server.Version,
 			publishTime,
 			existingPublishedAt,
 		) > 0
+		if opts.RequireNewer && !isNewLatest {
+			return nil, &VersionNotNewerError{Version: serverJSON.Version, Latest: currentLatest.Server.Version}
+		}
+	}
+
+	// Versions in namespaces that require review are held as pending, and only become latest once approved
+	status := model.StatusActive /* New versions are active by default */
+	if s.requiresReview(serverJSON.Name) {
+		status = model.StatusPending
+		isNewLatest = false
+	}
+
+	// Create metadata for the new server
+	officialMeta := &apiv0.RegistryExtensions{
+		Status:           status,
+		PublishedAt:      publishTime,
+		UpdatedAt:        publishTime,
+		IsLatest:         isNewLatest,
+		Unlisted:         opts.Unlisted,
+		OriginRegistry:   opts.OriginRegistry,
+		PublisherSubject: opts.PublisherSubject,
+		Verified:         verified,
+	}
+	if verified {
+		officialMeta.VerifiedAt = &publishTime
+	}
+
+	// A dry run reports the version as it would be published, without writing anything
+	if opts.DryRun {
+		return &apiv0.ServerResponse{
+			Server: serverJSON,
+			Meta:   apiv0.ResponseMeta{Official: officialMeta},
+		}, nil
 	}
 
 	// Unmark old latest version if needed
@@ -150,24 +457,115 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 		}
 	}
 
-	// Create metadata for the new server
-	officialMeta := &apiv0.RegistryExtensions{
-		Status:      model.StatusActive, /* New versions are active by default */
-		PublishedAt: publishTime,
-		UpdatedAt:   publishTime,
-		IsLatest:    isNewLatest,
+	// Remove the deleted version being replaced, freeing its version string
+	if replacesDeleted {
+		if err := s.db.DeleteServerVersion(ctx, tx, serverJSON.Name, serverJSON.Version); err != nil {
+			return nil, err
+		}
 	}
 
 	// Insert new server version
 	return s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
 }
 
-// validateNoDuplicateRemoteURLs checks that no other server is using the same remote URLs
+// checkDuplicateVersion returns ErrInvalidVersion if the version has already been published. A deleted
+// version no longer counts once DeletedVersionReuseCooldown has elapsed since its deletion, in which case
+// it reports that publishing replaces it.
+func (s *registryServiceImpl) checkDuplicateVersion(ctx context.Context, tx pgx.Tx, serverName, version string, now time.Time) (bool, error) {
+	versionExists, err := s.db.CheckVersionExists(ctx, tx, serverName, version)
+	if err != nil {
+		return false, err
+	}
+	if !versionExists {
+		return false, nil
+	}
+
+	if s.cfg.DeletedVersionReuseCooldown <= 0 {
+		return false, database.ErrInvalidVersion
+	}
+	existing, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+	if err != nil {
+		return false, err
+	}
+	official := existing.Meta.Official
+	if official == nil || official.Status != model.StatusDeleted || now.Sub(official.UpdatedAt) < s.cfg.DeletedVersionReuseCooldown {
+		return false, database.ErrInvalidVersion
+	}
+	return true, nil
+}
+
+// requiresReview reports whether publishes of the server are held for admin review by the configured policy.
+// Namespaces are compared case-insensitively, like server names.
+func (s *registryServiceImpl) requiresReview(serverName string) bool {
+	namespace, _, _ := strings.Cut(strings.ToLower(serverName), "/")
+	for _, reviewed := range s.cfg.ReviewRequiredNamespaces {
+		reviewed = strings.ToLower(reviewed)
+		if namespace == reviewed || strings.HasPrefix(namespace, reviewed+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// promoteIfLatest marks an approved version as latest when it is newer than the current latest version
+func (s *registryServiceImpl) promoteIfLatest(ctx context.Context, tx pgx.Tx, server *apiv0.ServerResponse) (*apiv0.ServerResponse, error) {
+	currentLatest, err := s.db.GetCurrentLatestVersion(ctx, tx, server.Server.Name)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+
+	if currentLatest != nil {
+		var publishedAt, existingPublishedAt time.Time
+		if server.Meta.Official != nil {
+			publishedAt = server.Meta.Official.PublishedAt
+		}
+		if currentLatest.Meta.Official != nil {
+			existingPublishedAt = currentLatest.Meta.Official.PublishedAt
+		}
+		if CompareVersions(server.Server.Version, currentLatest.Server.Version, publishedAt, existingPublishedAt) <= 0 {
+			return server, nil
+		}
+		if err := s.db.UnmarkAsLatest(ctx, tx, server.Server.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.db.MarkAsLatest(ctx, tx, server.Server.Name, server.Server.Version)
+}
+
+// withoutOfficialMeta drops any registry-managed metadata declared in the server JSON, which the registry
+// tracks separately and must not store as part of the server
+func withoutOfficialMeta(server apiv0.ServerJSON) apiv0.ServerJSON {
+	if server.Meta == nil || server.Meta.Official == nil {
+		return server
+	}
+	meta := *server.Meta
+	meta.Official = nil
+	server.Meta = &meta
+	if meta.PublisherProvided == nil {
+		server.Meta = nil
+	}
+	return server
+}
+
+// publishDBTimeout returns how long the database transaction of a publish may take
+func (s *registryServiceImpl) publishDBTimeout() time.Duration {
+	if s.cfg.PublishDBTimeout > 0 {
+		return s.cfg.PublishDBTimeout
+	}
+	return defaultPublishDBTimeout
+}
+
+// validateNoDuplicateRemoteURLs checks that no other server is using the same remote URLs. URLs are compared
+// as validators.NormalizeRemoteURL does, so a different host case or trailing slash doesn't make a new URL.
 func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context, tx pgx.Tx, serverDetail apiv0.ServerJSON) error {
+	now := time.Now()
+
 	// Check each remote URL in the new server for conflicts
 	for _, remote := range serverDetail.Remotes {
 		// Use filter to find servers with this remote URL
-		filter := &database.ServerFilter{RemoteURL: &remote.URL}
+		// Unlisted servers still hold their remote URLs
+		filter := &database.ServerFilter{RemoteURL: &remote.URL, IncludeUnlisted: true}
 
 		conflictingServers, _, err := s.db.ListServers(ctx, tx, filter, "", 1000)
 		if err != nil {
@@ -175,41 +573,201 @@ func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context,
 		}
 
 		// Check if any conflicting server has a different name
+		normalizedURL := validators.NormalizeRemoteURL(remote.URL)
 		for _, conflictingServer := range conflictingServers {
-			if conflictingServer.Server.Name != serverDetail.Name {
-				return fmt.Errorf("remote URL %s is already used by server %s", remote.URL, conflictingServer.Server.Name)
+			if conflictingServer.Server.Name == serverDetail.Name {
+				continue
+			}
+			if !usesRemoteURL(conflictingServer.Server, normalizedURL) {
+				continue
 			}
+			if s.isRemoteURLReleased(conflictingServer, now) {
+				continue
+			}
+			return &database.DuplicateRemoteURLError{URL: remote.URL, ServerName: conflictingServer.Server.Name}
 		}
 	}
 
 	return nil
 }
 
+// usesRemoteURL reports whether one of the server's remotes has the given normalized URL. The database lookup is
+// case-insensitive on the whole URL, so its results are re-checked here, where only the scheme and host are
+// case-folded.
+func usesRemoteURL(server apiv0.ServerJSON, normalizedURL string) bool {
+	for _, remote := range server.Remotes {
+		if validators.NormalizeRemoteURL(remote.URL) == normalizedURL {
+			return true
+		}
+	}
+	return false
+}
+
+// isRemoteURLReleased reports whether a server version no longer holds a claim on its remote URLs.
+// A version releases its URLs once it is no longer active and the configured cooldown has elapsed
+// since its last status change, which stops another server from immediately hijacking the URL.
+func (s *registryServiceImpl) isRemoteURLReleased(server *apiv0.ServerResponse, now time.Time) bool {
+	if s.cfg.RemoteURLReuseCooldown <= 0 || server.Meta.Official == nil {
+		return false
+	}
+
+	if server.Meta.Official.Status == model.StatusActive {
+		return false
+	}
+
+	return now.Sub(server.Meta.Official.UpdatedAt) >= s.cfg.RemoteURLReuseCooldown
+}
+
 // UpdateServer updates an existing server with new details
 func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error) {
+	return s.EditServer(ctx, serverName, version, req, UpdateOptions{Status: newStatus})
+}
+
+// EditServer updates an existing server with new details and optionally its registry metadata
+func (s *registryServiceImpl) EditServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, opts UpdateOptions) (_ *apiv0.ServerResponse, err error) {
+	ctx, span := startSpan(ctx, "EditServer", serverNameKey.String(serverName), serverVersionKey.String(version))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// Wrap the entire operation in a transaction
-	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.updateServerInTransaction(ctx, tx, serverName, version, req, newStatus)
+	var previousStatus model.Status
+	updated, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		return s.updateServerInTransaction(ctx, tx, serverName, version, req, opts, &previousStatus)
 	})
+	s.recordAudit(ctx, opts.Actor, AuditActionEdit, serverName, version, err)
+	if err != nil {
+		return nil, err
+	}
+
+	eventType := events.TypeServerUpdated
+	if updated.Meta.Official != nil && updated.Meta.Official.Status != previousStatus {
+		eventType = events.TypeServerStatusChanged
+	}
+	s.emitEvent(eventType, updated)
+	return updated, nil
+}
+
+// SetServerStatus sets the status of all versions of a server, or only the listed versions, in a single transaction.
+// Only the status changes, so no registry validation is performed. Latest version selection is unaffected.
+func (s *registryServiceImpl) SetServerStatus(ctx context.Context, serverName string, versions []string, status model.Status, actor string) (_ int, err error) {
+	ctx, span := startSpan(ctx, "SetServerStatus", serverNameKey.String(serverName))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	updated, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) ([]*apiv0.ServerResponse, error) {
+		return s.setServerStatusInTransaction(ctx, tx, serverName, versions, status)
+	})
+	if err != nil {
+		// A failed change leaves every version untouched; record it once, against the versions requested
+		s.recordAudit(ctx, actor, AuditActionSetStatus, serverName, strings.Join(versions, ","), err)
+		return 0, err
+	}
+
+	for _, server := range updated {
+		s.recordAudit(ctx, actor, AuditActionSetStatus, serverName, server.Server.Version, nil)
+		s.emitEvent(events.TypeServerStatusChanged, server)
+	}
+	return len(updated), nil
+}
+
+// setServerStatusInTransaction contains the actual SetServerStatus logic within a transaction
+func (s *registryServiceImpl) setServerStatusInTransaction(ctx context.Context, tx pgx.Tx, serverName string, versions []string, status model.Status) ([]*apiv0.ServerResponse, error) {
+	// Acquire advisory lock to prevent concurrent publishes and edits of the same server
+	if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+		return nil, err
+	}
+
+	allVersions, err := s.db.GetAllVersionsByServerName(ctx, tx, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := allVersions
+	if len(versions) > 0 {
+		byVersion := make(map[string]*apiv0.ServerResponse, len(allVersions))
+		for _, server := range allVersions {
+			byVersion[server.Server.Version] = server
+		}
+		targets = make([]*apiv0.ServerResponse, 0, len(versions))
+		for _, version := range slices.Compact(slices.Sorted(slices.Values(versions))) {
+			server, ok := byVersion[version]
+			if !ok {
+				return nil, fmt.Errorf("%w: version %s of %s", database.ErrNotFound, version, serverName)
+			}
+			targets = append(targets, server)
+		}
+	}
+
+	// Check every transition before changing anything, so a disallowed change leaves all versions untouched
+	for _, server := range targets {
+		if err := s.statuses.check(statusOf(server), status); err != nil {
+			return nil, err
+		}
+	}
+
+	var updated []*apiv0.ServerResponse
+	for _, server := range targets {
+		if statusOf(server) == status {
+			continue
+		}
+		updatedServer, err := s.db.SetServerStatus(ctx, tx, serverName, server.Server.Version, string(status))
+		if err != nil {
+			return nil, err
+		}
+		if statusOf(server) == model.StatusPending && status == model.StatusActive {
+			updatedServer, err = s.promoteIfLatest(ctx, tx, updatedServer)
+			if err != nil {
+				return nil, err
+			}
+		}
+		updated = append(updated, updatedServer)
+	}
+
+	return updated, nil
+}
+
+// statusOf returns the status of a stored server version, treating missing metadata as active
+func statusOf(server *apiv0.ServerResponse) model.Status {
+	if server.Meta.Official != nil {
+		return server.Meta.Official.Status
+	}
+	return model.StatusActive
 }
 
 // updateServerInTransaction contains the actual UpdateServer logic within a transaction
-func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx pgx.Tx, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error) {
+func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx pgx.Tx, serverName, version string, req *apiv0.ServerJSON, opts UpdateOptions, previousStatus *model.Status) (*apiv0.ServerResponse, error) {
+	newStatus := opts.Status
+
 	// Get current server to check if it's deleted or being deleted
 	currentServer, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
 	if err != nil {
 		return nil, err
 	}
 
+	// Reject status changes the state machine does not allow
+	currentStatus := model.StatusActive
+	if currentServer.Meta.Official != nil {
+		currentStatus = currentServer.Meta.Official.Status
+	}
+	*previousStatus = currentStatus
+	if newStatus != nil {
+		if err := s.statuses.check(currentStatus, model.Status(*newStatus)); err != nil {
+			return nil, err
+		}
+	}
+	setsDeprecation := opts.DeprecationMessage != nil || opts.SupersededBy != nil
+	if setsDeprecation && (newStatus == nil || model.Status(*newStatus) != model.StatusDeprecated) {
+		return nil, ErrDeprecationWithoutStatus
+	}
+
 	// Skip registry validation if:
 	// 1. Server is currently deleted, OR
 	// 2. Server is being set to deleted status
-	currentlyDeleted := currentServer.Meta.Official != nil && currentServer.Meta.Official.Status == model.StatusDeleted
+	currentlyDeleted := currentStatus == model.StatusDeleted
 	beingDeleted := newStatus != nil && *newStatus == string(model.StatusDeleted)
 	skipRegistryValidation := currentlyDeleted || beingDeleted
 
 	// Validate the request, potentially skipping registry validation for deleted servers
-	if err := s.validateUpdateRequest(ctx, *req, skipRegistryValidation); err != nil {
+	verified, err := s.validateUpdateRequest(ctx, *req, skipRegistryValidation)
+	if err != nil {
 		return nil, err
 	}
 
@@ -219,7 +777,7 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 	}
 
 	// Merge the request with the current server, preserving metadata
-	updatedServer := *req
+	updatedServer := withoutOfficialMeta(*req)
 
 	// Check for duplicate remote URLs using the updated server
 	if err := s.validateNoDuplicateRemoteURLs(ctx, tx, updatedServer); err != nil {
@@ -227,41 +785,139 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 	}
 
 	// Update server in database
-	updatedServerResponse, err := s.db.UpdateServer(ctx, tx, serverName, version, &updatedServer)
+	if _, err := s.db.UpdateServer(ctx, tx, serverName, version, &updatedServer); err != nil {
+		return nil, err
+	}
+
+	// The packages may have changed, so the version is only verified if this edit proved ownership again
+	var verifiedAt *time.Time
+	if verified {
+		now := time.Now()
+		verifiedAt = &now
+	}
+	updatedServerResponse, err := s.db.SetServerVerified(ctx, tx, serverName, version, verifiedAt)
 	if err != nil {
 		return nil, err
 	}
 
 	// Handle status change if provided
 	if newStatus != nil {
-		updatedWithStatus, err := s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus)
+		updatedServerResponse, err = s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus)
+		if err != nil {
+			return nil, err
+		}
+
+		// Approving a pending version makes it live, so it may now be the latest version
+		if currentStatus == model.StatusPending && model.Status(*newStatus) == model.StatusActive {
+			updatedServerResponse, err = s.promoteIfLatest(ctx, tx, updatedServerResponse)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Record the deprecation notice, keeping any part of the current one that isn't replaced
+	if setsDeprecation {
+		official := updatedServerResponse.Meta.Official
+		message, supersededBy := official.DeprecationMessage, official.SupersededBy
+		if opts.DeprecationMessage != nil {
+			message = *opts.DeprecationMessage
+		}
+		if opts.SupersededBy != nil {
+			supersededBy = *opts.SupersededBy
+			if err := s.validateSuccessor(ctx, tx, serverName, supersededBy); err != nil {
+				return nil, err
+			}
+		}
+		updatedServerResponse, err = s.db.SetServerDeprecation(ctx, tx, serverName, version, message, supersededBy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Handle visibility change if provided
+	if opts.Unlisted != nil {
+		updatedServerResponse, err = s.db.SetServerUnlisted(ctx, tx, serverName, version, *opts.Unlisted)
 		if err != nil {
 			return nil, err
 		}
-		return updatedWithStatus, nil
 	}
 
 	return updatedServerResponse, nil
 }
 
-// validateUpdateRequest validates an update request with optional registry validation skipping
-func (s *registryServiceImpl) validateUpdateRequest(ctx context.Context, req apiv0.ServerJSON, skipRegistryValidation bool) error {
-	// Always validate the server JSON structure
-	if err := validators.ValidateServerJSON(&req); err != nil {
+// validateSuccessor checks that a deprecated server's successor is another server in the registry
+func (s *registryServiceImpl) validateSuccessor(ctx context.Context, tx pgx.Tx, serverName, supersededBy string) error {
+	if supersededBy == "" {
+		return nil
+	}
+	if supersededBy == serverName {
+		return fmt.Errorf("%w: a server cannot supersede itself", ErrInvalidSuccessor)
+	}
+	if _, err := s.db.GetServerByName(ctx, tx, supersededBy); err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return fmt.Errorf("%w: server %s does not exist", ErrInvalidSuccessor, supersededBy)
+		}
 		return err
 	}
+	return nil
+}
+
+// validateUpdateRequest validates an update request with optional registry validation skipping, reporting
+// whether ownership of every package was proven
+func (s *registryServiceImpl) validateUpdateRequest(ctx context.Context, req apiv0.ServerJSON, skipRegistryValidation bool) (bool, error) {
+	// Always validate the server JSON structure, within the same size limits as publishing
+	if err := validators.ValidateServerJSONWithLimits(&req, validators.ServerJSONLimitsFromConfig(s.cfg)); err != nil {
+		return false, err
+	}
+	if err := validators.ValidatePackagesAndRemotesLimit(req, s.cfg); err != nil {
+		return false, err
+	}
+	if err := validators.ValidateRequiredFields(req, s.cfg); err != nil {
+		return false, err
+	}
+	if err := validators.ValidateKnownCategories(req, s.cfg); err != nil {
+		return false, err
+	}
+	// Edited versions must still match the published schema
+	if err := validators.ValidateAgainstSchema(&req); err != nil {
+		return false, err
+	}
+	if err := validators.ValidateIconContentType(ctx, req, s.cfg); err != nil {
+		return false, err
+	}
+
+	// Edits may not swap in packages from registries this deployment doesn't allow
+	if err := validators.ValidateRegistryBaseURLPolicies(req.Packages, s.cfg); err != nil {
+		return false, err
+	}
 
 	// Skip registry validation if requested (for deleted servers)
 	if skipRegistryValidation || !s.cfg.EnableRegistryValidation {
-		return nil
+		return false, nil
 	}
 
 	// Perform registry validation for all packages
-	for i, pkg := range req.Packages {
-		if err := validators.ValidatePackage(ctx, pkg, req.Name); err != nil {
-			return fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
-		}
-	}
+	return validators.VerifyPackages(ctx, req.Packages, req.Name, s.cfg)
+}
 
-	return nil
+// SubscribeEvents streams registry change events published after afterID
+func (s *registryServiceImpl) SubscribeEvents(afterID int) (<-chan events.Event, func()) {
+	return s.events.Subscribe(afterID)
+}
+
+// emitEvent publishes a change event for a committed server change
+func (s *registryServiceImpl) emitEvent(eventType events.Type, server *apiv0.ServerResponse) {
+	event := events.Event{
+		Type:       eventType,
+		ServerName: server.Server.Name,
+		Version:    server.Server.Version,
+	}
+	if server.Meta.Official != nil {
+		event.Status = server.Meta.Official.Status
+	}
+	event = s.events.Publish(event)
+	if s.webhooks != nil {
+		s.webhooks.Enqueue(event)
+	}
 }
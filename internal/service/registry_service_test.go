@@ -3,19 +3,37 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// testPackages is a minimal runnable package, for servers that would otherwise declare no packages or remotes
+var testPackages = []model.Package{
+	{
+		RegistryType: model.RegistryTypeNPM,
+		Identifier:   "test-package",
+		Version:      "1.0.0",
+		Transport:    model.Transport{Type: model.TransportTypeStdio},
+	},
+}
+
 func TestValidateNoDuplicateRemoteURLs(t *testing.T) {
 	ctx := context.Background()
 
@@ -41,7 +59,7 @@ func TestValidateNoDuplicateRemoteURLs(t *testing.T) {
 	}
 
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 
 	// Create existing servers using the new CreateServer method
 	for _, server := range existingServers {
@@ -91,6 +109,44 @@ func TestValidateNoDuplicateRemoteURLs(t *testing.T) {
 			expectError: true,
 			errorMsg:    "remote URL https://api.example.com/mcp is already used by server com.example/existing-server",
 		},
+		{
+			name: "duplicate remote URL with trailing slash - should fail",
+			serverDetail: apiv0.ServerJSON{
+				Name:        "com.example/new-server-trailing-slash",
+				Description: "A new server with duplicate URL",
+				Version:     "1.0.0",
+				Remotes: []model.Transport{
+					{Type: "streamable-http", URL: "https://api.example.com/mcp/"},
+				},
+			},
+			expectError: true,
+			errorMsg:    "remote URL https://api.example.com/mcp/ is already used by server com.example/existing-server",
+		},
+		{
+			name: "duplicate remote URL with different host case - should fail",
+			serverDetail: apiv0.ServerJSON{
+				Name:        "com.example/new-server-host-case",
+				Description: "A new server with duplicate URL",
+				Version:     "1.0.0",
+				Remotes: []model.Transport{
+					{Type: "streamable-http", URL: "https://API.Example.com/mcp"},
+				},
+			},
+			expectError: true,
+			errorMsg:    "is already used by server com.example/existing-server",
+		},
+		{
+			name: "remote URL with different path case - should pass",
+			serverDetail: apiv0.ServerJSON{
+				Name:        "com.example/new-server-path-case",
+				Description: "A new server with a distinct URL",
+				Version:     "1.0.0",
+				Remotes: []model.Transport{
+					{Type: "streamable-http", URL: "https://api.example.com/MCP"},
+				},
+			},
+			expectError: false,
+		},
 		{
 			name: "updating same server with same URLs - should pass",
 			serverDetail: apiv0.ServerJSON{
@@ -114,6 +170,10 @@ func TestValidateNoDuplicateRemoteURLs(t *testing.T) {
 			if tt.expectError {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errorMsg)
+				assert.ErrorIs(t, err, database.ErrDuplicateRemoteURL)
+				var duplicateErr *database.DuplicateRemoteURLError
+				require.ErrorAs(t, err, &duplicateErr)
+				assert.Equal(t, "com.example/existing-server", duplicateErr.ServerName)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -121,16 +181,99 @@ func TestValidateNoDuplicateRemoteURLs(t *testing.T) {
 	}
 }
 
+func TestValidateNoDuplicateRemoteURLs_ReuseCooldown(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+
+	// Publish a server holding the remote URL, then deprecate it to release the URL
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+	_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+		Name:        "com.example/original-server",
+		Description: "Server that originally held the URL",
+		Version:     "1.0.0",
+		Remotes: []model.Transport{
+			{Type: "streamable-http", URL: "https://api.example.com/mcp"},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = service.UpdateServer(ctx, "com.example/original-server", "1.0.0", &apiv0.ServerJSON{
+		Name:        "com.example/original-server",
+		Description: "Server that originally held the URL",
+		Version:     "1.0.0",
+		Remotes: []model.Transport{
+			{Type: "streamable-http", URL: "https://api.example.com/mcp"},
+		},
+	}, stringPtr(string(model.StatusDeprecated)))
+	require.NoError(t, err)
+
+	claimingServer := apiv0.ServerJSON{
+		Name:        "com.example/claiming-server",
+		Description: "Server trying to claim the URL",
+		Version:     "1.0.0",
+		Remotes: []model.Transport{
+			{Type: "streamable-http", URL: "https://api.example.com/mcp"},
+		},
+	}
+
+	t.Run("reuse disabled - should fail", func(t *testing.T) {
+		impl := NewRegistryService(testDB, &config.Config{MaxVersionsPerServer: 10000}).(*registryServiceImpl)
+		err := impl.validateNoDuplicateRemoteURLs(ctx, nil, claimingServer)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is already used by server com.example/original-server")
+	})
+
+	t.Run("within cooldown - should fail", func(t *testing.T) {
+		impl := NewRegistryService(testDB, &config.Config{RemoteURLReuseCooldown: time.Hour, MaxVersionsPerServer: 10000}).(*registryServiceImpl)
+		err := impl.validateNoDuplicateRemoteURLs(ctx, nil, claimingServer)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is already used by server com.example/original-server")
+	})
+
+	t.Run("after cooldown - should pass", func(t *testing.T) {
+		time.Sleep(20 * time.Millisecond)
+		impl := NewRegistryService(testDB, &config.Config{RemoteURLReuseCooldown: 10 * time.Millisecond, MaxVersionsPerServer: 10000}).(*registryServiceImpl)
+		err := impl.validateNoDuplicateRemoteURLs(ctx, nil, claimingServer)
+		assert.NoError(t, err)
+	})
+
+	t.Run("active holder after cooldown - should fail", func(t *testing.T) {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Name:        "com.example/active-server",
+			Description: "Server actively using the URL",
+			Version:     "1.0.0",
+			Remotes: []model.Transport{
+				{Type: "streamable-http", URL: "https://active.example.com/mcp"},
+			},
+		})
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+
+		impl := NewRegistryService(testDB, &config.Config{RemoteURLReuseCooldown: 10 * time.Millisecond, MaxVersionsPerServer: 10000}).(*registryServiceImpl)
+		err = impl.validateNoDuplicateRemoteURLs(ctx, nil, apiv0.ServerJSON{
+			Name:        "com.example/claiming-server",
+			Description: "Server trying to claim an active URL",
+			Version:     "1.0.0",
+			Remotes: []model.Transport{
+				{Type: "streamable-http", URL: "https://active.example.com/mcp"},
+			},
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is already used by server com.example/active-server")
+	})
+}
+
 func TestGetServerByName(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 
 	// Create multiple versions of the same server
 	_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
 		Name:        "com.example/test-server",
 		Description: "Test server v1",
 		Version:     "1.0.0",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
@@ -138,6 +281,7 @@ func TestGetServerByName(t *testing.T) {
 		Name:        "com.example/test-server",
 		Description: "Test server v2",
 		Version:     "2.0.0",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
@@ -191,7 +335,7 @@ func TestGetServerByName(t *testing.T) {
 func TestGetServerByNameAndVersion(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 
 	serverName := "com.example/versioned-server"
 
@@ -200,6 +344,7 @@ func TestGetServerByNameAndVersion(t *testing.T) {
 		Name:        serverName,
 		Description: "Versioned server v1",
 		Version:     "1.0.0",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
@@ -207,6 +352,7 @@ func TestGetServerByNameAndVersion(t *testing.T) {
 		Name:        serverName,
 		Description: "Versioned server v2",
 		Version:     "2.0.0",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
@@ -282,7 +428,7 @@ func TestGetServerByNameAndVersion(t *testing.T) {
 func TestGetAllVersionsByServerName(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 
 	serverName := "com.example/multi-version-server"
 
@@ -291,6 +437,7 @@ func TestGetAllVersionsByServerName(t *testing.T) {
 		Name:        serverName,
 		Description: "Multi-version server v1",
 		Version:     "1.0.0",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
@@ -298,6 +445,7 @@ func TestGetAllVersionsByServerName(t *testing.T) {
 		Name:        serverName,
 		Description: "Multi-version server v2",
 		Version:     "2.0.0",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
@@ -305,6 +453,7 @@ func TestGetAllVersionsByServerName(t *testing.T) {
 		Name:        serverName,
 		Description: "Multi-version server v2.1",
 		Version:     "2.1.0",
+		Packages:    testPackages,
 	})
 	require.NoError(t, err)
 
@@ -372,10 +521,40 @@ func TestGetAllVersionsByServerName(t *testing.T) {
 	}
 }
 
+func TestGetLatestServerVersionForMajor(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	serverName := "com.example/major-version-server"
+	for _, version := range []string{"1.0.0", "2.0.0", "1.3.0", "2.0.1", "1.2.9", "snapshot"} {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Name:        serverName,
+			Description: "Major version server " + version,
+			Version:     version,
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+	}
+
+	latest, err := service.GetLatestServerVersionForMajor(ctx, serverName, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0", latest.Server.Version)
+
+	latest, err = service.GetLatestServerVersionForMajor(ctx, serverName, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.1", latest.Server.Version)
+
+	_, err = service.GetLatestServerVersionForMajor(ctx, serverName, 3)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	_, err = service.GetLatestServerVersionForMajor(ctx, "com.example/unknown-server", 1)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
 func TestCreateServerConcurrentVersionsNoRace(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 
 	const concurrency = 100
 	serverName := "com.example/test-concurrent"
@@ -391,6 +570,7 @@ func TestCreateServerConcurrentVersionsNoRace(t *testing.T) {
 				Name:        serverName,
 				Description: fmt.Sprintf("Version %d", idx),
 				Version:     fmt.Sprintf("1.0.%d", idx),
+				Packages:    testPackages,
 			})
 			results[idx] = result
 			errors[idx] = err
@@ -430,7 +610,7 @@ func TestCreateServerConcurrentVersionsNoRace(t *testing.T) {
 func TestUpdateServer(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 
 	serverName := "com.example/update-test-server"
 	version := "1.0.0"
@@ -485,6 +665,7 @@ func TestUpdateServer(t *testing.T) {
 				Name:        serverName,
 				Description: "Updated with status change",
 				Version:     version,
+				Packages:    testPackages,
 			},
 			newStatus:   stringPtr(string(model.StatusDeprecated)),
 			expectError: false,
@@ -502,6 +683,7 @@ func TestUpdateServer(t *testing.T) {
 				Name:        "com.example/non-existent",
 				Description: "Should fail",
 				Version:     "1.0.0",
+				Packages:    testPackages,
 			},
 			expectError: true,
 			errorMsg:    "record not found",
@@ -529,11 +711,53 @@ func TestUpdateServer(t *testing.T) {
 	}
 }
 
+func TestUpdateServer_RegistryBaseURLPolicy(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{
+		EnableRegistryValidation: false,
+		DeniedRegistryBaseURLs:   []string{"https://registry.denied.example.com"},
+		MaxVersionsPerServer:     10000,
+	})
+
+	const name = "com.example/policy-edit-server"
+	server := &apiv0.ServerJSON{Name: name, Description: "A test server", Version: "1.0.0", Packages: testPackages}
+	_, err := service.CreateServer(ctx, server)
+	require.NoError(t, err)
+
+	// Edits are held to the same registry policy as publishes
+	edited := *server
+	edited.Packages = []model.Package{{
+		RegistryType:    model.RegistryTypeNPM,
+		RegistryBaseURL: "https://registry.denied.example.com",
+		Identifier:      "test-package",
+		Version:         "1.0.0",
+		Transport:       model.Transport{Type: model.TransportTypeStdio},
+	}}
+	_, err = service.UpdateServer(ctx, name, "1.0.0", &edited, nil)
+	assert.ErrorIs(t, err, validators.ErrUnsupportedRegistryBaseURL)
+}
+
+func TestUpdateServer_ConfiguredSizeLimits(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxServerJSONBytes: 2048, MaxVersionsPerServer: 10000})
+
+	const name = "com.example/size-limit-edit-server"
+	server := &apiv0.ServerJSON{Name: name, Description: "A test server", Version: "1.0.0", Packages: testPackages}
+	_, err := service.CreateServer(ctx, server)
+	require.NoError(t, err)
+
+	// Edits are held to the configured document size, not the default
+	edited := *server
+	edited.LongDescription = strings.Repeat("a", 5000)
+	_, err = service.UpdateServer(ctx, name, "1.0.0", &edited, nil)
+	assert.ErrorIs(t, err, validators.ErrDocumentTooLarge)
+}
+
 func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
 	// Enable registry validation to test that it gets skipped for deleted servers
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: true})
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: true, MaxVersionsPerServer: 10000})
 
 	serverName := "com.example/validation-skip-test"
 	version := "1.0.0"
@@ -624,7 +848,7 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 func TestListServers(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 
 	// Create test servers
 	testServers := []struct {
@@ -642,6 +866,7 @@ func TestListServers(t *testing.T) {
 			Name:        server.name,
 			Description: server.description,
 			Version:     server.version,
+			Packages:    testPackages,
 		})
 		require.NoError(t, err)
 	}
@@ -715,7 +940,7 @@ func TestListServers(t *testing.T) {
 func TestVersionComparison(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
-	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
 
 	serverName := "com.example/version-comparison-server"
 
@@ -739,6 +964,7 @@ func TestVersionComparison(t *testing.T) {
 			Name:        serverName,
 			Description: v.description,
 			Version:     v.version,
+			Packages:    testPackages,
 		})
 		require.NoError(t, err, "Failed to create version %s", v.version)
 	}
@@ -767,3 +993,509 @@ func TestVersionComparison(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestPublishServer_ReviewPolicy(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{
+		EnableRegistryValidation: false,
+		ReviewRequiredNamespaces: []string{"io.modelcontextprotocol"},
+		MaxVersionsPerServer:     10000,
+	})
+
+	publish := func(name, version string) *apiv0.ServerResponse {
+		t.Helper()
+		published, err := service.PublishServer(ctx, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "A test server",
+			Version:     version,
+			Packages:    testPackages,
+		}, PublishOptions{})
+		require.NoError(t, err)
+		return published
+	}
+
+	t.Run("publishes outside the policy go live", func(t *testing.T) {
+		published := publish("com.example/live-server", "1.0.0")
+		assert.Equal(t, model.StatusActive, published.Meta.Official.Status)
+		assert.True(t, published.Meta.Official.IsLatest)
+
+		latest, err := service.GetServerByName(ctx, "com.example/live-server")
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", latest.Server.Version)
+	})
+
+	t.Run("publishes in a covered namespace are held for review", func(t *testing.T) {
+		for _, name := range []string{"io.modelcontextprotocol/held-server", "io.modelcontextprotocol.tools/held-server", "IO.ModelContextProtocol/mixed-case-server"} {
+			published := publish(name, "1.0.0")
+			assert.Equal(t, model.StatusPending, published.Meta.Official.Status, name)
+			assert.False(t, published.Meta.Official.IsLatest, name)
+
+			_, err := service.GetServerByName(ctx, name)
+			assert.ErrorIs(t, err, database.ErrNotFound, name)
+
+			servers, _, err := service.ListServers(ctx, &database.ServerFilter{Name: &name}, "", 10)
+			require.NoError(t, err)
+			assert.Empty(t, servers, name)
+		}
+	})
+
+	t.Run("approval makes a held version live and latest", func(t *testing.T) {
+		const name = "io.modelcontextprotocol/approved-server"
+		publish(name, "1.0.0")
+		approve := string(model.StatusActive)
+		_, err := service.UpdateServer(ctx, name, "1.0.0", &apiv0.ServerJSON{Name: name, Description: "A test server", Version: "1.0.0", Packages: testPackages}, &approve)
+		require.NoError(t, err)
+
+		publish(name, "2.0.0")
+		latest, err := service.GetServerByName(ctx, name)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", latest.Server.Version, "held version must not replace the live latest")
+
+		updated, err := service.SetServerStatus(ctx, name, []string{"2.0.0"}, model.StatusActive, "")
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated)
+
+		latest, err = service.GetServerByName(ctx, name)
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", latest.Server.Version)
+		assert.Equal(t, model.StatusActive, latest.Meta.Official.Status)
+	})
+}
+
+func TestRequiresReview(t *testing.T) {
+	service := &registryServiceImpl{cfg: &config.Config{ReviewRequiredNamespaces: []string{"io.ModelContextProtocol"}}}
+
+	assert.True(t, service.requiresReview("io.modelcontextprotocol/server"))
+	assert.True(t, service.requiresReview("IO.MODELCONTEXTPROTOCOL/server"))
+	assert.True(t, service.requiresReview("io.modelcontextprotocol.Tools/server"))
+	assert.False(t, service.requiresReview("io.modelcontextprotocolx/server"))
+	assert.False(t, service.requiresReview("com.example/server"))
+}
+
+func TestPublishServer_DryRun(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+	const name = "com.example/dry-run-server"
+
+	publish := func(version string, dryRun bool) *apiv0.ServerResponse {
+		t.Helper()
+		published, err := service.PublishServer(ctx, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "A test server",
+			Version:     version,
+			Packages:    testPackages,
+		}, PublishOptions{DryRun: dryRun})
+		require.NoError(t, err)
+		return published
+	}
+
+	// A dry run of the first version reports it would become latest, but stores nothing
+	result := publish("1.0.0", true)
+	assert.True(t, result.Meta.Official.IsLatest)
+	assert.Equal(t, model.StatusActive, result.Meta.Official.Status)
+	_, err := service.GetServerByName(ctx, name)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	publish("1.0.0", false)
+
+	tests := []struct {
+		version        string
+		expectIsLatest bool
+	}{
+		{"2.0.0", true},
+		{"0.9.0", false},
+		{"1.0.1-beta.1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			result := publish(tt.version, true)
+			assert.Equal(t, tt.version, result.Server.Version)
+			assert.Equal(t, tt.expectIsLatest, result.Meta.Official.IsLatest)
+		})
+	}
+
+	// Nothing from the dry runs was persisted, and the stored latest version is unchanged
+	versions, err := service.GetAllVersionsByServerName(ctx, name)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, "1.0.0", versions[0].Server.Version)
+	assert.True(t, versions[0].Meta.Official.IsLatest)
+
+	// Dry runs still reject versions that already exist
+	_, err = service.PublishServer(ctx, &apiv0.ServerJSON{
+		Name:        name,
+		Description: "A test server",
+		Version:     "1.0.0",
+		Packages:    testPackages,
+	}, PublishOptions{DryRun: true})
+	assert.ErrorIs(t, err, database.ErrInvalidVersion)
+}
+
+func TestPublishServer_NameCaseVariants(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	publish := func(name, version string) (*apiv0.ServerResponse, error) {
+		return service.PublishServer(ctx, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "A test server",
+			Version:     version,
+			Packages:    testPackages,
+		}, PublishOptions{})
+	}
+
+	_, err := publish("io.github.Example/Case-Server", "1.0.0")
+	require.NoError(t, err)
+
+	// A case variant publishes a new version of the same server, under its stored name
+	published, err := publish("io.github.example/case-server", "2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "io.github.Example/Case-Server", published.Server.Name)
+	assert.True(t, published.Meta.Official.IsLatest)
+
+	versions, err := service.GetAllVersionsByServerName(ctx, "io.github.Example/Case-Server")
+	require.NoError(t, err)
+	assert.Len(t, versions, 2)
+
+	_, err = service.GetServerByName(ctx, "io.github.example/case-server")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	// Republishing an existing version under a case variant collides with it
+	_, err = publish("io.github.EXAMPLE/CASE-SERVER", "1.0.0")
+	assert.ErrorIs(t, err, database.ErrInvalidVersion)
+}
+
+func TestPublishServer_Verified(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name             string
+		enableValidation bool
+		expectVerified   bool
+	}{
+		{"validated publish is verified", true, true},
+		{"validation disabled is not verified", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: tt.enableValidation, MaxVersionsPerServer: 10000})
+			const name = "com.example/verified-server"
+
+			// Remote-only servers have no packages whose ownership needs checking against an external registry
+			published, err := service.PublishServer(ctx, &apiv0.ServerJSON{
+				Name:        name,
+				Description: "A test server",
+				Version:     "1.0.0",
+				Remotes: []model.Transport{
+					{Type: "streamable-http", URL: "https://verified.example.com/mcp"},
+				},
+			}, PublishOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectVerified, published.Meta.Official.Verified)
+			assert.Equal(t, tt.expectVerified, published.Meta.Official.VerifiedAt != nil)
+
+			stored, err := service.GetServerByNameAndVersion(ctx, name, "1.0.0")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectVerified, stored.Meta.Official.Verified)
+			if tt.expectVerified {
+				require.NotNil(t, stored.Meta.Official.VerifiedAt)
+				assert.WithinDuration(t, *published.Meta.Official.VerifiedAt, *stored.Meta.Official.VerifiedAt, time.Second)
+			} else {
+				assert.Nil(t, stored.Meta.Official.VerifiedAt)
+			}
+
+			verified := true
+			servers, _, err := service.ListServers(ctx, &database.ServerFilter{Verified: &verified}, "", 10)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectVerified, len(servers) == 1)
+		})
+	}
+}
+
+func TestPublishServer_RequireNewer(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+	const name = "com.example/monotonic-server"
+
+	publish := func(version string, requireNewer bool) (*apiv0.ServerResponse, error) {
+		return service.PublishServer(ctx, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "A test server",
+			Version:     version,
+		}, PublishOptions{RequireNewer: requireNewer})
+	}
+
+	// The first version of a server is always newer
+	_, err := publish("1.0.0", true)
+	require.NoError(t, err)
+
+	_, err = publish("0.9.0", true)
+	require.ErrorIs(t, err, ErrVersionNotNewer)
+	var notNewerErr *VersionNotNewerError
+	require.ErrorAs(t, err, &notNewerErr)
+	assert.Equal(t, "0.9.0", notNewerErr.Version)
+	assert.Equal(t, "1.0.0", notNewerErr.Latest)
+	_, err = service.GetServerByNameAndVersion(ctx, name, "0.9.0")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	// Backfilling without the option still works, without taking over as latest
+	backfilled, err := publish("0.9.0", false)
+	require.NoError(t, err)
+	assert.False(t, backfilled.Meta.Official.IsLatest)
+
+	newer, err := publish("1.1.0", true)
+	require.NoError(t, err)
+	assert.True(t, newer.Meta.Official.IsLatest)
+}
+
+func TestPublishServer_RepublishDeletedVersion(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	const name = "com.example/republished-server"
+
+	server := func(description string) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Name:        name,
+			Description: description,
+			Version:     "1.0.0",
+			Packages:    testPackages,
+		}
+	}
+
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+	_, err := service.PublishServer(ctx, server("Original server"), PublishOptions{})
+	require.NoError(t, err)
+
+	// An active version always counts as a duplicate
+	reuseService := NewRegistryService(testDB, &config.Config{DeletedVersionReuseCooldown: 10 * time.Millisecond, MaxVersionsPerServer: 10000})
+	time.Sleep(20 * time.Millisecond)
+	_, err = reuseService.PublishServer(ctx, server("Replacement server"), PublishOptions{})
+	assert.ErrorIs(t, err, database.ErrInvalidVersion)
+
+	_, err = service.UpdateServer(ctx, name, "1.0.0", server("Original server"), stringPtr(string(model.StatusDeleted)))
+	require.NoError(t, err)
+
+	t.Run("reuse disabled - should fail", func(t *testing.T) {
+		_, err := service.PublishServer(ctx, server("Replacement server"), PublishOptions{})
+		assert.ErrorIs(t, err, database.ErrInvalidVersion)
+	})
+
+	t.Run("within cooldown - should fail", func(t *testing.T) {
+		impl := NewRegistryService(testDB, &config.Config{DeletedVersionReuseCooldown: time.Hour, MaxVersionsPerServer: 10000})
+		_, err := impl.PublishServer(ctx, server("Replacement server"), PublishOptions{})
+		assert.ErrorIs(t, err, database.ErrInvalidVersion)
+	})
+
+	t.Run("after cooldown - replaces the deleted version", func(t *testing.T) {
+		time.Sleep(20 * time.Millisecond)
+		published, err := reuseService.PublishServer(ctx, server("Replacement server"), PublishOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusActive, published.Meta.Official.Status)
+		assert.True(t, published.Meta.Official.IsLatest)
+
+		versions, err := reuseService.GetAllVersionsByServerName(ctx, name)
+		require.NoError(t, err)
+		require.Len(t, versions, 1)
+		assert.Equal(t, "Replacement server", versions[0].Server.Description)
+		assert.Equal(t, model.StatusActive, versions[0].Meta.Official.Status)
+	})
+}
+
+func TestPublishBatch(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	batch := func(name string, versions ...string) []apiv0.ServerJSON {
+		servers := make([]apiv0.ServerJSON, len(versions))
+		for i, version := range versions {
+			servers[i] = apiv0.ServerJSON{
+				Name:        name,
+				Description: "A batch published server",
+				Version:     version,
+				Packages:    testPackages,
+			}
+		}
+		return servers
+	}
+
+	t.Run("publishes all versions with a single latest", func(t *testing.T) {
+		const name = "com.example/batch-server"
+		published, err := service.PublishBatch(ctx, batch(name, "1.0.0", "1.2.0", "2.0.0", "1.1.0", "2.0.0-beta.1"))
+		require.NoError(t, err)
+		require.Len(t, published, 5)
+
+		var latest []string
+		for _, server := range published {
+			if server.Meta.Official.IsLatest {
+				latest = append(latest, server.Server.Version)
+			}
+		}
+		assert.Equal(t, []string{"2.0.0"}, latest)
+
+		versions, err := service.GetAllVersionsByServerName(ctx, name)
+		require.NoError(t, err)
+		require.Len(t, versions, 5)
+		latest = nil
+		for _, server := range versions {
+			if server.Meta.Official.IsLatest {
+				latest = append(latest, server.Server.Version)
+			}
+		}
+		assert.Equal(t, []string{"2.0.0"}, latest)
+	})
+
+	t.Run("rolls back the whole batch on any failure", func(t *testing.T) {
+		const name = "com.example/rolled-back-server"
+		_, err := service.PublishServer(ctx, &batch(name, "1.0.0")[0], PublishOptions{})
+		require.NoError(t, err)
+
+		// 1.0.0 already exists, so 1.1.0 and 2.0.0 must not be stored either
+		_, err = service.PublishBatch(ctx, batch(name, "1.1.0", "2.0.0", "1.0.0"))
+		require.ErrorIs(t, err, database.ErrInvalidVersion)
+
+		versions, err := service.GetAllVersionsByServerName(ctx, name)
+		require.NoError(t, err)
+		require.Len(t, versions, 1)
+		assert.Equal(t, "1.0.0", versions[0].Server.Version)
+		assert.True(t, versions[0].Meta.Official.IsLatest)
+	})
+
+	t.Run("rejects versions of multiple servers", func(t *testing.T) {
+		servers := append(batch("com.example/first-server", "1.0.0"), batch("com.example/second-server", "1.0.0")...)
+		_, err := service.PublishBatch(ctx, servers)
+		require.ErrorIs(t, err, ErrInvalidBatch)
+
+		_, err = service.GetServerByName(ctx, "com.example/first-server")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("rejects duplicate versions within the batch", func(t *testing.T) {
+		_, err := service.PublishBatch(ctx, batch("com.example/duplicate-batch-server", "1.0.0", "1.0.0"))
+		require.ErrorIs(t, err, database.ErrInvalidVersion)
+		assert.Contains(t, err.Error(), "appears more than once")
+	})
+
+	t.Run("rejects an empty batch", func(t *testing.T) {
+		_, err := service.PublishBatch(ctx, nil)
+		require.ErrorIs(t, err, ErrInvalidBatch)
+	})
+}
+
+func TestListServers_SignedCursors(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{CursorSigningKey: "test-key", RequireSignedCursors: true, MaxVersionsPerServer: 10000})
+
+	// Names and versions containing colons must survive the cursor round trip; they are inserted directly
+	// because publishing validation would reject them
+	expected := []string{
+		"com.example/a:server@1.0.0",
+		"com.example/a:server@1.0.0:build",
+		"com.example/b@2.0.0",
+		"com.example/c:d:e@3.0.0",
+	}
+	for _, entry := range expected {
+		name, version, _ := strings.Cut(entry, "@")
+		_, err := testDB.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "Cursor test server",
+			Version:     version,
+		}, &apiv0.RegistryExtensions{
+			Status:      model.StatusActive,
+			PublishedAt: time.Now(),
+			UpdatedAt:   time.Now(),
+		})
+		require.NoError(t, err)
+	}
+
+	for _, sort := range []database.ServerSort{"", database.SortByRecent} {
+		t.Run("paginates with sort "+string(sort), func(t *testing.T) {
+			var names []string
+			cursor := ""
+			for {
+				results, nextCursor, err := service.ListServers(ctx, &database.ServerFilter{Sort: sort}, cursor, 1)
+				require.NoError(t, err)
+				for _, result := range results {
+					names = append(names, result.Server.Name+"@"+result.Server.Version)
+				}
+				if nextCursor == "" {
+					break
+				}
+				assert.NotContains(t, nextCursor, ":", "cursors should be opaque")
+				cursor = nextCursor
+			}
+			assert.ElementsMatch(t, expected, names)
+			assert.Len(t, names, len(expected), "no server should be listed twice")
+		})
+	}
+
+	t.Run("forged cursor is rejected", func(t *testing.T) {
+		_, cursor, err := service.ListServers(ctx, nil, "", 1)
+		require.NoError(t, err)
+		payload, signature, found := strings.Cut(cursor, ".")
+		require.True(t, found)
+
+		forgedPayload := base64.RawURLEncoding.EncodeToString([]byte(`["com.example/b","2.0.0"]`))
+		require.NotEqual(t, payload, forgedPayload)
+		_, _, err = service.ListServers(ctx, nil, forgedPayload+"."+signature, 1)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("unsigned cursor is rejected when signing is required", func(t *testing.T) {
+		_, _, err := service.ListServers(ctx, nil, "com.example/b:2.0.0", 1)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+}
+
+func TestPublishServer_TracingSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx := context.Background()
+	service := NewRegistryService(database.WithTracing(database.NewTestDB(t)), &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	_, err := service.PublishServer(ctx, &apiv0.ServerJSON{
+		Name:        "com.example/traced-server",
+		Description: "Tracing test server",
+		Version:     "1.0.0",
+		Packages:    testPackages,
+	}, PublishOptions{})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	byID := make(map[trace.SpanID]sdktrace.ReadOnlySpan, len(spans))
+	var publishSpan sdktrace.ReadOnlySpan
+	for _, span := range spans {
+		byID[span.SpanContext().SpanID()] = span
+		if span.Name() == "RegistryService.PublishServer" {
+			publishSpan = span
+		}
+	}
+	require.NotNil(t, publishSpan, "publishing should record a service span")
+	assert.Contains(t, publishSpan.Attributes(), attribute.String("mcp.server.name", "com.example/traced-server"))
+
+	// Every database operation of the publish, including those inside its transaction, descends from the
+	// service span
+	dbSpans := map[string]bool{}
+	for _, span := range spans {
+		if !strings.HasPrefix(span.Name(), "db.") {
+			continue
+		}
+		for parent := span.Parent(); parent.IsValid(); parent = byID[parent.SpanID()].Parent() {
+			if parent.SpanID() == publishSpan.SpanContext().SpanID() {
+				dbSpans[span.Name()] = true
+				break
+			}
+			if _, ok := byID[parent.SpanID()]; !ok {
+				break
+			}
+		}
+	}
+	assert.True(t, dbSpans["db.InTransaction"], "the publish transaction should be traced under the service span")
+	assert.True(t, dbSpans["db.AcquirePublishLock"], "operations in the transaction should be traced under the service span")
+	assert.True(t, dbSpans["db.CreateServer"], "operations in the transaction should be traced under the service span")
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ReindexLatest recomputes the latest version of every server with CompareVersions, for recovering from a
+// latest flag that got out of sync, e.g. after manual database edits. Each server is repaired in its own
+// transaction under its publish lock, so the job never races with publishes and edits of that server.
+func (s *registryServiceImpl) ReindexLatest(ctx context.Context) (_ *apiv0.ReindexLatestResult, err error) {
+	ctx, span := startSpan(ctx, "ReindexLatest")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	names, err := s.db.ListServerNames(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &apiv0.ReindexLatestResult{}
+	for _, name := range names {
+		corrected, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (bool, error) {
+			return s.reindexLatestInTransaction(ctx, tx, name)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to reindex latest version of %s: %w", name, err)
+		}
+		result.ServersChecked++
+		if corrected {
+			result.ServersCorrected++
+		}
+	}
+
+	return result, nil
+}
+
+// reindexLatestInTransaction repairs the latest flag of one server, reporting whether it had to be changed.
+// The latest version is the newest version that is not pending review, whatever its other status.
+func (s *registryServiceImpl) reindexLatestInTransaction(ctx context.Context, tx pgx.Tx, serverName string) (bool, error) {
+	if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+		return false, err
+	}
+
+	versions, err := s.db.GetAllVersionsByServerName(ctx, tx, serverName)
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var expected *apiv0.ServerResponse
+	var flagged []*apiv0.ServerResponse
+	for _, server := range versions {
+		if server.Meta.Official != nil && server.Meta.Official.IsLatest {
+			flagged = append(flagged, server)
+		}
+		if statusOf(server) == model.StatusPending {
+			continue
+		}
+		if expected == nil || CompareVersions(server.Server.Version, expected.Server.Version, publishedAt(server), publishedAt(expected)) > 0 {
+			expected = server
+		}
+	}
+
+	// A single flagged version is correct if no eligible version is newer, which keeps ties as they are
+	if expected == nil && len(flagged) == 0 {
+		return false, nil
+	}
+	if expected != nil && len(flagged) == 1 && statusOf(flagged[0]) != model.StatusPending &&
+		CompareVersions(flagged[0].Server.Version, expected.Server.Version, publishedAt(flagged[0]), publishedAt(expected)) == 0 {
+		return false, nil
+	}
+
+	if err := s.db.UnmarkAsLatest(ctx, tx, serverName); err != nil {
+		return false, err
+	}
+	if expected == nil {
+		log.Printf("Reindex latest: %s has no version eligible to be latest, cleared %d latest flags", serverName, len(flagged))
+		return true, nil
+	}
+	if _, err := s.db.MarkAsLatest(ctx, tx, serverName, expected.Server.Version); err != nil {
+		return false, err
+	}
+	log.Printf("Reindex latest: marked %s %s as latest, replacing %d latest flags", serverName, expected.Server.Version, len(flagged))
+	return true, nil
+}
@@ -0,0 +1,89 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestReindexLatest(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, MaxVersionsPerServer: 10000})
+
+	for _, v := range []struct{ name, version string }{
+		{"com.example/two-latest", "1.0.0"},
+		{"com.example/two-latest", "1.1.0"},
+		{"com.example/two-latest", "2.0.0"},
+		{"com.example/no-latest", "1.0.0"},
+		{"com.example/no-latest", "1.5.0"},
+		{"com.example/pending-newer", "1.0.0"},
+		{"com.example/pending-newer", "3.0.0"},
+		{"com.example/healthy", "1.0.0"},
+		{"com.example/healthy", "1.2.0"},
+	} {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Name:        v.name,
+			Description: "Reindex test server",
+			Version:     v.version,
+			Packages:    testPackages,
+		})
+		require.NoError(t, err)
+	}
+
+	// Break the latest flags the way manual edits could, which requires dropping the index that prevents
+	// two latest versions of a server
+	err := testDB.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		for _, statement := range []string{
+			`DROP INDEX idx_unique_latest_per_server`,
+			`UPDATE servers SET is_latest = true WHERE server_name = 'com.example/two-latest' AND version = '1.0.0'`,
+			`UPDATE servers SET is_latest = false WHERE server_name = 'com.example/no-latest'`,
+			`UPDATE servers SET status = 'pending' WHERE server_name = 'com.example/pending-newer' AND version = '3.0.0'`,
+		} {
+			if _, err := tx.Exec(ctx, statement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	latestVersions := func(serverName string) []string {
+		t.Helper()
+		versions, err := service.GetAllVersionsByServerName(ctx, serverName)
+		require.NoError(t, err)
+		latest := []string{}
+		for _, server := range versions {
+			if server.Meta.Official.IsLatest {
+				latest = append(latest, server.Server.Version)
+			}
+		}
+		return latest
+	}
+	require.ElementsMatch(t, []string{"1.0.0", "2.0.0"}, latestVersions("com.example/two-latest"))
+	require.Empty(t, latestVersions("com.example/no-latest"))
+
+	result, err := service.ReindexLatest(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.ServersChecked)
+	assert.Equal(t, 3, result.ServersCorrected)
+
+	assert.Equal(t, []string{"2.0.0"}, latestVersions("com.example/two-latest"))
+	assert.Equal(t, []string{"1.5.0"}, latestVersions("com.example/no-latest"))
+	assert.Equal(t, []string{"1.0.0"}, latestVersions("com.example/pending-newer"), "pending versions never become latest")
+	assert.Equal(t, []string{"1.2.0"}, latestVersions("com.example/healthy"))
+
+	// Once repaired there is nothing left to correct
+	result, err = service.ReindexLatest(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.ServersChecked)
+	assert.Equal(t, 0, result.ServersCorrected)
+}
@@ -2,23 +2,89 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/events"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 // RegistryService defines the interface for registry operations
 type RegistryService interface {
 	// ListServers retrieve all servers with optional filtering
 	ListServers(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
-	// GetServerByName retrieve latest version of a server by server name
+	// ListServersPage retrieve a page of servers with optional filtering, with cursors for the next and previous pages
+	ListServersPage(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) (*database.ServerPage, error)
+	// ListLimits returns the page size used when a list request sets no limit, and the largest allowed limit
+	ListLimits() (defaultLimit, maxLimit int)
+	// GetServerByName retrieve latest version of a server by server name, or by an alias of its name
 	GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error)
 	// GetServerByNameAndVersion retrieve specific version of a server by server name and version
 	GetServerByNameAndVersion(ctx context.Context, serverName string, version string) (*apiv0.ServerResponse, error)
+	// GetRawServer retrieve specific version of a server as stored, for debugging
+	GetRawServer(ctx context.Context, serverName string, version string) (*apiv0.RawServer, error)
 	// GetAllVersionsByServerName retrieve all versions of a server by server name
 	GetAllVersionsByServerName(ctx context.Context, serverName string) ([]*apiv0.ServerResponse, error)
+	// GetLatestServerVersionForMajor retrieve the newest semver version of a server with the given major version
+	GetLatestServerVersionForMajor(ctx context.Context, serverName string, major int) (*apiv0.ServerResponse, error)
+	// CheckVersionsExist report which of the given versions of a server exist, including unlisted, pending and
+	// deleted versions, whose version numbers can't be published again
+	CheckVersionsExist(ctx context.Context, serverName string, versions []string) (map[string]bool, error)
+	// GetServerStats retrieve server counts by status
+	GetServerStats(ctx context.Context) (*apiv0.ServerStats, error)
 	// CreateServer creates a new server version
 	CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
+	// PublishServer creates a new server version with publisher-controlled registry metadata
+	PublishServer(ctx context.Context, req *apiv0.ServerJSON, opts PublishOptions) (*apiv0.ServerResponse, error)
+	// PublishBatch publishes several versions of one server atomically, under a single publish lock
+	PublishBatch(ctx context.Context, servers []apiv0.ServerJSON) ([]*apiv0.ServerResponse, error)
 	// UpdateServer updates an existing server and optionally its status
 	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error)
+	// EditServer updates an existing server and optionally its registry metadata
+	EditServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, opts UpdateOptions) (*apiv0.ServerResponse, error)
+	// SetServerStatus sets the status of all versions of a server, or only the listed versions, on behalf of actor,
+	// returning how many changed
+	SetServerStatus(ctx context.Context, serverName string, versions []string, status model.Status, actor string) (int, error)
+	// ReindexLatest recomputes the latest version of every server, repairing servers whose latest flag is wrong
+	ReindexLatest(ctx context.Context) (*apiv0.ReindexLatestResult, error)
+	// PurgeDeleted permanently removes deleted versions last updated before a cutoff
+	PurgeDeleted(ctx context.Context, before time.Time) (*apiv0.PurgeDeletedResult, error)
+	// CreateAlias makes an old server name resolve to another server
+	CreateAlias(ctx context.Context, alias, target string) (*apiv0.ServerAlias, error)
+	// ListAuditEntries retrieve audit log entries, newest first, optionally only those for one server
+	ListAuditEntries(ctx context.Context, serverName string, cursor string, limit int) ([]*apiv0.AuditEntry, string, error)
+	// SubscribeEvents stream change events published after afterID; call the returned function to unsubscribe
+	SubscribeEvents(afterID int) (<-chan events.Event, func())
+}
+
+// PublishOptions holds registry metadata the publisher controls when publishing a version
+type PublishOptions struct {
+	// Unlisted hides the version from list and search results while keeping it resolvable by name
+	Unlisted bool
+	// OriginRegistry records the registry an imported version was mirrored from; empty for native publishes
+	OriginRegistry string
+	// SkipPublishLock skips the per-server advisory lock, for bulk loads that cannot race with other publishes
+	SkipPublishLock bool
+	// DryRun runs validation and computes the registry metadata, including whether the version would become
+	// latest, without storing the version
+	DryRun bool
+	// Actor identifies who is publishing, for the audit log; empty records the change as made by the system
+	Actor string
+	// PublisherSubject is the subject of the publisher's token, stored with the version
+	PublisherSubject string
+	// RequireNewer rejects the version with a VersionNotNewerError unless it would become the latest version,
+	// for publishers enforcing monotonic releases; by default older versions can be backfilled
+	RequireNewer bool
+}
+
+// UpdateOptions holds registry metadata changes applied when editing a version; nil fields are left unchanged
+type UpdateOptions struct {
+	Status   *string
+	Unlisted *bool
+	// DeprecationMessage and SupersededBy can only be set when the version's status is set to deprecated
+	DeprecationMessage *string
+	SupersededBy       *string
+	// Actor identifies who is editing, for the audit log; empty records the change as made by the system
+	Actor string
 }
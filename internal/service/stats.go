@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// statsCache holds the most recently computed server stats, so that frequent dashboard polling
+// doesn't trigger a full table scan on every request
+type statsCache struct {
+	mu        sync.Mutex
+	stats     apiv0.ServerStats
+	expiresAt time.Time
+}
+
+// GetServerStats returns server counts by status, reusing a cached result for StatsCacheTTL
+func (s *registryServiceImpl) GetServerStats(ctx context.Context) (_ *apiv0.ServerStats, err error) {
+	ctx, span := startSpan(ctx, "GetServerStats")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	s.statsCache.mu.Lock()
+	defer s.statsCache.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(s.statsCache.expiresAt) {
+		stats := s.statsCache.stats
+		return &stats, nil
+	}
+
+	stats, err := s.db.GetServerStats(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.statsCache.stats = *stats
+	s.statsCache.expiresAt = now.Add(s.cfg.StatsCacheTTL)
+
+	return stats, nil
+}
@@ -0,0 +1,70 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// countingStatsDB counts GetServerStats calls; other Database methods are not used by these tests
+type countingStatsDB struct {
+	database.Database
+	calls int
+}
+
+func (db *countingStatsDB) GetServerStats(_ context.Context, _ pgx.Tx) (*apiv0.ServerStats, error) {
+	db.calls++
+	return &apiv0.ServerStats{Active: db.calls, TotalServers: db.calls, TotalVersions: db.calls}, nil
+}
+
+func TestGetServerStats_Cache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cached within TTL", func(t *testing.T) {
+		db := &countingStatsDB{}
+		svc := NewRegistryService(db, &config.Config{StatsCacheTTL: time.Hour, MaxVersionsPerServer: 10000})
+
+		first, err := svc.GetServerStats(ctx)
+		require.NoError(t, err)
+		second, err := svc.GetServerStats(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, db.calls)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("recomputed after TTL", func(t *testing.T) {
+		db := &countingStatsDB{}
+		svc := NewRegistryService(db, &config.Config{StatsCacheTTL: time.Millisecond, MaxVersionsPerServer: 10000})
+
+		_, err := svc.GetServerStats(ctx)
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+		stats, err := svc.GetServerStats(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, db.calls)
+		assert.Equal(t, 2, stats.Active)
+	})
+
+	t.Run("zero TTL disables caching", func(t *testing.T) {
+		db := &countingStatsDB{}
+		svc := NewRegistryService(db, &config.Config{MaxVersionsPerServer: 10000})
+
+		for range 3 {
+			_, err := svc.GetServerStats(ctx)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 3, db.calls)
+	})
+}
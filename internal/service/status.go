@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// defaultStatusTransitions is used when the config does not set StatusTransitions
+const defaultStatusTransitions = "active:deprecated,active:deleted,deprecated:active,deprecated:deleted,pending:active,pending:deleted"
+
+// ErrInvalidStatusTransition is returned when a status change is not allowed
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+// ErrDeprecationWithoutStatus is returned when a deprecation notice is set without deprecating the version
+var ErrDeprecationWithoutStatus = errors.New("deprecation message and successor can only be set when setting status to deprecated")
+
+// ErrInvalidSuccessor is returned when a deprecated version names a successor that is not another existing server
+var ErrInvalidSuccessor = errors.New("invalid successor")
+
+// StatusTransitionError describes a rejected status change
+type StatusTransitionError struct {
+	From model.Status
+	To   model.Status
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("cannot change status of %s server to %s", e.From, e.To)
+}
+
+func (e *StatusTransitionError) Unwrap() error {
+	return ErrInvalidStatusTransition
+}
+
+// statusMachine is the single source of truth for which status changes are allowed
+type statusMachine struct {
+	allowed map[model.Status][]model.Status
+}
+
+// newStatusMachine builds the state machine from the configured transitions, falling back to the defaults
+func newStatusMachine(cfg *config.Config) *statusMachine {
+	spec := defaultStatusTransitions
+	if cfg != nil && cfg.StatusTransitions != "" {
+		spec = cfg.StatusTransitions
+	}
+
+	// The config is validated at startup, so a parse failure here means an unvalidated config was
+	// passed in directly. Fall back to the defaults rather than allowing every transition.
+	allowed, err := config.ParseStatusTransitions(spec)
+	if err != nil {
+		allowed, _ = config.ParseStatusTransitions(defaultStatusTransitions)
+	}
+	return &statusMachine{allowed: allowed}
+}
+
+// check returns a StatusTransitionError if moving from one status to another is not allowed.
+// Keeping the current status is always allowed.
+func (m *statusMachine) check(from, to model.Status) error {
+	if from == to {
+		return nil
+	}
+	for _, allowed := range m.allowed[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return &StatusTransitionError{From: from, To: to}
+}
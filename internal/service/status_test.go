@@ -0,0 +1,110 @@
+//nolint:testpackage
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestStatusMachine_DefaultTransitions(t *testing.T) {
+	machine := newStatusMachine(&config.Config{})
+
+	tests := []struct {
+		from    model.Status
+		to      model.Status
+		allowed bool
+	}{
+		{model.StatusActive, model.StatusActive, true},
+		{model.StatusActive, model.StatusDeprecated, true},
+		{model.StatusActive, model.StatusDeleted, true},
+		{model.StatusDeprecated, model.StatusActive, true},
+		{model.StatusDeprecated, model.StatusDeprecated, true},
+		{model.StatusDeprecated, model.StatusDeleted, true},
+		{model.StatusDeleted, model.StatusDeleted, true},
+		{model.StatusDeleted, model.StatusActive, false},
+		{model.StatusDeleted, model.StatusDeprecated, false},
+		{model.StatusPending, model.StatusActive, true},
+		{model.StatusPending, model.StatusDeleted, true},
+		{model.StatusPending, model.StatusDeprecated, false},
+		{model.StatusActive, model.StatusPending, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			err := machine.check(tt.from, tt.to)
+			if tt.allowed {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+			var transitionErr *StatusTransitionError
+			if assert.True(t, errors.As(err, &transitionErr)) {
+				assert.Equal(t, tt.from, transitionErr.From)
+				assert.Equal(t, tt.to, transitionErr.To)
+			}
+		})
+	}
+}
+
+func TestStatusMachine_ConfiguredTransitions(t *testing.T) {
+	// Only allow deprecating, and restoring deleted versions
+	machine := newStatusMachine(&config.Config{StatusTransitions: "active:deprecated, deleted:active"})
+
+	assert.NoError(t, machine.check(model.StatusActive, model.StatusDeprecated))
+	assert.NoError(t, machine.check(model.StatusDeleted, model.StatusActive))
+	assert.ErrorIs(t, machine.check(model.StatusDeprecated, model.StatusActive), ErrInvalidStatusTransition)
+	assert.ErrorIs(t, machine.check(model.StatusActive, model.StatusDeleted), ErrInvalidStatusTransition)
+
+	// Review transitions are configured like any other
+	assert.ErrorIs(t, machine.check(model.StatusPending, model.StatusActive), ErrInvalidStatusTransition)
+	assert.ErrorIs(t, machine.check(model.StatusPending, model.StatusDeleted), ErrInvalidStatusTransition)
+
+	// e.g. to only allow rejecting pending versions
+	machine = newStatusMachine(&config.Config{StatusTransitions: "pending:deleted"})
+	assert.NoError(t, machine.check(model.StatusPending, model.StatusDeleted))
+	assert.ErrorIs(t, machine.check(model.StatusPending, model.StatusActive), ErrInvalidStatusTransition)
+}
+
+func TestStatusMachine_InvalidConfigFallsBackToDefaults(t *testing.T) {
+	machine := newStatusMachine(&config.Config{StatusTransitions: "active->deleted"})
+
+	assert.NoError(t, machine.check(model.StatusActive, model.StatusDeleted))
+	assert.ErrorIs(t, machine.check(model.StatusDeleted, model.StatusActive), ErrInvalidStatusTransition)
+}
+
+func TestConfigValidate_StatusTransitions(t *testing.T) {
+	tests := []struct {
+		name        string
+		transitions string
+		expectError bool
+	}{
+		{"defaults", defaultStatusTransitions, false},
+		{"empty uses defaults", "", false},
+		{"missing separator", "active-deleted", true},
+		{"unknown status", "active:archived", true},
+		{"pending status", "pending:active,active:pending", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				JWTPrivateKey:         "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+				MaxVersionsPerServer:  1,
+				MaxPackagesAndRemotes: 1,
+				StatusTransitions:     tt.transitions,
+			}
+			err := cfg.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,12 +1,34 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"golang.org/x/mod/semver"
 )
 
+// ErrVersionNotNewer is returned when a publish requiring a newer version would not become the latest version
+var ErrVersionNotNewer = errors.New("version is not newer than the latest version")
+
+// VersionNotNewerError describes a publish rejected because its version is not newer than the latest
+type VersionNotNewerError struct {
+	Version string
+	Latest  string
+}
+
+func (e *VersionNotNewerError) Error() string {
+	return fmt.Sprintf("version %s is not newer than the latest version %s", e.Version, e.Latest)
+}
+
+func (e *VersionNotNewerError) Unwrap() error {
+	return ErrVersionNotNewer
+}
+
 // IsSemanticVersion checks if a version string follows semantic versioning format
 // Uses the official golang.org/x/mod/semver package for validation
 // Requires exactly three parts: major.minor.patch (optionally with prerelease/build)
@@ -41,6 +63,18 @@ func ensureVPrefix(version string) string {
 	return version
 }
 
+// majorVersion returns the major version of a semantic version, reporting false for versions that are not semver
+func majorVersion(version string) (int, bool) {
+	if !IsSemanticVersion(version) {
+		return 0, false
+	}
+	major, err := strconv.Atoi(strings.TrimPrefix(semver.Major(ensureVPrefix(version)), "v"))
+	if err != nil {
+		return 0, false
+	}
+	return major, true
+}
+
 // compareSemanticVersions compares two semantic version strings
 // Uses the official golang.org/x/mod/semver package for comparison
 // Returns:
@@ -84,3 +118,19 @@ func CompareVersions(version1 string, version2 string, timestamp1 time.Time, tim
 	}
 	return -1
 }
+
+// SortVersionsNewestFirst orders versions of a server from newest to oldest using CompareVersions, the same
+// ordering that selects the latest version. Versions that compare equal keep their original order.
+func SortVersionsNewestFirst(versions []*apiv0.ServerResponse) {
+	slices.SortStableFunc(versions, func(a, b *apiv0.ServerResponse) int {
+		return CompareVersions(b.Server.Version, a.Server.Version, publishedAt(b), publishedAt(a))
+	})
+}
+
+// publishedAt returns when a server version was published, or the zero time if it has no registry metadata
+func publishedAt(server *apiv0.ServerResponse) time.Time {
+	if server.Meta.Official == nil {
+		return time.Time{}
+	}
+	return server.Meta.Official.PublishedAt
+}
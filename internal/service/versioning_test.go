@@ -1,10 +1,12 @@
 package service_test
 
 import (
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 func TestIsSemanticVersion(t *testing.T) {
@@ -162,3 +164,34 @@ func TestCompareVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestSortVersionsNewestFirst(t *testing.T) {
+	now := time.Now()
+	version := func(v string, publishedAt time.Time) *apiv0.ServerResponse {
+		return &apiv0.ServerResponse{
+			Server: apiv0.ServerJSON{Version: v},
+			Meta:   apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{PublishedAt: publishedAt}},
+		}
+	}
+
+	// Most recently published first, as the database returns them
+	versions := []*apiv0.ServerResponse{
+		version("1.0.1", now),
+		version("nightly", now.Add(-time.Minute)),
+		version("2.0.0", now.Add(-2*time.Minute)),
+		version("snapshot", now.Add(-3*time.Minute)),
+		version("2.0.0-beta.1", now.Add(-4*time.Minute)),
+		version("1.0.0", now.Add(-5*time.Minute)),
+	}
+
+	service.SortVersionsNewestFirst(versions)
+
+	got := make([]string, len(versions))
+	for i, v := range versions {
+		got[i] = v.Server.Version
+	}
+	want := []string{"2.0.0", "2.0.0-beta.1", "1.0.1", "1.0.0", "nightly", "snapshot"}
+	if !slices.Equal(got, want) {
+		t.Errorf("SortVersionsNewestFirst() = %v, want %v", got, want)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
@@ -32,6 +33,15 @@ type Metrics struct {
 
 	// Up tracks the health of the service
 	Up metric.Int64Gauge
+
+	meter metric.Meter
+}
+
+// DBPoolStats is a snapshot of database connection pool usage
+type DBPoolStats struct {
+	Acquired int64
+	Idle     int64
+	Total    int64
 }
 
 // ShutdownFunc is a delegate that shuts down the OpenTelemetry components.
@@ -78,9 +88,78 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 		RequestDuration: reqDuration,
 		ErrorCount:      errCount,
 		Up:              up,
+		meter:           meter,
 	}, nil
 }
 
+// RegisterDBPoolMetrics exposes database connection pool usage as gauges, calling stats on each collection
+func (m *Metrics) RegisterDBPoolMetrics(stats func() DBPoolStats) error {
+	acquired, err := m.meter.Int64ObservableGauge(
+		Namespace+".db_pool_acquired",
+		metric.WithDescription("Number of database connections currently in use"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create db pool acquired gauge: %w", err)
+	}
+
+	idle, err := m.meter.Int64ObservableGauge(
+		Namespace+".db_pool_idle",
+		metric.WithDescription("Number of idle database connections"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create db pool idle gauge: %w", err)
+	}
+
+	total, err := m.meter.Int64ObservableGauge(
+		Namespace+".db_pool_total",
+		metric.WithDescription("Total number of database connections in the pool"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create db pool total gauge: %w", err)
+	}
+
+	_, err = m.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		s := stats()
+		o.ObserveInt64(acquired, s.Acquired)
+		o.ObserveInt64(idle, s.Idle)
+		o.ObserveInt64(total, s.Total)
+		return nil
+	}, acquired, idle, total)
+	if err != nil {
+		return fmt.Errorf("failed to register db pool metrics callback: %w", err)
+	}
+
+	return nil
+}
+
+// RegisterRegistryBreakerMetrics exposes whether each package registry's circuit breaker is open as a gauge
+// (1 = degraded, 0 = healthy), calling states on each collection
+func (m *Metrics) RegisterRegistryBreakerMetrics(states func() map[string]bool) error {
+	open, err := m.meter.Int64ObservableGauge(
+		Namespace+".registry_breaker_open",
+		metric.WithDescription("Whether ownership validation against a package registry is degraded because the registry is failing (1 = degraded, 0 = healthy)"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create registry breaker gauge: %w", err)
+	}
+
+	_, err = m.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for registryType, degraded := range states() {
+			var value int64
+			if degraded {
+				value = 1
+			}
+			o.ObserveInt64(open, value, metric.WithAttributes(attribute.String("registry_type", registryType)))
+		}
+		return nil
+	}, open)
+	if err != nil {
+		return fmt.Errorf("failed to register registry breaker metrics callback: %w", err)
+	}
+
+	return nil
+}
+
 func NewPrometheusMeterProvider(res *resource.Resource, exp *prometheus.Exporter) (*sdkmetric.MeterProvider, error) {
 	if exp == nil {
 		return nil, errors.New("exporter cannot be nil")
@@ -97,20 +176,9 @@ func InitMetrics(version string) (ShutdownFunc, *Metrics, error) {
 	// Initialized the returned shutdownFunc to no-op.
 	shutdown := func(_ context.Context) error { return nil }
 
-	res, err := resource.New(context.Background(),
-		resource.WithAttributes(
-			semconv.ServiceName(Namespace),
-			semconv.ServiceVersion(version),
-		),
-		resource.WithProcessRuntimeDescription(),
-	)
-	if err != nil {
-		return shutdown, nil, fmt.Errorf("failed to create resource: %w", err)
-	}
-
-	res, err = resource.Merge(resource.Default(), res)
+	res, err := newResource(version)
 	if err != nil {
-		return shutdown, nil, fmt.Errorf("failed to merge resources: %w", err)
+		return shutdown, nil, err
 	}
 
 	exporter, err := prometheus.New()
@@ -140,6 +208,26 @@ func InitMetrics(version string) (ShutdownFunc, *Metrics, error) {
 	return shutdown, metrics, err
 }
 
+// newResource describes this service to metrics and trace backends
+func newResource(version string) (*resource.Resource, error) {
+	res, err := resource.New(context.Background(),
+		resource.WithAttributes(
+			semconv.ServiceName(Namespace),
+			semconv.ServiceVersion(version),
+		),
+		resource.WithProcessRuntimeDescription(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	res, err = resource.Merge(resource.Default(), res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge resources: %w", err)
+	}
+	return res, nil
+}
+
 // PrometheusHandler returns the HTTP handler for Prometheus metrics
 // This handler serves the metrics endpoint for Prometheus to scrape.
 func (m *Metrics) PrometheusHandler() http.Handler {
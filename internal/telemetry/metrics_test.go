@@ -1,14 +1,17 @@
 package telemetry_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
@@ -82,3 +85,62 @@ func TestNewPrometheusMeterProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterDBPoolMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics, err := telemetry.NewMetrics(provider.Meter("test"))
+	require.NoError(t, err)
+
+	err = metrics.RegisterDBPoolMetrics(func() telemetry.DBPoolStats {
+		return telemetry.DBPoolStats{Acquired: 3, Idle: 2, Total: 5}
+	})
+	require.NoError(t, err)
+
+	var collected metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &collected))
+
+	values := map[string]int64{}
+	for _, scope := range collected.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if gauge, ok := m.Data.(metricdata.Gauge[int64]); ok && len(gauge.DataPoints) == 1 {
+				values[m.Name] = gauge.DataPoints[0].Value
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]int64{
+		telemetry.Namespace + ".db_pool_acquired": 3,
+		telemetry.Namespace + ".db_pool_idle":     2,
+		telemetry.Namespace + ".db_pool_total":    5,
+	}, values)
+}
+
+func TestRegisterRegistryBreakerMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics, err := telemetry.NewMetrics(provider.Meter("test"))
+	require.NoError(t, err)
+
+	err = metrics.RegisterRegistryBreakerMetrics(func() map[string]bool {
+		return map[string]bool{"npm": false, "oci": true}
+	})
+	require.NoError(t, err)
+
+	var collected metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &collected))
+
+	values := map[string]int64{}
+	for _, scope := range collected.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if gauge, ok := m.Data.(metricdata.Gauge[int64]); ok && m.Name == telemetry.Namespace+".registry_breaker_open" {
+				for _, point := range gauge.DataPoints {
+					registryType, _ := point.Attributes.Value("registry_type")
+					values[registryType.AsString()] = point.Value
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]int64{"npm": 0, "oci": 1}, values)
+}
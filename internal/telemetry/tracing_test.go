@@ -0,0 +1,47 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+func TestInitTracing_DisabledWithoutEndpoint(t *testing.T) {
+	previous := otel.GetTracerProvider()
+
+	shutdown, err := telemetry.InitTracing("", "test")
+	require.NoError(t, err)
+	assert.Equal(t, previous, otel.GetTracerProvider(), "no tracer provider should be installed")
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestStartAndEndSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx, parent := telemetry.StartSpan(context.Background(), "parent")
+	_, child := telemetry.StartSpan(ctx, "child")
+	telemetry.EndSpan(child, errors.New("boom"))
+	telemetry.EndSpan(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "boom", spans[0].Status().Description)
+	assert.Len(t, spans[0].Events(), 1, "the error should be recorded on the span")
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
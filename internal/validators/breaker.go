@@ -0,0 +1,136 @@
+package validators
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+)
+
+// Built-in breaker settings, used when the config leaves them unset
+const (
+	defaultRegistryBreakerThreshold = 5
+	defaultRegistryBreakerCooldown  = time.Minute
+)
+
+// BreakerState is the state of a package registry's circuit breaker
+type BreakerState string
+
+const (
+	// BreakerClosed means the registry is healthy
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen means the registry failed repeatedly and the cooldown has not passed yet
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen means the cooldown has passed, so the next check decides whether the registry recovered
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// RegistryBreaker is a circuit breaker over the package registries ownership checks depend on. It counts
+// consecutive outages of each registry type - checks that could not reach the registry, timed out or got a
+// server error, rather than checks the package failed - and reports a registry as degraded once the count
+// reaches the threshold.
+type RegistryBreaker struct {
+	mu         sync.Mutex
+	registries map[string]*registryOutages
+	now        func() time.Time
+}
+
+// registryOutages tracks the consecutive outages of one registry type
+type registryOutages struct {
+	count int
+	last  time.Time
+}
+
+// DefaultRegistryBreaker is the breaker ValidatePackage records ownership checks in
+var DefaultRegistryBreaker = NewRegistryBreaker()
+
+// NewRegistryBreaker creates a breaker with every registry closed
+func NewRegistryBreaker() *RegistryBreaker {
+	return &RegistryBreaker{
+		registries: make(map[string]*registryOutages),
+		now:        time.Now,
+	}
+}
+
+// Record records the result of an ownership check against the registry type. Outages count towards opening
+// the breaker; any other result, including a failed check, shows the registry is reachable and closes it.
+func (b *RegistryBreaker) Record(registryType string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !isRegistryOutage(err) {
+		delete(b.registries, registryType)
+		return
+	}
+	outages, ok := b.registries[registryType]
+	if !ok {
+		outages = &registryOutages{}
+		b.registries[registryType] = outages
+	}
+	outages.count++
+	outages.last = b.now()
+}
+
+// State returns the breaker state of the registry type under the configured threshold and cooldown
+func (b *RegistryBreaker) State(cfg *config.Config, registryType string) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	outages, ok := b.registries[registryType]
+	if !ok || outages.count < registryBreakerThreshold(cfg) {
+		return BreakerClosed
+	}
+	if b.now().Sub(outages.last) < registryBreakerCooldown(cfg) {
+		return BreakerOpen
+	}
+	return BreakerHalfOpen
+}
+
+// Degraded returns the registry types whose breaker is not closed, sorted
+func (b *RegistryBreaker) Degraded(cfg *config.Config) []string {
+	var degraded []string
+	for _, registryType := range RegistryTypes() {
+		if b.State(cfg, registryType) != BreakerClosed {
+			degraded = append(degraded, registryType)
+		}
+	}
+	return degraded
+}
+
+// RegistryTypes returns the registry types ownership checks are performed for, sorted
+func RegistryTypes() []string {
+	types := make([]string, 0, len(packageValidators))
+	for registryType := range packageValidators {
+		types = append(types, registryType)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// isRegistryOutage reports whether an ownership check failed because the registry could not be reached, did
+// not respond in time or responded with a server error
+func isRegistryOutage(err error) bool {
+	if err == nil {
+		return false
+	}
+	var urlErr *url.Error
+	return errors.Is(err, ErrValidationTimeout) || errors.Is(err, registries.ErrRegistryUnavailable) || errors.As(err, &urlErr)
+}
+
+func registryBreakerThreshold(cfg *config.Config) int {
+	if cfg.RegistryBreakerThreshold > 0 {
+		return cfg.RegistryBreakerThreshold
+	}
+	return defaultRegistryBreakerThreshold
+}
+
+func registryBreakerCooldown(cfg *config.Config) time.Duration {
+	if cfg.RegistryBreakerCooldown > 0 {
+		return cfg.RegistryBreakerCooldown
+	}
+	return defaultRegistryBreakerCooldown
+}
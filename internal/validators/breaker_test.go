@@ -0,0 +1,148 @@
+//nolint:testpackage
+package validators
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// withRegistryBreaker replaces the default breaker with a fresh one for the duration of the test
+func withRegistryBreaker(t *testing.T) *RegistryBreaker {
+	t.Helper()
+	original := DefaultRegistryBreaker
+	DefaultRegistryBreaker = NewRegistryBreaker()
+	t.Cleanup(func() { DefaultRegistryBreaker = original })
+	return DefaultRegistryBreaker
+}
+
+var errUnreachable = &url.Error{Op: "Get", URL: "https://registry.npmjs.org/example", Err: errors.New("connection refused")}
+
+func TestRegistryBreaker(t *testing.T) {
+	cfg := &config.Config{RegistryBreakerThreshold: 3, RegistryBreakerCooldown: time.Minute}
+	now := time.Now()
+	breaker := NewRegistryBreaker()
+	breaker.now = func() time.Time { return now }
+
+	// Outages below the threshold leave the breaker closed
+	breaker.Record(model.RegistryTypeOCI, errUnreachable)
+	breaker.Record(model.RegistryTypeOCI, &ValidationTimeoutError{RegistryType: model.RegistryTypeOCI, Timeout: time.Second})
+	assert.Equal(t, BreakerClosed, breaker.State(cfg, model.RegistryTypeOCI))
+	assert.Empty(t, breaker.Degraded(cfg))
+
+	// Consecutive outages reaching the threshold open it, for that registry only
+	breaker.Record(model.RegistryTypeOCI, errUnreachable)
+	assert.Equal(t, BreakerOpen, breaker.State(cfg, model.RegistryTypeOCI))
+	assert.Equal(t, BreakerClosed, breaker.State(cfg, model.RegistryTypeNPM))
+	assert.Equal(t, []string{model.RegistryTypeOCI}, breaker.Degraded(cfg))
+
+	// After the cooldown the next check decides whether the registry recovered
+	now = now.Add(time.Minute)
+	assert.Equal(t, BreakerHalfOpen, breaker.State(cfg, model.RegistryTypeOCI))
+	assert.Equal(t, []string{model.RegistryTypeOCI}, breaker.Degraded(cfg))
+
+	// A check the package failed still shows the registry is reachable
+	breaker.Record(model.RegistryTypeOCI, errors.New("missing annotation"))
+	assert.Equal(t, BreakerClosed, breaker.State(cfg, model.RegistryTypeOCI))
+	assert.Empty(t, breaker.Degraded(cfg))
+}
+
+// statusTransport answers every request with the same status code
+type statusTransport int
+
+func (s statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: int(s), Body: http.NoBody, Header: http.Header{}, Request: req}, nil
+}
+
+func TestRegistryBreaker_ServerErrors(t *testing.T) {
+	cfg := &config.Config{RegistryBreakerThreshold: 3, RegistryBreakerCooldown: time.Minute}
+	pkg := model.Package{RegistryType: model.RegistryTypeOCI, RegistryBaseURL: model.RegistryURLGHCR, Identifier: "example/image", Version: "1.0.0"}
+
+	tests := []struct {
+		status   int
+		expected BreakerState
+	}{
+		{http.StatusBadGateway, BreakerOpen},
+		{http.StatusServiceUnavailable, BreakerOpen},
+		{http.StatusGatewayTimeout, BreakerOpen},
+		{http.StatusNotFound, BreakerClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			breaker := NewRegistryBreaker()
+			for range 3 {
+				err := registries.ValidateOCIWithOptions(context.Background(), pkg, "io.github.example/image", registries.OCIOptions{
+					HTTPClient:    &http.Client{Transport: statusTransport(tt.status)},
+					RetryAttempts: 1,
+				})
+				require.Error(t, err)
+				breaker.Record(model.RegistryTypeOCI, err)
+			}
+			assert.Equal(t, tt.expected, breaker.State(cfg, model.RegistryTypeOCI))
+		})
+	}
+}
+
+func TestValidatePackage_RegistryBreaker(t *testing.T) {
+	calls := 0
+	withPackageValidator(t, model.RegistryTypeNPM, func(_ context.Context, _ model.Package, _ string, _ *config.Config) error {
+		calls++
+		return errUnreachable
+	})
+	pkg := model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "example"}
+
+	tests := []struct {
+		name      string
+		skip      bool
+		expectErr bool
+	}{
+		{"validation still runs while degraded by default", false, true},
+		{"validation is skipped while degraded when configured", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := withRegistryBreaker(t)
+			cfg := &config.Config{RegistryBreakerThreshold: 2, SkipValidationOnOutage: tt.skip}
+
+			for range 2 {
+				require.ErrorIs(t, ValidatePackage(context.Background(), pkg, "com.example/server", cfg), errUnreachable)
+			}
+			require.Equal(t, BreakerOpen, breaker.State(cfg, model.RegistryTypeNPM))
+
+			calls = 0
+			err := ValidatePackage(context.Background(), pkg, "com.example/server", cfg)
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.Equal(t, 1, calls)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, calls)
+			}
+		})
+	}
+}
+
+func TestValidatePackage_CancelledChecksAreNotOutages(t *testing.T) {
+	breaker := withRegistryBreaker(t)
+	withPackageValidator(t, model.RegistryTypeNPM, func(ctx context.Context, _ model.Package, _ string, _ *config.Config) error {
+		return &url.Error{Op: "Get", URL: "https://registry.npmjs.org/example", Err: ctx.Err()}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cfg := &config.Config{RegistryBreakerThreshold: 1}
+	require.Error(t, ValidatePackage(ctx, model.Package{RegistryType: model.RegistryTypeNPM}, "com.example/server", cfg))
+	assert.Equal(t, BreakerClosed, breaker.State(cfg, model.RegistryTypeNPM))
+}
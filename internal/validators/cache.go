@@ -0,0 +1,103 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// validationCacheKey identifies an ownership check: the package, down to its version and file hash, and the
+// server name it must be published under
+type validationCacheKey struct {
+	RegistryType    string
+	RegistryBaseURL string
+	Identifier      string
+	Version         string
+	FileSHA256      string
+	ServerName      string
+}
+
+func newValidationCacheKey(pkg model.Package, serverName string) validationCacheKey {
+	return validationCacheKey{
+		RegistryType:    pkg.RegistryType,
+		RegistryBaseURL: effectiveRegistryBaseURL(pkg),
+		Identifier:      pkg.Identifier,
+		Version:         pkg.Version,
+		FileSHA256:      pkg.FileSHA256,
+		ServerName:      serverName,
+	}
+}
+
+// validationOutcome is a cached ownership check result; a nil err means the package passed
+type validationOutcome struct {
+	err       error
+	expiresAt time.Time
+}
+
+// ValidationCache remembers the outcome of ownership checks for a short time, so validating an unchanged
+// package again, as repeated publishes and edits do, doesn't query its registry again
+type ValidationCache struct {
+	mu       sync.Mutex
+	outcomes map[validationCacheKey]validationOutcome
+	now      func() time.Time
+}
+
+// DefaultValidationCache is the cache ValidatePackage reuses ownership check outcomes from
+var DefaultValidationCache = NewValidationCache()
+
+// NewValidationCache creates an empty cache
+func NewValidationCache() *ValidationCache {
+	return &ValidationCache{
+		outcomes: make(map[validationCacheKey]validationOutcome),
+		now:      time.Now,
+	}
+}
+
+// Get returns the cached outcome of an ownership check, if there is one that has not expired
+func (c *ValidationCache) Get(key validationCacheKey) (validationOutcome, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	outcome, ok := c.outcomes[key]
+	if !ok {
+		return validationOutcome{}, false
+	}
+	if !c.now().Before(outcome.expiresAt) {
+		delete(c.outcomes, key)
+		return validationOutcome{}, false
+	}
+	return outcome, true
+}
+
+// Put caches the outcome of an ownership check for ttl. Outcomes that say nothing lasting about the package -
+// registry outages, rate limiting and cancelled checks - are not cached, nor is anything when ttl is zero.
+func (c *ValidationCache) Put(key validationCacheKey, err error, ttl time.Duration) {
+	if ttl <= 0 || isTransientValidationError(err) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	// Drop expired outcomes as new ones come in, so the cache doesn't grow with every package ever validated
+	for cachedKey, outcome := range c.outcomes {
+		if !now.Before(outcome.expiresAt) {
+			delete(c.outcomes, cachedKey)
+		}
+	}
+	c.outcomes[key] = validationOutcome{err: err, expiresAt: now.Add(ttl)}
+}
+
+// isTransientValidationError reports whether an ownership check failed for a reason that may have passed by
+// the next check
+func isTransientValidationError(err error) bool {
+	return isRegistryOutage(err) ||
+		errors.Is(err, registries.ErrRateLimited) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,112 @@
+//nolint:testpackage
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// withValidationCache replaces the default validation cache with a fresh one for the duration of the test
+func withValidationCache(t *testing.T) *ValidationCache {
+	t.Helper()
+	original := DefaultValidationCache
+	DefaultValidationCache = NewValidationCache()
+	t.Cleanup(func() { DefaultValidationCache = original })
+	return DefaultValidationCache
+}
+
+func TestValidatePackage_Cache(t *testing.T) {
+	withRegistryBreaker(t)
+	cfg := &config.Config{ValidationCacheTTL: time.Minute}
+	pkg := model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "example", Version: "1.0.0"}
+	errMismatch := errors.New("package is published for another server")
+
+	tests := []struct {
+		name          string
+		result        error
+		expectedCalls int
+	}{
+		{"success is cached", nil, 1},
+		{"ownership failure is cached", errMismatch, 1},
+		{"outage is not cached", errUnreachable, 2},
+		{"rate limiting is not cached", fmt.Errorf("%w: example", registries.ErrRateLimited), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withValidationCache(t)
+			calls := 0
+			withPackageValidator(t, model.RegistryTypeNPM, func(_ context.Context, _ model.Package, _ string, _ *config.Config) error {
+				calls++
+				return tt.result
+			})
+
+			for range 2 {
+				err := ValidatePackage(context.Background(), pkg, "com.example/server", cfg)
+				if tt.result == nil {
+					require.NoError(t, err)
+				} else {
+					require.ErrorIs(t, err, tt.result)
+				}
+			}
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestValidatePackage_CacheKey(t *testing.T) {
+	withRegistryBreaker(t)
+	withValidationCache(t)
+	cfg := &config.Config{ValidationCacheTTL: time.Minute}
+	calls := 0
+	withPackageValidator(t, model.RegistryTypeNPM, func(_ context.Context, _ model.Package, _ string, _ *config.Config) error {
+		calls++
+		return nil
+	})
+
+	pkg := model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "example", Version: "1.0.0"}
+	require.NoError(t, ValidatePackage(context.Background(), pkg, "com.example/server", cfg))
+
+	// The default registry base URL is the same package as an explicit one
+	explicit := pkg
+	explicit.RegistryBaseURL = model.RegistryURLNPM
+	require.NoError(t, ValidatePackage(context.Background(), explicit, "com.example/server", cfg))
+	assert.Equal(t, 1, calls)
+
+	otherVersion := pkg
+	otherVersion.Version = "1.0.1"
+	require.NoError(t, ValidatePackage(context.Background(), otherVersion, "com.example/server", cfg))
+	assert.Equal(t, 2, calls, "another version must be checked")
+
+	require.NoError(t, ValidatePackage(context.Background(), pkg, "com.example/other-server", cfg))
+	assert.Equal(t, 3, calls, "ownership must be checked for another server name")
+}
+
+func TestValidationCache_Expiry(t *testing.T) {
+	cache := NewValidationCache()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	key := newValidationCacheKey(model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "example"}, "com.example/server")
+
+	cache.Put(key, nil, time.Minute)
+	_, ok := cache.Get(key)
+	assert.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Get(key)
+	assert.False(t, ok, "outcomes expire after the TTL")
+
+	cache.Put(key, nil, 0)
+	_, ok = cache.Get(key)
+	assert.False(t, ok, "nothing is cached with a zero TTL")
+}
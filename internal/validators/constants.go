@@ -9,9 +9,11 @@ var (
 	ErrInvalidSubfolderPath = errors.New("invalid subfolder path")
 
 	// Package validation errors
-	ErrPackageNameHasSpaces  = errors.New("package name cannot contain spaces")
-	ErrReservedVersionString = errors.New("version string 'latest' is reserved and cannot be used")
-	ErrVersionLooksLikeRange = errors.New("version must be a specific version, not a range")
+	ErrPackageNameHasSpaces   = errors.New("package name cannot contain spaces")
+	ErrReservedVersionString  = errors.New("version string 'latest' is reserved and cannot be used")
+	ErrVersionLooksLikeRange  = errors.New("version must be a specific version, not a range")
+	ErrVersionNotSemver       = errors.New("version must be a semantic version such as 1.2.3")
+	ErrPackageVersionNotExact = errors.New("package version must be an exact version the registry can resolve")
 
 	// Remote validation errors
 	ErrInvalidRemoteURL = errors.New("invalid remote URL")
@@ -26,9 +28,36 @@ var (
 	ErrArgumentValueStartsWithName   = errors.New("argument value cannot start with the argument name")
 	ErrArgumentDefaultStartsWithName = errors.New("argument default cannot start with the argument name")
 
+	// Environment variable validation errors
+	ErrInvalidEnvironmentVariableName = errors.New("environment variable name must match [A-Z_][A-Z0-9_]*")
+	ErrDuplicateEnvironmentVariable   = errors.New("environment variable is declared more than once")
+
+	// Schema validation errors
+	ErrSchemaValidation = errors.New("server JSON does not match server.schema.json")
+
 	// Server name validation errors
 	ErrMultipleSlashesInServerName = errors.New("server name cannot contain multiple slashes")
 	ErrInvalidServerNameFormat     = errors.New("server name format is invalid")
+	ErrInvalidServerNamespace      = errors.New("server namespace must be a valid reverse-DNS name")
+	ErrNonActivePublishStatus      = errors.New("servers can only be published as active; use the edit endpoint to change status")
+	ErrTooManyPackagesAndRemotes   = errors.New("too many packages and remotes")
+	ErrTooManyPackages             = errors.New("too many packages")
+	ErrTooManyRemotes              = errors.New("too many remotes")
+	ErrDocumentTooLarge            = errors.New("server.json document is too large")
+	ErrNoPackagesOrRemotes         = errors.New("server must declare at least one package or remote")
+	ErrUnsupportedTransportType    = errors.New("unsupported transport type")
+	ErrDuplicateRemote             = errors.New("remote URL is listed more than once")
+	ErrMissingRequiredField        = errors.New("missing required field")
+	ErrInvalidCategory             = errors.New("invalid category")
+	ErrGitHubOwnerCaseMismatch     = errors.New("server namespace casing does not match the GitHub owner")
+	ErrValidationTimeout           = errors.New("package registry validation timed out")
+	ErrValidationQueueTimeout      = errors.New("too many servers are being validated, please try again later")
+	ErrNamespaceNotAllowed         = errors.New("server namespace is not allowed on this registry")
+	ErrNameBlocked                 = errors.New("server name is not allowed on this registry")
+	ErrInvalidIconURL              = errors.New("invalid iconUrl")
+	ErrInvalidDocumentationURL     = errors.New("invalid documentationUrl")
+	ErrLongDescriptionTooLong      = errors.New("longDescription is too long")
+	ErrInvalidMaintainer           = errors.New("invalid maintainer")
 )
 
 // RepositorySource represents valid repository sources
@@ -0,0 +1,83 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// maxIconURLLength bounds the iconUrl, which clients embed in listings and fetch directly
+const maxIconURLLength = 2048
+
+// iconCheckTimeout bounds the HEAD request made to an icon when its content type is checked
+const iconCheckTimeout = 10 * time.Second
+
+// newIconClient returns the client used to check icon content types. Tests replace it to trust their servers.
+var newIconClient = func(cfg *config.Config) *http.Client {
+	return &http.Client{
+		Timeout:   iconCheckTimeout,
+		Transport: config.NewOutboundTransport(cfg.MinTLSVersion(), nil),
+	}
+}
+
+func validateIconURL(iconURL string) error {
+	// Skip validation if icon URL is not provided (optional field)
+	if iconURL == "" {
+		return nil
+	}
+
+	if len(iconURL) > maxIconURLLength {
+		return fmt.Errorf("%w: must be at most %d characters", ErrInvalidIconURL, maxIconURLLength)
+	}
+
+	parsedURL, err := url.Parse(iconURL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidIconURL, err)
+	}
+
+	// Icons are loaded by clients, so only allow HTTPS to avoid mixed content and tampering in transit
+	if parsedURL.Scheme != "https" || parsedURL.Host == "" {
+		return fmt.Errorf("%w: must be an absolute https URL: %s", ErrInvalidIconURL, iconURL)
+	}
+
+	return nil
+}
+
+// ValidateIconContentType makes a HEAD request to the server's icon, if it has one and EnableIconContentTypeCheck
+// is set, and checks that it is served as an image
+func ValidateIconContentType(ctx context.Context, serverJSON apiv0.ServerJSON, cfg *config.Config) error {
+	iconURL := serverJSON.IconURL
+	if !cfg.EnableIconContentTypeCheck || iconURL == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, iconURL, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidIconURL, err)
+	}
+	req.Header.Set("User-Agent", cfg.UserAgent())
+
+	resp, err := newIconClient(cfg).Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: failed to fetch icon: %w", ErrInvalidIconURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: icon returned status %d", ErrInvalidIconURL, resp.StatusCode)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "image/") {
+		return fmt.Errorf("%w: icon is served as %q, not an image", ErrInvalidIconURL, resp.Header.Get("Content-Type"))
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+//nolint:testpackage
+package validators
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestValidateIconContentType(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/logo.png":
+			w.Header().Set("Content-Type", "image/png")
+		case "/logo.svg":
+			w.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+		case "/index.html":
+			w.Header().Set("Content-Type", "text/html")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	original := newIconClient
+	newIconClient = func(_ *config.Config) *http.Client { return server.Client() }
+	t.Cleanup(func() { newIconClient = original })
+
+	tests := []struct {
+		name        string
+		path        string
+		checkIcons  bool
+		expectError bool
+	}{
+		{"png icon", "/logo.png", true, false},
+		{"svg icon with parameters", "/logo.svg", true, false},
+		{"html page", "/index.html", true, true},
+		{"missing icon", "/missing.png", true, true},
+		{"html page without the check", "/index.html", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{EnableIconContentTypeCheck: tt.checkIcons}
+			serverJSON := apiv0.ServerJSON{IconURL: server.URL + tt.path}
+			err := ValidateIconContentType(context.Background(), serverJSON, cfg)
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrInvalidIconURL)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
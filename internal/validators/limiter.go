@@ -0,0 +1,90 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// defaultValidationQueueTimeout bounds how long a publish waits for a validation slot when the config sets no timeout
+const defaultValidationQueueTimeout = 30 * time.Second
+
+// ValidationLimiter bounds how many publishes and edits check package ownership at once, so a burst of
+// publishes queues instead of hitting package registries all at once and tripping their rate limits
+type ValidationLimiter struct {
+	mu    sync.Mutex
+	limit int
+	slots chan struct{}
+}
+
+// DefaultValidationLimiter is the limiter VerifyPackages acquires a slot from
+var DefaultValidationLimiter = &ValidationLimiter{}
+
+// Acquire waits for a validation slot under the configured limit, returning a function that releases it. When
+// no slot frees up within the queue timeout it returns an error wrapping ErrValidationQueueTimeout. A limit of
+// zero does not limit validations.
+func (l *ValidationLimiter) Acquire(ctx context.Context, cfg *config.Config) (func(), error) {
+	slots := l.slotsFor(cfg.MaxConcurrentPublishValidations)
+	if slots == nil {
+		return func() {}, nil
+	}
+
+	timeout := cfg.PublishValidationQueueTimeout
+	if timeout <= 0 {
+		timeout = defaultValidationQueueTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("%w: no validation slot became free within %s", ErrValidationQueueTimeout, timeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// slotsFor returns the semaphore for the limit, replacing it if the limit changed, or nil when unlimited
+func (l *ValidationLimiter) slotsFor(limit int) chan struct{} {
+	if limit <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.slots == nil || l.limit != limit {
+		l.limit = limit
+		l.slots = make(chan struct{}, limit)
+	}
+	return l.slots
+}
+
+// VerifyPackages checks ownership of every package like VerifyPackage, reporting whether all of them were
+// verified. The checks run once a slot in DefaultValidationLimiter is free.
+func VerifyPackages(ctx context.Context, packages []model.Package, serverName string, cfg *config.Config) (bool, error) {
+	if len(packages) == 0 {
+		return true, nil
+	}
+
+	release, err := DefaultValidationLimiter.Acquire(ctx, cfg)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	verified := true
+	for i, pkg := range packages {
+		packageVerified, err := VerifyPackage(ctx, pkg, serverName, cfg)
+		if err != nil {
+			return false, fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
+		}
+		verified = verified && packageVerified
+	}
+	return verified, nil
+}
@@ -2,28 +2,178 @@ package validators
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
-// ValidatePackage validates that the package referenced in the server configuration is:
-// 1. allowed on the official registry (based on registry base url); and
-// 2. owned by the publisher, by checking for a matching server name in the package metadata
-func ValidatePackage(ctx context.Context, pkg model.Package, serverName string) error {
-	switch pkg.RegistryType {
-	case model.RegistryTypeNPM:
+// defaultRegistryValidationTimeout bounds each package's ownership check when the config sets no timeout
+const defaultRegistryValidationTimeout = 30 * time.Second
+
+// ValidationTimeoutError reports that a package registry did not answer an ownership check in time
+type ValidationTimeoutError struct {
+	RegistryType string
+	Timeout      time.Duration
+}
+
+func (e *ValidationTimeoutError) Error() string {
+	return fmt.Sprintf("%s: the %s registry did not respond within %s; it may be slow or unavailable, please try again later",
+		ErrValidationTimeout, e.RegistryType, e.Timeout)
+}
+
+func (e *ValidationTimeoutError) Unwrap() error {
+	return ErrValidationTimeout
+}
+
+// packageValidators check package ownership against the public registry of each registry type
+var packageValidators = map[string]func(ctx context.Context, pkg model.Package, serverName string, cfg *config.Config) error{
+	model.RegistryTypeNPM: func(ctx context.Context, pkg model.Package, serverName string, _ *config.Config) error {
 		return registries.ValidateNPM(ctx, pkg, serverName)
-	case model.RegistryTypePyPI:
+	},
+	model.RegistryTypePyPI: func(ctx context.Context, pkg model.Package, serverName string, _ *config.Config) error {
 		return registries.ValidatePyPI(ctx, pkg, serverName)
-	case model.RegistryTypeNuGet:
+	},
+	model.RegistryTypeNuGet: func(ctx context.Context, pkg model.Package, serverName string, _ *config.Config) error {
 		return registries.ValidateNuGet(ctx, pkg, serverName)
-	case model.RegistryTypeOCI:
-		return registries.ValidateOCI(ctx, pkg, serverName)
-	case model.RegistryTypeMCPB:
+	},
+	model.RegistryTypeOCI: func(ctx context.Context, pkg model.Package, serverName string, cfg *config.Config) error {
+		return registries.ValidateOCIWithOptions(ctx, pkg, serverName, registries.OCIOptions{
+			StrictDigestBinding: cfg.EnableStrictOCIBinding,
+			UsePinnedDigest:     cfg.EnableOCIPinnedDigest,
+			RejectMutableTags:   cfg.RejectMutableOCITags,
+			RetryAttempts:       cfg.OCIRetryAttempts,
+			RetryBackoff:        cfg.OCIRetryBackoff,
+			UserAgent:           cfg.UserAgent(),
+			MinTLSVersion:       cfg.MinTLSVersion(),
+		})
+	},
+	model.RegistryTypeMCPB: func(ctx context.Context, pkg model.Package, serverName string, _ *config.Config) error {
 		return registries.ValidateMCPB(ctx, pkg, serverName)
-	default:
-		return fmt.Errorf("unsupported registry type: %s", pkg.RegistryType)
+	},
+}
+
+// ValidatePackage validates that the package referenced in the server configuration is:
+// 1. allowed on the official registry (based on registry base url); and
+// 2. owned by the publisher, by checking for a matching server name in the package metadata
+//
+// The check is bounded by the registry type's validation timeout, returning a ValidationTimeoutError if exceeded.
+func ValidatePackage(ctx context.Context, pkg model.Package, serverName string, cfg *config.Config) error {
+	_, err := VerifyPackage(ctx, pkg, serverName, cfg)
+	return err
+}
+
+// VerifyPackage validates the package like ValidatePackage, also reporting whether its ownership was actually
+// proven: a package accepted without a check because its registry is degraded is not verified.
+func VerifyPackage(ctx context.Context, pkg model.Package, serverName string, cfg *config.Config) (bool, error) {
+	validate, ok := packageValidators[pkg.RegistryType]
+	if !ok {
+		return false, fmt.Errorf("unsupported registry type: %s", pkg.RegistryType)
+	}
+
+	// Reuse a recent outcome for the same package and server instead of asking the registry again
+	key := newValidationCacheKey(pkg, serverName)
+	if outcome, ok := DefaultValidationCache.Get(key); ok {
+		return outcome.err == nil, outcome.err
+	}
+
+	// While the registry is known to be down, optionally accept the package rather than fail every publish
+	if cfg.SkipValidationOnOutage && DefaultRegistryBreaker.State(cfg, pkg.RegistryType) == BreakerOpen {
+		log.Printf("Skipping %s ownership validation of %s for %s: the registry is degraded", pkg.RegistryType, pkg.Identifier, serverName)
+		return false, nil
+	}
+
+	err := validateWithTimeout(ctx, validate, pkg, serverName, cfg)
+	// A check abandoned because the request went away says nothing about the registry or the package
+	if ctx.Err() == nil {
+		DefaultRegistryBreaker.Record(pkg.RegistryType, err)
+		DefaultValidationCache.Put(key, err, cfg.ValidationCacheTTL)
+	}
+	return err == nil, err
+}
+
+// validateWithTimeout runs the ownership check bounded by the registry type's validation timeout
+func validateWithTimeout(ctx context.Context, validate func(context.Context, model.Package, string, *config.Config) error, pkg model.Package, serverName string, cfg *config.Config) error {
+	timeout := registryValidationTimeout(cfg, pkg.RegistryType)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := validate(ctx, pkg, serverName, cfg)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &ValidationTimeoutError{RegistryType: pkg.RegistryType, Timeout: timeout}
+	}
+	return err
+}
+
+// registryValidationTimeout returns the ownership check timeout for the registry type, preferring a per-type override
+func registryValidationTimeout(cfg *config.Config, registryType string) time.Duration {
+	if timeout := cfg.RegistryValidationTimeouts[registryType]; timeout > 0 {
+		return timeout
+	}
+	if cfg.RegistryValidationTimeout > 0 {
+		return cfg.RegistryValidationTimeout
+	}
+	return defaultRegistryValidationTimeout
+}
+
+// defaultRegistryBaseURLs are the registry base URLs packages use when they don't specify one
+var defaultRegistryBaseURLs = map[string]string{
+	model.RegistryTypeNPM:   model.RegistryURLNPM,
+	model.RegistryTypePyPI:  model.RegistryURLPyPI,
+	model.RegistryTypeNuGet: model.RegistryURLNuGet,
+	model.RegistryTypeOCI:   model.RegistryURLDocker,
+}
+
+// validateRegistryBaseURLPolicy checks the package's registry against the configured allowlist and denylist
+func validateRegistryBaseURLPolicy(pkg model.Package, cfg *config.Config) error {
+	if len(cfg.AllowedRegistryBaseURLs) == 0 && len(cfg.DeniedRegistryBaseURLs) == 0 {
+		return nil
+	}
+
+	baseURL := effectiveRegistryBaseURL(pkg)
+	matches := func(candidate string) bool {
+		return strings.TrimSuffix(strings.TrimSpace(candidate), "/") == baseURL
+	}
+
+	if slices.ContainsFunc(cfg.DeniedRegistryBaseURLs, matches) {
+		return fmt.Errorf("%w: packages from %s are not allowed on this registry", ErrUnsupportedRegistryBaseURL, baseURL)
+	}
+	if len(cfg.AllowedRegistryBaseURLs) > 0 && !slices.ContainsFunc(cfg.AllowedRegistryBaseURLs, matches) {
+		return fmt.Errorf("%w: packages from %s are not allowed on this registry (allowed: %s)",
+			ErrUnsupportedRegistryBaseURL, baseURL, strings.Join(cfg.AllowedRegistryBaseURLs, ", "))
+	}
+
+	return nil
+}
+
+// ValidateRegistryBaseURLPolicies checks every package's registry against the configured allowlist and denylist,
+// for both publishing and editing
+func ValidateRegistryBaseURLPolicies(packages []model.Package, cfg *config.Config) error {
+	for i, pkg := range packages {
+		if err := validateRegistryBaseURLPolicy(pkg, cfg); err != nil {
+			return fmt.Errorf("package %d (%s): %w", i, pkg.Identifier, err)
+		}
+	}
+	return nil
+}
+
+// effectiveRegistryBaseURL returns the registry base URL a package resolves against, applying the registry
+// type's default, or for MCPB packages the identifier's host, when none is given
+func effectiveRegistryBaseURL(pkg model.Package) string {
+	if pkg.RegistryBaseURL != "" {
+		return strings.TrimSuffix(pkg.RegistryBaseURL, "/")
+	}
+	if pkg.RegistryType == model.RegistryTypeMCPB {
+		if parsed, err := url.Parse(pkg.Identifier); err == nil && parsed.Host != "" {
+			return parsed.Scheme + "://" + parsed.Host
+		}
 	}
+	return defaultRegistryBaseURLs[pkg.RegistryType]
 }
@@ -0,0 +1,81 @@
+//nolint:testpackage
+package validators
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestVerifyPublishRequest(t *testing.T) {
+	withPackageValidator(t, model.RegistryTypeNPM, func(_ context.Context, _ model.Package, _ string, _ *config.Config) error {
+		return errUnreachable
+	})
+	withPackageValidator(t, model.RegistryTypePyPI, func(_ context.Context, _ model.Package, _ string, _ *config.Config) error {
+		return nil
+	})
+
+	pypiPackage := model.Package{
+		RegistryType: model.RegistryTypePyPI,
+		Identifier:   "verified-package",
+		Version:      "1.0.0",
+		Transport:    model.Transport{Type: model.TransportTypeStdio},
+	}
+	npmPackage := model.Package{
+		RegistryType: model.RegistryTypeNPM,
+		Identifier:   "degraded-package",
+		Version:      "1.0.0",
+		Transport:    model.Transport{Type: model.TransportTypeStdio},
+	}
+	serverJSON := func(packages ...model.Package) apiv0.ServerJSON {
+		return apiv0.ServerJSON{
+			Name:        "com.example/test-server",
+			Description: "A test server",
+			Version:     "1.0.0",
+			Packages:    packages,
+		}
+	}
+
+	t.Run("validated packages are verified", func(t *testing.T) {
+		withValidationCache(t)
+		verified, err := VerifyPublishRequest(context.Background(), serverJSON(pypiPackage), &config.Config{EnableRegistryValidation: true})
+		require.NoError(t, err)
+		assert.True(t, verified)
+	})
+
+	t.Run("disabled validation is not verified", func(t *testing.T) {
+		withValidationCache(t)
+		verified, err := VerifyPublishRequest(context.Background(), serverJSON(pypiPackage), &config.Config{EnableRegistryValidation: false})
+		require.NoError(t, err)
+		assert.False(t, verified)
+	})
+
+	t.Run("checks skipped during an outage are not verified", func(t *testing.T) {
+		withValidationCache(t)
+		breaker := withRegistryBreaker(t)
+		cfg := &config.Config{EnableRegistryValidation: true, RegistryBreakerThreshold: 1, SkipValidationOnOutage: true}
+		breaker.Record(model.RegistryTypeNPM, errUnreachable)
+		require.Equal(t, BreakerOpen, breaker.State(cfg, model.RegistryTypeNPM))
+
+		verified, err := VerifyPublishRequest(context.Background(), serverJSON(pypiPackage, npmPackage), cfg)
+		require.NoError(t, err)
+		assert.False(t, verified)
+	})
+
+	t.Run("cached outcomes are verified", func(t *testing.T) {
+		withValidationCache(t)
+		cfg := &config.Config{EnableRegistryValidation: true, ValidationCacheTTL: time.Minute}
+		for range 2 {
+			verified, err := VerifyPublishRequest(context.Background(), serverJSON(pypiPackage), cfg)
+			require.NoError(t, err)
+			assert.True(t, verified)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+//nolint:testpackage
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// withPackageValidator replaces the validator for a registry type for the duration of the test
+func withPackageValidator(t *testing.T, registryType string, validate func(ctx context.Context, pkg model.Package, serverName string, cfg *config.Config) error) {
+	t.Helper()
+	original := packageValidators[registryType]
+	packageValidators[registryType] = validate
+	t.Cleanup(func() { packageValidators[registryType] = original })
+}
+
+func TestValidatePublishRequest_RegistryValidationTimeout(t *testing.T) {
+	// A registry that takes longer to answer than the validation timeout allows
+	withPackageValidator(t, model.RegistryTypeNPM, func(ctx context.Context, _ model.Package, _ string, _ *config.Config) error {
+		select {
+		case <-time.After(5 * time.Second):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	serverJSON := apiv0.ServerJSON{
+		Name:        "com.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+		Packages: []model.Package{{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "test-package",
+			Version:      "1.0.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+		}},
+	}
+	cfg := &config.Config{
+		EnableRegistryValidation:   true,
+		RegistryValidationTimeout:  time.Minute,
+		RegistryValidationTimeouts: map[string]time.Duration{model.RegistryTypeNPM: 20 * time.Millisecond},
+	}
+
+	start := time.Now()
+	err := ValidatePublishRequest(context.Background(), serverJSON, cfg)
+	assert.Less(t, time.Since(start), time.Second, "the per-type timeout should override the general one")
+
+	require.ErrorIs(t, err, ErrValidationTimeout)
+	var timeoutErr *ValidationTimeoutError
+	require.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, model.RegistryTypeNPM, timeoutErr.RegistryType)
+	assert.Equal(t, 20*time.Millisecond, timeoutErr.Timeout)
+	assert.Contains(t, err.Error(), "did not respond within 20ms")
+}
+
+func TestValidatePackage_FailuresAreNotTimeouts(t *testing.T) {
+	withPackageValidator(t, model.RegistryTypeNPM, func(_ context.Context, _ model.Package, _ string, _ *config.Config) error {
+		return errors.New("package not found")
+	})
+
+	err := ValidatePackage(context.Background(), model.Package{RegistryType: model.RegistryTypeNPM}, "com.example/test-server", &config.Config{})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrValidationTimeout)
+}
+
+func TestRegistryValidationTimeout(t *testing.T) {
+	assert.Equal(t, defaultRegistryValidationTimeout, registryValidationTimeout(&config.Config{}, model.RegistryTypeOCI))
+
+	cfg := &config.Config{
+		RegistryValidationTimeout:  10 * time.Second,
+		RegistryValidationTimeouts: map[string]time.Duration{model.RegistryTypeOCI: time.Minute},
+	}
+	assert.Equal(t, time.Minute, registryValidationTimeout(cfg, model.RegistryTypeOCI))
+	assert.Equal(t, 10*time.Second, registryValidationTimeout(cfg, model.RegistryTypePyPI))
+}
+
+func TestVerifyPackages_ConcurrencyLimit(t *testing.T) {
+	const limit = 2
+	t.Cleanup(func() { DefaultValidationLimiter = &ValidationLimiter{} })
+	DefaultValidationLimiter = &ValidationLimiter{}
+
+	// A validator that counts how many checks run at once
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	withPackageValidator(t, model.RegistryTypeNPM, func(_ context.Context, _ model.Package, _ string, _ *config.Config) error {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	})
+
+	cfg := &config.Config{MaxConcurrentPublishValidations: limit, PublishValidationQueueTimeout: 10 * time.Second}
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pkg := model.Package{RegistryType: model.RegistryTypeNPM, Identifier: fmt.Sprintf("limited-package-%d", i), Version: "1.0.0"}
+			_, err := VerifyPackages(context.Background(), []model.Package{pkg}, "com.example/test-server", cfg)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, limit, maxRunning)
+}
+
+func TestVerifyPackages_QueueTimeout(t *testing.T) {
+	t.Cleanup(func() { DefaultValidationLimiter = &ValidationLimiter{} })
+	DefaultValidationLimiter = &ValidationLimiter{}
+
+	// A validator that holds its slot until released
+	release := make(chan struct{})
+	started := make(chan struct{})
+	withPackageValidator(t, model.RegistryTypeNPM, func(_ context.Context, _ model.Package, _ string, _ *config.Config) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	cfg := &config.Config{MaxConcurrentPublishValidations: 1, PublishValidationQueueTimeout: 20 * time.Millisecond}
+	pkg := model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "queued-package", Version: "1.0.0"}
+
+	done := make(chan error)
+	go func() {
+		_, err := VerifyPackages(context.Background(), []model.Package{pkg}, "com.example/test-server", cfg)
+		done <- err
+	}()
+	<-started
+
+	// The only slot is taken, so the next validation gives up waiting
+	_, err := VerifyPackages(context.Background(), []model.Package{{RegistryType: model.RegistryTypeNPM, Identifier: "waiting-package", Version: "1.0.0"}}, "com.example/test-server", cfg)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrValidationQueueTimeout)
+
+	close(release)
+	assert.NoError(t, <-done)
+}
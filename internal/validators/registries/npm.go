@@ -55,7 +55,7 @@ func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) erro
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	req.Header.Set("User-Agent", DefaultUserAgent)
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := client.Do(req)
@@ -64,6 +64,9 @@ func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) erro
 	}
 	defer resp.Body.Close()
 
+	if err := serverError("NPM", resp); err != nil {
+		return err
+	}
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("NPM package '%s' not found (status: %d)", pkg.Identifier, resp.StatusCode)
 	}
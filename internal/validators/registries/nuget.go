@@ -49,7 +49,7 @@ func ValidateNuGet(ctx context.Context, pkg model.Package, serverName string) er
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	req.Header.Set("User-Agent", DefaultUserAgent)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -57,6 +57,9 @@ func ValidateNuGet(ctx context.Context, pkg model.Package, serverName string) er
 	}
 	defer resp.Body.Close()
 
+	if err := serverError("NuGet", resp); err != nil {
+		return err
+	}
 	if resp.StatusCode == http.StatusOK {
 		// Check README content
 		readmeBytes, err := io.ReadAll(resp.Body)
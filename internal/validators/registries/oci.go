@@ -2,11 +2,16 @@ package registries
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,8 +21,12 @@ import (
 var (
 	ErrMissingIdentifierForOCI = errors.New("package identifier is required for OCI packages")
 	ErrMissingVersionForOCI    = errors.New("package version is required for OCI packages")
+	ErrMutableOCITag           = errors.New("mutable OCI tag")
 )
 
+// mutableOCITags are conventional tags that registries move to newer images, so they do not identify a fixed image
+var mutableOCITags = []string{"latest", "main", "master", "edge", "nightly", "dev", "stable"}
+
 const (
 	dockerIoAPIBaseURL = "https://registry-1.docker.io"
 	ghcrAPIBaseURL     = "https://ghcr.io"
@@ -78,8 +87,55 @@ type OCIImageConfig struct {
 	} `json:"config"`
 }
 
+// OCIOptions configures optional OCI validation behavior
+type OCIOptions struct {
+	// StrictDigestBinding requires every platform manifest of the tag to carry the annotation and the
+	// tag to still resolve to the validated manifest digest once validation completes
+	StrictDigestBinding bool
+	// HTTPClient is used for registry requests; nil uses a client with a 10 second timeout that refuses TLS
+	// versions below MinTLSVersion
+	HTTPClient *http.Client
+	// MinTLSVersion is the lowest TLS version negotiated with registries when HTTPClient is nil; zero uses TLS 1.2
+	MinTLSVersion uint16
+	// RetryAttempts is how many times a registry request is tried when it fails with a connection
+	// error or 5xx response; zero uses the default of 3
+	RetryAttempts int
+	// RetryBackoff is the delay before the first retry, doubling for each further retry; zero uses the default
+	RetryBackoff time.Duration
+	// UsePinnedDigest fetches the manifest by digest when the version pins one (e.g. 1.0.0@sha256:...), so a
+	// tag moved between validation and use cannot change what was validated
+	UsePinnedDigest bool
+	// RejectMutableTags rejects versions that are a mutable tag such as "latest" unless they pin a digest
+	// (e.g. latest@sha256:...), in which case the manifest is fetched by that digest
+	RejectMutableTags bool
+	// UserAgent is sent on every registry request, including token requests; empty uses DefaultUserAgent
+	UserAgent string
+}
+
+// digestRegex matches a sha256 content digest as used by OCI registries
+var digestRegex = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// splitPinnedDigest splits a version of the form "tag@digest" into its tag and digest. Versions without a
+// digest are returned unchanged with an empty digest.
+func splitPinnedDigest(version string) (string, string, error) {
+	tag, digest, found := strings.Cut(version, "@")
+	if !found {
+		return version, "", nil
+	}
+	if !digestRegex.MatchString(digest) {
+		return "", "", fmt.Errorf("invalid OCI digest '%s': expected sha256: followed by 64 lowercase hex characters", digest)
+	}
+	return tag, digest, nil
+}
+
 // ValidateOCI validates that an OCI image contains the correct MCP server name annotation
 func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) error {
+	return ValidateOCIWithOptions(ctx, pkg, serverName, OCIOptions{})
+}
+
+// ValidateOCIWithOptions validates that an OCI image contains the correct MCP server name annotation,
+// applying the given options
+func ValidateOCIWithOptions(ctx context.Context, pkg model.Package, serverName string, opts OCIOptions) error {
 	// Set default registry base URL if empty
 	if pkg.RegistryBaseURL == "" {
 		pkg.RegistryBaseURL = model.RegistryURLDocker
@@ -99,13 +155,18 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 		return err
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second, Transport: outboundTransport(opts.MinTLSVersion)}
+	}
+	client = withUserAgent(newRetryClient(client, opts.RetryAttempts, opts.RetryBackoff), opts.UserAgent)
 
 	// Parse image reference (namespace/repo or repo)
-	namespace, repo, err := parseImageReference(pkg.Identifier)
+	imageRef, err := parseImageReference(pkg.RegistryBaseURL, pkg.Identifier)
 	if err != nil {
 		return fmt.Errorf("invalid OCI image reference: %w", err)
 	}
+	namespace, repo := imageRef.Namespace, imageRef.Repo
 
 	// Get registry configuration
 	registryConfig := getRegistryConfig(pkg.RegistryBaseURL, namespace, repo)
@@ -113,8 +174,36 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 		return fmt.Errorf("unsupported registry: %s", pkg.RegistryBaseURL)
 	}
 
+	// Resolve the manifest by the pinned digest when there is one, since unlike the tag it cannot be moved
+	tag, pinnedDigest, err := splitPinnedDigest(pkg.Version)
+	if err != nil {
+		return err
+	}
+	if imageRef.Tag != "" && imageRef.Tag != tag {
+		return fmt.Errorf("OCI image reference '%s' has tag '%s', which does not match the package version '%s'", pkg.Identifier, imageRef.Tag, pkg.Version)
+	}
+	// A digest in the identifier always selects the manifest, as the publisher asked for that exact image
+	identifierDigest := imageRef.Digest != ""
+	if identifierDigest {
+		if pinnedDigest != "" && pinnedDigest != imageRef.Digest {
+			return fmt.Errorf("OCI image reference '%s' has digest %s, which does not match the digest %s pinned by the package version", pkg.Identifier, imageRef.Digest, pinnedDigest)
+		}
+		pinnedDigest = imageRef.Digest
+	}
+	if opts.RejectMutableTags && pinnedDigest == "" && slices.Contains(mutableOCITags, strings.ToLower(tag)) {
+		return fmt.Errorf("%w '%s' for OCI image '%s': use an immutable tag such as a release version, or pin a digest (e.g. %s@sha256:...)", ErrMutableOCITag, tag, pkg.Identifier, tag)
+	}
+	byDigest := identifierDigest || (pinnedDigest != "" && (opts.UsePinnedDigest || opts.RejectMutableTags))
+	reference := pkg.Version
+	if pinnedDigest != "" {
+		reference = tag
+		if byDigest {
+			reference = pinnedDigest
+		}
+	}
+
 	// Get the image manifest
-	manifest, err := fetchImageManifest(ctx, client, registryConfig, namespace, repo, pkg.Version)
+	manifest, manifestDigest, err := fetchImageManifest(ctx, client, registryConfig, namespace, repo, reference)
 	if err != nil {
 		// Handle rate limiting explicitly - skip validation
 		if errors.Is(err, ErrRateLimited) {
@@ -123,15 +212,75 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 		}
 		return err
 	}
+	if byDigest && manifestDigest != pinnedDigest {
+		return fmt.Errorf("OCI image '%s/%s@%s' resolved to a different digest %s", namespace, repo, pinnedDigest, manifestDigest)
+	}
+
+	// Each further stage makes more registry requests, so stop between them once the publish is cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if opts.StrictDigestBinding {
+		return validateBoundManifest(ctx, client, registryConfig, namespace, repo, reference, manifest, manifestDigest, serverName)
+	}
 
 	// Get config digest from manifest
 	configDigest, err := getConfigDigestFromManifest(ctx, client, registryConfig, namespace, repo, manifest)
 	if err != nil {
 		return err
 	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	// Validate server name annotation
-	return validateServerNameAnnotation(ctx, client, registryConfig, namespace, repo, pkg.Version, configDigest, serverName)
+	return validateServerNameAnnotation(ctx, client, registryConfig, namespace, repo, reference, configDigest, serverName)
+}
+
+// validateBoundManifest validates the annotation on every platform manifest the tag resolves to, then checks the
+// tag still resolves to the same manifest digest. This binds the identifier, version and digest to the verified
+// annotation, so the tag cannot be moved to a different image between validation and storage.
+func validateBoundManifest(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, namespace, repo, tag string, manifest *OCIManifest, manifestDigest, serverName string) error {
+	configDigests := []string{manifest.Config.Digest}
+	if len(manifest.Manifests) > 0 {
+		configDigests = configDigests[:0]
+		for _, platformManifest := range manifest.Manifests {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			specificManifest, err := getSpecificManifest(ctx, client, registryConfig, namespace, repo, platformManifest.Digest)
+			if err != nil {
+				return fmt.Errorf("failed to get specific manifest: %w", err)
+			}
+			configDigests = append(configDigests, specificManifest.Config.Digest)
+		}
+	}
+
+	for _, configDigest := range configDigests {
+		if configDigest == "" {
+			return fmt.Errorf("manifest missing config digest - invalid or corrupted manifest")
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := validateServerNameAnnotation(ctx, client, registryConfig, namespace, repo, tag, configDigest, serverName); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	_, currentDigest, err := fetchImageManifest(ctx, client, registryConfig, namespace, repo, tag)
+	if err != nil {
+		return fmt.Errorf("failed to re-resolve OCI image '%s/%s:%s': %w", namespace, repo, tag, err)
+	}
+	if currentDigest != manifestDigest {
+		return fmt.Errorf("OCI image '%s/%s:%s' changed during validation (digest %s became %s)", namespace, repo, tag, manifestDigest, currentDigest)
+	}
+
+	return nil
 }
 
 // validateRegistryURL validates that the registry base URL is supported
@@ -143,50 +292,67 @@ func validateRegistryURL(registryURL string) error {
 	return nil
 }
 
-// fetchImageManifest fetches the OCI manifest for an image
-func fetchImageManifest(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, namespace, repo, tag string) (*OCIManifest, error) {
+// fetchImageManifest fetches the OCI manifest for an image, along with the digest of the manifest
+func fetchImageManifest(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, namespace, repo, tag string) (*OCIManifest, string, error) {
 	manifestURL := fmt.Sprintf("%s/v2/%s/%s/manifests/%s", registryConfig.APIBaseURL, namespace, repo, tag)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create manifest request: %w", err)
+		return nil, "", fmt.Errorf("failed to create manifest request: %w", err)
 	}
 
 	// Get auth token if registry requires it
 	if registryConfig.AuthURL != "" {
 		token, err := getRegistryAuthToken(ctx, client, registryConfig)
 		if err != nil {
-			return nil, fmt.Errorf("failed to authenticate with registry: %w", err)
+			return nil, "", fmt.Errorf("failed to authenticate with registry: %w", err)
 		}
 		req.Header.Set("Authorization", "Bearer "+token)
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
 	}
 
 	req.Header.Set("Accept", "application/vnd.oci.image.index.v1+json,application/vnd.docker.distribution.manifest.list.v2+json,application/vnd.docker.distribution.manifest.v2+json,application/vnd.oci.image.manifest.v1+json")
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch OCI manifest: %w", err)
+		return nil, "", fmt.Errorf("failed to fetch OCI manifest: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnauthorized {
-		return nil, fmt.Errorf("OCI image '%s/%s:%s' not found (status: %d)", namespace, repo, tag, resp.StatusCode)
+		return nil, "", fmt.Errorf("OCI image '%s/%s:%s' not found (status: %d)", namespace, repo, tag, resp.StatusCode)
 	}
 	if resp.StatusCode == http.StatusTooManyRequests {
 		// Rate limited, return explicit error
 		log.Printf("Rate limited when accessing OCI image '%s/%s:%s'", namespace, repo, tag)
-		return nil, fmt.Errorf("%w: %s/%s:%s", ErrRateLimited, namespace, repo, tag)
+		return nil, "", fmt.Errorf("%w: %s/%s:%s", ErrRateLimited, namespace, repo, tag)
+	}
+	if err := serverError("OCI registry", resp); err != nil {
+		return nil, "", err
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch OCI manifest (status: %d)", resp.StatusCode)
+		return nil, "", fmt.Errorf("failed to fetch OCI manifest (status: %d)", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read OCI manifest: %w", err)
 	}
 
 	var manifest OCIManifest
-	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
-		return nil, fmt.Errorf("failed to parse OCI manifest: %w", err)
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, "", fmt.Errorf("failed to parse OCI manifest: %w", err)
 	}
 
-	return &manifest, nil
+	// Prefer the digest reported by the registry, falling back to hashing the manifest ourselves
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		sum := sha256.Sum256(body)
+		digest = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	return &manifest, digest, nil
 }
 
 // getConfigDigestFromManifest extracts the config digest from an OCI manifest
@@ -229,16 +395,57 @@ func validateServerNameAnnotation(ctx context.Context, client *http.Client, regi
 	return nil
 }
 
-func parseImageReference(identifier string) (string, string, error) {
-	parts := strings.Split(identifier, "/")
+// imageReference is an image identifier split into its parts. Tag and Digest are empty unless the
+// identifier carries them (e.g. owner/repo:1.0.0 or owner/repo@sha256:...).
+type imageReference struct {
+	Namespace string
+	Repo      string
+	Tag       string
+	Digest    string
+}
+
+// tagRegex matches a valid OCI tag
+var tagRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]{0,127}$`)
+
+// parseImageReference splits an image identifier into namespace, repository, and any tag or digest for
+// the given registry. Docker Hub treats a bare repository name as an official image in the "library"
+// namespace, whereas GHCR images always live under an owner, so a missing namespace is rejected there.
+func parseImageReference(registryBaseURL, identifier string) (imageReference, error) {
+	var ref imageReference
+	name := identifier
+	if before, digest, found := strings.Cut(name, "@"); found {
+		if !digestRegex.MatchString(digest) {
+			return imageReference{}, fmt.Errorf("invalid digest in image reference '%s': expected sha256: followed by 64 lowercase hex characters", identifier)
+		}
+		name, ref.Digest = before, digest
+	}
+	// A tag can only follow the last path segment; a colon earlier would be a registry host port
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		if !tagRegex.MatchString(name[i+1:]) {
+			return imageReference{}, fmt.Errorf("invalid tag in image reference: %s", identifier)
+		}
+		name, ref.Tag = name[:i], name[i+1:]
+	}
+
+	parts := strings.Split(name, "/")
+	for _, part := range parts {
+		if part == "" {
+			return imageReference{}, fmt.Errorf("invalid image reference: %s", identifier)
+		}
+	}
+
 	switch len(parts) {
 	case 2:
-		return parts[0], parts[1], nil
+		ref.Namespace, ref.Repo = parts[0], parts[1]
 	case 1:
-		return "library", parts[0], nil
+		if registryBaseURL == model.RegistryURLGHCR {
+			return imageReference{}, fmt.Errorf("image reference '%s' must include an owner namespace for %s (e.g. owner/%s)", identifier, registryBaseURL, identifier)
+		}
+		ref.Namespace, ref.Repo = "library", parts[0]
 	default:
-		return "", "", fmt.Errorf("invalid image reference: %s", identifier)
+		return imageReference{}, fmt.Errorf("invalid image reference: %s", identifier)
 	}
+	return ref, nil
 }
 
 // getRegistryAuthToken retrieves an authentication token from a registry
@@ -260,6 +467,9 @@ func getRegistryAuthToken(ctx context.Context, client *http.Client, config *Regi
 	}
 	defer resp.Body.Close()
 
+	if err := serverError("OCI registry", resp); err != nil {
+		return "", err
+	}
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("auth request failed with status %d", resp.StatusCode)
 	}
@@ -287,10 +497,12 @@ func getSpecificManifest(ctx context.Context, client *http.Client, registryConfi
 			return nil, fmt.Errorf("failed to authenticate with registry: %w", err)
 		}
 		req.Header.Set("Authorization", "Bearer "+token)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 	}
 
 	req.Header.Set("Accept", "application/vnd.oci.image.manifest.v1+json")
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -298,6 +510,9 @@ func getSpecificManifest(ctx context.Context, client *http.Client, registryConfi
 	}
 	defer resp.Body.Close()
 
+	if err := serverError("OCI registry", resp); err != nil {
+		return nil, err
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("specific manifest not found (status: %d)", resp.StatusCode)
 	}
@@ -325,10 +540,12 @@ func getImageConfig(ctx context.Context, client *http.Client, registryConfig *Re
 			return nil, fmt.Errorf("failed to authenticate with registry: %w", err)
 		}
 		req.Header.Set("Authorization", "Bearer "+token)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 	}
 
 	req.Header.Set("Accept", "application/vnd.docker.distribution.manifest.v2+json")
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -336,6 +553,9 @@ func getImageConfig(ctx context.Context, client *http.Client, registryConfig *Re
 	}
 	defer resp.Body.Close()
 
+	if err := serverError("OCI registry", resp); err != nil {
+		return nil, err
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("image config not found (status: %d)", resp.StatusCode)
 	}
@@ -0,0 +1,427 @@
+package registries_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// redirectTransport sends every request to the test server, regardless of the registry host
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// fakeOCIRegistry serves a multi-arch image whose platform configs carry the given annotations
+type fakeOCIRegistry struct {
+	annotations []string
+	// tagDigests is the sequence of digests the tag resolves to; the last one repeats
+	tagDigests    []string
+	manifestCalls atomic.Int32
+}
+
+func (f *fakeOCIRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writeJSON := func(v any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	switch r.URL.Path {
+	case "/token":
+		writeJSON(map[string]string{"token": "test-token"})
+	case "/v2/example/image/manifests/1.0.0":
+		call := int(f.manifestCalls.Add(1)) - 1
+		w.Header().Set("Docker-Content-Digest", f.tagDigests[min(call, len(f.tagDigests)-1)])
+		manifests := make([]map[string]string, len(f.annotations))
+		for i := range f.annotations {
+			manifests[i] = map[string]string{"digest": "sha256:platform" + string(rune('a'+i))}
+		}
+		writeJSON(map[string]any{"manifests": manifests})
+	default:
+		for i, annotation := range f.annotations {
+			suffix := string(rune('a' + i))
+			switch r.URL.Path {
+			case "/v2/example/image/manifests/sha256:platform" + suffix:
+				writeJSON(map[string]any{"config": map[string]string{"digest": "sha256:config" + suffix}})
+				return
+			case "/v2/example/image/blobs/sha256:config" + suffix:
+				writeJSON(map[string]any{"config": map[string]any{"Labels": map[string]string{
+					"io.modelcontextprotocol.server.name": annotation,
+				}}})
+				return
+			}
+		}
+		http.NotFound(w, r)
+	}
+}
+
+func TestValidateOCI_StrictDigestBinding(t *testing.T) {
+	const serverName = "io.github.example/image"
+
+	tests := []struct {
+		name        string
+		annotations []string
+		tagDigests  []string
+		strict      bool
+		expectError string
+	}{
+		{
+			name:        "all platforms annotated and tag stable",
+			annotations: []string{serverName, serverName},
+			tagDigests:  []string{"sha256:index1"},
+			strict:      true,
+		},
+		{
+			name:        "platform with mismatched annotation passes without strict binding",
+			annotations: []string{serverName, "io.github.other/image"},
+			tagDigests:  []string{"sha256:index1"},
+			strict:      false,
+		},
+		{
+			name:        "platform with mismatched annotation fails with strict binding",
+			annotations: []string{serverName, "io.github.other/image"},
+			tagDigests:  []string{"sha256:index1"},
+			strict:      true,
+			expectError: "got 'io.github.other/image'",
+		},
+		{
+			name:        "tag moved to a different image during validation",
+			annotations: []string{serverName},
+			tagDigests:  []string{"sha256:index1", "sha256:index2"},
+			strict:      true,
+			expectError: "changed during validation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(&fakeOCIRegistry{annotations: tt.annotations, tagDigests: tt.tagDigests})
+			defer server.Close()
+			target, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeOCI,
+				RegistryBaseURL: model.RegistryURLGHCR,
+				Identifier:      "example/image",
+				Version:         "1.0.0",
+			}
+			err = registries.ValidateOCIWithOptions(context.Background(), pkg, serverName, registries.OCIOptions{
+				StrictDigestBinding: tt.strict,
+				HTTPClient:          &http.Client{Transport: &redirectTransport{target: target}},
+			})
+
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// movedTagRegistry serves a tag that was moved to an image annotated for another server, while the pinned digest
+// still resolves to the original, correctly annotated image
+type movedTagRegistry struct {
+	pinnedDigest string
+}
+
+func (f *movedTagRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writeJSON := func(v any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	label := func(name string) map[string]any {
+		return map[string]any{"config": map[string]any{"Labels": map[string]string{"io.modelcontextprotocol.server.name": name}}}
+	}
+
+	switch r.URL.Path {
+	case "/token":
+		writeJSON(map[string]string{"token": "test-token"})
+	case "/v2/example/image/manifests/" + f.pinnedDigest:
+		w.Header().Set("Docker-Content-Digest", f.pinnedDigest)
+		writeJSON(map[string]any{"config": map[string]string{"digest": "sha256:original"}})
+	case "/v2/example/image/manifests/1.0.0":
+		w.Header().Set("Docker-Content-Digest", "sha256:"+strings.Repeat("b", 64))
+		writeJSON(map[string]any{"config": map[string]string{"digest": "sha256:moved"}})
+	case "/v2/example/image/blobs/sha256:original":
+		writeJSON(label("io.github.example/image"))
+	case "/v2/example/image/blobs/sha256:moved":
+		writeJSON(label("io.github.attacker/image"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestValidateOCI_PinnedDigest(t *testing.T) {
+	const serverName = "io.github.example/image"
+	pinnedDigest := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		name        string
+		version     string
+		usePinned   bool
+		expectError string
+	}{
+		{
+			name:      "validates by pinned digest even though the tag moved",
+			version:   "1.0.0@" + pinnedDigest,
+			usePinned: true,
+		},
+		{
+			name:        "validates by tag when pinned digests are not used",
+			version:     "1.0.0@" + pinnedDigest,
+			usePinned:   false,
+			expectError: "got 'io.github.attacker/image'",
+		},
+		{
+			name:        "moved tag without a digest fails",
+			version:     "1.0.0",
+			usePinned:   true,
+			expectError: "got 'io.github.attacker/image'",
+		},
+		{
+			name:        "unknown pinned digest fails",
+			version:     "1.0.0@sha256:" + strings.Repeat("c", 64),
+			usePinned:   true,
+			expectError: "not found",
+		},
+		{
+			name:        "malformed digest",
+			version:     "1.0.0@sha256:abc",
+			usePinned:   true,
+			expectError: "invalid OCI digest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(&movedTagRegistry{pinnedDigest: pinnedDigest})
+			defer server.Close()
+			target, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeOCI,
+				RegistryBaseURL: model.RegistryURLGHCR,
+				Identifier:      "example/image",
+				Version:         tt.version,
+			}
+			err = registries.ValidateOCIWithOptions(context.Background(), pkg, serverName, registries.OCIOptions{
+				UsePinnedDigest: tt.usePinned,
+				HTTPClient:      &http.Client{Transport: &redirectTransport{target: target}},
+			})
+
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateOCI_RejectMutableTags(t *testing.T) {
+	const serverName = "io.github.example/image"
+	pinnedDigest := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		name         string
+		version      string
+		rejectTags   bool
+		expectError  string
+		expectMutErr bool
+	}{
+		{
+			name:         "latest is rejected",
+			version:      "latest",
+			rejectTags:   true,
+			expectError:  "pin a digest",
+			expectMutErr: true,
+		},
+		{
+			name:         "other mutable tags are rejected regardless of case",
+			version:      "Nightly",
+			rejectTags:   true,
+			expectMutErr: true,
+		},
+		{
+			name:       "latest with a pinned digest is validated by the digest",
+			version:    "latest@" + pinnedDigest,
+			rejectTags: true,
+		},
+		{
+			name:        "latest is not rejected when the rule is disabled",
+			version:     "latest",
+			rejectTags:  false,
+			expectError: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(&movedTagRegistry{pinnedDigest: pinnedDigest})
+			defer server.Close()
+			target, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeOCI,
+				RegistryBaseURL: model.RegistryURLGHCR,
+				Identifier:      "example/image",
+				Version:         tt.version,
+			}
+			err = registries.ValidateOCIWithOptions(context.Background(), pkg, serverName, registries.OCIOptions{
+				RejectMutableTags: tt.rejectTags,
+				HTTPClient:        &http.Client{Transport: &redirectTransport{target: target}},
+			})
+
+			switch {
+			case tt.expectMutErr:
+				require.ErrorIs(t, err, registries.ErrMutableOCITag)
+				assert.Contains(t, err.Error(), tt.expectError)
+			case tt.expectError != "":
+				require.Error(t, err)
+				assert.NotErrorIs(t, err, registries.ErrMutableOCITag)
+				assert.Contains(t, err.Error(), tt.expectError)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateOCI_IdentifierDigest(t *testing.T) {
+	const serverName = "io.github.example/image"
+	pinnedDigest := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		name        string
+		identifier  string
+		version     string
+		expectError string
+	}{
+		{
+			name:       "validates by the identifier digest even though the tag moved",
+			identifier: "example/image@" + pinnedDigest,
+			version:    "1.0.0",
+		},
+		{
+			name:        "unknown identifier digest fails",
+			identifier:  "example/image@sha256:" + strings.Repeat("c", 64),
+			version:     "1.0.0",
+			expectError: "not found",
+		},
+		{
+			name:        "identifier digest must match the version digest",
+			identifier:  "example/image@" + pinnedDigest,
+			version:     "1.0.0@sha256:" + strings.Repeat("c", 64),
+			expectError: "does not match the digest",
+		},
+		{
+			name:        "identifier tag must match the version",
+			identifier:  "example/image:2.0.0",
+			version:     "1.0.0",
+			expectError: "does not match the package version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(&movedTagRegistry{pinnedDigest: pinnedDigest})
+			defer server.Close()
+			target, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeOCI,
+				RegistryBaseURL: model.RegistryURLGHCR,
+				Identifier:      tt.identifier,
+				Version:         tt.version,
+			}
+			err = registries.ValidateOCIWithOptions(context.Background(), pkg, serverName, registries.OCIOptions{
+				HTTPClient: &http.Client{Transport: &redirectTransport{target: target}},
+			})
+
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// userAgentCapture records the User-Agent of each request before passing it on
+type userAgentCapture struct {
+	base       http.RoundTripper
+	mu         sync.Mutex
+	userAgents []string
+}
+
+func (c *userAgentCapture) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.userAgents = append(c.userAgents, req.Header.Get("User-Agent"))
+	c.mu.Unlock()
+	return c.base.RoundTrip(req)
+}
+
+func TestValidateOCI_UserAgent(t *testing.T) {
+	const serverName = "io.github.example/image"
+	server := httptest.NewServer(&fakeOCIRegistry{annotations: []string{serverName}, tagDigests: []string{"sha256:index1"}})
+	defer server.Close()
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	pkg := model.Package{
+		RegistryType:    model.RegistryTypeOCI,
+		RegistryBaseURL: model.RegistryURLGHCR,
+		Identifier:      "example/image",
+		Version:         "1.0.0",
+	}
+
+	for _, tt := range []struct {
+		name      string
+		userAgent string
+		expected  string
+	}{
+		{name: "default", expected: registries.DefaultUserAgent},
+		{name: "configured", userAgent: "example-mirror/2.0 (+https://example.com/contact)", expected: "example-mirror/2.0 (+https://example.com/contact)"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			capture := &userAgentCapture{base: &redirectTransport{target: target}}
+			err := registries.ValidateOCIWithOptions(context.Background(), pkg, serverName, registries.OCIOptions{
+				HTTPClient: &http.Client{Transport: capture},
+				UserAgent:  tt.userAgent,
+			})
+			require.NoError(t, err)
+
+			// Token, manifest and config requests all identify the registry
+			require.NotEmpty(t, capture.userAgents)
+			for _, userAgent := range capture.userAgents {
+				assert.Equal(t, tt.expected, userAgent)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+//nolint:testpackage
+package registries
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		name              string
+		registryBaseURL   string
+		identifier        string
+		expectedNamespace string
+		expectedRepo      string
+		expectedTag       string
+		expectedDigest    string
+		expectError       string
+	}{
+		{
+			name:              "docker hub official image defaults to library",
+			registryBaseURL:   model.RegistryURLDocker,
+			identifier:        "redis",
+			expectedNamespace: "library",
+			expectedRepo:      "redis",
+		},
+		{
+			name:              "docker hub user image",
+			registryBaseURL:   model.RegistryURLDocker,
+			identifier:        "domdomegg/airtable-mcp-server",
+			expectedNamespace: "domdomegg",
+			expectedRepo:      "airtable-mcp-server",
+		},
+		{
+			name:              "ghcr owner image",
+			registryBaseURL:   model.RegistryURLGHCR,
+			identifier:        "modelcontextprotocol/registry",
+			expectedNamespace: "modelcontextprotocol",
+			expectedRepo:      "registry",
+		},
+		{
+			name:              "docker hub official image with namespace",
+			registryBaseURL:   model.RegistryURLDocker,
+			identifier:        "library/redis",
+			expectedNamespace: "library",
+			expectedRepo:      "redis",
+		},
+		{
+			name:              "image with tag",
+			registryBaseURL:   model.RegistryURLDocker,
+			identifier:        "user/app:1.2.3",
+			expectedNamespace: "user",
+			expectedRepo:      "app",
+			expectedTag:       "1.2.3",
+		},
+		{
+			name:              "image with digest",
+			registryBaseURL:   model.RegistryURLGHCR,
+			identifier:        "user/app@sha256:" + strings.Repeat("a", 64),
+			expectedNamespace: "user",
+			expectedRepo:      "app",
+			expectedDigest:    "sha256:" + strings.Repeat("a", 64),
+		},
+		{
+			name:              "image with tag and digest",
+			registryBaseURL:   model.RegistryURLDocker,
+			identifier:        "redis:7@sha256:" + strings.Repeat("b", 64),
+			expectedNamespace: "library",
+			expectedRepo:      "redis",
+			expectedTag:       "7",
+			expectedDigest:    "sha256:" + strings.Repeat("b", 64),
+		},
+		{
+			name:            "malformed digest is rejected",
+			registryBaseURL: model.RegistryURLDocker,
+			identifier:      "user/app@sha256:abc",
+			expectError:     "invalid digest",
+		},
+		{
+			name:            "empty tag is rejected",
+			registryBaseURL: model.RegistryURLDocker,
+			identifier:      "user/app:",
+			expectError:     "invalid tag",
+		},
+		{
+			name:            "multi-slash reference with a registry port is rejected",
+			registryBaseURL: model.RegistryURLDocker,
+			identifier:      "localhost:5000/user/app:1.0.0",
+			expectError:     "invalid image reference",
+		},
+		{
+			name:            "ghcr image without owner is rejected",
+			registryBaseURL: model.RegistryURLGHCR,
+			identifier:      "registry",
+			expectError:     "must include an owner namespace",
+		},
+		{
+			name:            "empty namespace segment is rejected",
+			registryBaseURL: model.RegistryURLDocker,
+			identifier:      "/redis",
+			expectError:     "invalid image reference",
+		},
+		{
+			name:            "too many segments are rejected",
+			registryBaseURL: model.RegistryURLDocker,
+			identifier:      "a/b/c",
+			expectError:     "invalid image reference",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := parseImageReference(tt.registryBaseURL, tt.identifier)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedNamespace, ref.Namespace)
+			assert.Equal(t, tt.expectedRepo, ref.Repo)
+			assert.Equal(t, tt.expectedTag, ref.Tag)
+			assert.Equal(t, tt.expectedDigest, ref.Digest)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package registries_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// flakyTransport fails the first failures requests for manifestPath, then passes requests through
+type flakyTransport struct {
+	base          http.RoundTripper
+	manifestPath  string
+	failures      int32
+	failWith      func() (*http.Response, error)
+	manifestCalls atomic.Int32
+}
+
+func (t *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == t.manifestPath {
+		if t.manifestCalls.Add(1) <= t.failures {
+			return t.failWith()
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+func statusResponse(status int) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Header:     http.Header{},
+		}, nil
+	}
+}
+
+func TestValidateOCI_RetriesTransientFailures(t *testing.T) {
+	const serverName = "io.github.example/image"
+
+	tests := []struct {
+		name              string
+		failures          int32
+		failWith          func() (*http.Response, error)
+		expectError       string
+		expectUnavailable bool
+		expectedCalls     int32
+	}{
+		{
+			name:          "succeeds after two server errors",
+			failures:      2,
+			failWith:      statusResponse(http.StatusServiceUnavailable),
+			expectedCalls: 3,
+		},
+		{
+			name:     "succeeds after two connection errors",
+			failures: 2,
+			failWith: func() (*http.Response, error) {
+				return nil, errors.New("connection reset by peer")
+			},
+			expectedCalls: 3,
+		},
+		{
+			name:              "gives up after the configured attempts",
+			failures:          10,
+			failWith:          statusResponse(http.StatusBadGateway),
+			expectError:       "status: 502",
+			expectUnavailable: true,
+			expectedCalls:     3,
+		},
+		{
+			name:          "not found is not retried",
+			failures:      10,
+			failWith:      statusResponse(http.StatusNotFound),
+			expectError:   "not found",
+			expectedCalls: 1,
+		},
+		{
+			name:          "unauthorized is not retried",
+			failures:      10,
+			failWith:      statusResponse(http.StatusUnauthorized),
+			expectError:   "status: 401",
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(&fakeOCIRegistry{annotations: []string{serverName}, tagDigests: []string{"sha256:index1"}})
+			defer server.Close()
+			target, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			transport := &flakyTransport{
+				base:         &redirectTransport{target: target},
+				manifestPath: "/v2/example/image/manifests/1.0.0",
+				failures:     tt.failures,
+				failWith:     tt.failWith,
+			}
+
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeOCI,
+				RegistryBaseURL: model.RegistryURLGHCR,
+				Identifier:      "example/image",
+				Version:         "1.0.0",
+			}
+			err = registries.ValidateOCIWithOptions(context.Background(), pkg, serverName, registries.OCIOptions{
+				HTTPClient:    &http.Client{Transport: transport},
+				RetryAttempts: 3,
+				RetryBackoff:  time.Millisecond,
+			})
+
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				assert.Equal(t, tt.expectUnavailable, errors.Is(err, registries.ErrRegistryUnavailable))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCalls, transport.manifestCalls.Load())
+		})
+	}
+}
+
+func TestValidateOCI_RetryStopsOnCancellation(t *testing.T) {
+	server := httptest.NewServer(&fakeOCIRegistry{annotations: []string{"io.github.example/image"}, tagDigests: []string{"sha256:index1"}})
+	defer server.Close()
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	transport := &flakyTransport{
+		base:         &redirectTransport{target: target},
+		manifestPath: "/v2/example/image/manifests/1.0.0",
+		failures:     10,
+		failWith:     statusResponse(http.StatusServiceUnavailable),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	pkg := model.Package{
+		RegistryType:    model.RegistryTypeOCI,
+		RegistryBaseURL: model.RegistryURLGHCR,
+		Identifier:      "example/image",
+		Version:         "1.0.0",
+	}
+	start := time.Now()
+	err = registries.ValidateOCIWithOptions(ctx, pkg, "io.github.example/image", registries.OCIOptions{
+		HTTPClient:    &http.Client{Transport: transport},
+		RetryAttempts: 5,
+		RetryBackoff:  time.Hour,
+	})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, int32(1), transport.manifestCalls.Load())
+}
+
+// cancelAfterTransport cancels the validation's context once the tag's manifest has been fetched, recording
+// every request made
+type cancelAfterTransport struct {
+	base         http.RoundTripper
+	manifestPath string
+	cancel       context.CancelFunc
+	paths        []string
+}
+
+func (t *cancelAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.paths = append(t.paths, req.URL.Path)
+	resp, err := t.base.RoundTrip(req)
+	if req.URL.Path == t.manifestPath {
+		t.cancel()
+	}
+	return resp, err
+}
+
+func TestValidateOCI_StopsWhenCancelled(t *testing.T) {
+	const serverName = "io.github.example/image"
+
+	for _, strict := range []bool{false, true} {
+		t.Run(fmt.Sprintf("strict binding %t", strict), func(t *testing.T) {
+			server := httptest.NewServer(&fakeOCIRegistry{annotations: []string{serverName, serverName}, tagDigests: []string{"sha256:index1"}})
+			defer server.Close()
+			target, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			transport := &cancelAfterTransport{
+				base:         &redirectTransport{target: target},
+				manifestPath: "/v2/example/image/manifests/1.0.0",
+				cancel:       cancel,
+			}
+
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeOCI,
+				RegistryBaseURL: model.RegistryURLGHCR,
+				Identifier:      "example/image",
+				Version:         "1.0.0",
+			}
+			err = registries.ValidateOCIWithOptions(ctx, pkg, serverName, registries.OCIOptions{
+				HTTPClient:          &http.Client{Transport: transport},
+				StrictDigestBinding: strict,
+			})
+
+			require.ErrorIs(t, err, context.Canceled)
+			// Only the token and the tag's manifest were fetched, not the platform manifests or configs
+			assert.Equal(t, []string{"/token", "/v2/example/image/manifests/1.0.0"}, transport.paths)
+		})
+	}
+}
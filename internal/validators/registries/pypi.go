@@ -53,7 +53,7 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	req.Header.Set("User-Agent", DefaultUserAgent)
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := client.Do(req)
@@ -62,6 +62,9 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 	}
 	defer resp.Body.Close()
 
+	if err := serverError("PyPI", resp); err != nil {
+		return err
+	}
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("PyPI package '%s' not found (status: %d)", pkg.Identifier, resp.StatusCode)
 	}
@@ -0,0 +1,93 @@
+package registries
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// ErrRegistryUnavailable is returned when a registry responds with a server error, which says nothing about
+// the package being checked
+var ErrRegistryUnavailable = errors.New("registry unavailable")
+
+const (
+	// defaultRetryAttempts is used when OCIOptions does not set RetryAttempts
+	defaultRetryAttempts = 3
+	// defaultRetryBackoff is used when OCIOptions does not set RetryBackoff
+	defaultRetryBackoff = 500 * time.Millisecond
+)
+
+// retryTransport retries requests that fail with a connection error or a 5xx response, waiting an
+// exponentially growing, jittered backoff between attempts. Other responses, such as 401 and 404,
+// are returned straight away since retrying them would not change the outcome.
+type retryTransport struct {
+	base     http.RoundTripper
+	attempts int
+	backoff  time.Duration
+}
+
+// newRetryClient returns a copy of client whose requests are retried on transient failures
+func newRetryClient(client *http.Client, attempts int, backoff time.Duration) *http.Client {
+	if attempts <= 0 {
+		attempts = defaultRetryAttempts
+	}
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	retryClient := *client
+	retryClient.Transport = &retryTransport{base: base, attempts: attempts, backoff: backoff}
+	return &retryClient
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests with a body can't be safely replayed
+	if req.Body != nil && req.Body != http.NoBody {
+		return t.base.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.attempts || !isTransientFailure(req, resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		// Exponential backoff with up to 50% jitter, giving up early if the request is cancelled
+		delay := t.backoff << (attempt - 1)
+		delay += time.Duration(rand.Int64N(int64(delay)/2 + 1))
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isTransientFailure reports whether a request failed in a way that may succeed on retry
+func isTransientFailure(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// Connection errors are transient, unless the request itself was cancelled
+		return req.Context().Err() == nil
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// serverError returns an ErrRegistryUnavailable error if the registry responded with a 5xx, and nil otherwise
+func serverError(registryName string, resp *http.Response) error {
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: %s returned a server error (status: %d)", ErrRegistryUnavailable, registryName, resp.StatusCode)
+	}
+	return nil
+}
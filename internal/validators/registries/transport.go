@@ -0,0 +1,21 @@
+package registries
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// outboundTransports holds one transport per minimum TLS version, so registry connections are reused across
+// validations instead of being opened anew for each one
+var outboundTransports sync.Map
+
+// outboundTransport returns the shared transport refusing TLS versions below minTLSVersion
+func outboundTransport(minTLSVersion uint16) http.RoundTripper {
+	if transport, ok := outboundTransports.Load(minTLSVersion); ok {
+		return transport.(http.RoundTripper)
+	}
+	transport, _ := outboundTransports.LoadOrStore(minTLSVersion, config.NewOutboundTransport(minTLSVersion, nil))
+	return transport.(http.RoundTripper)
+}
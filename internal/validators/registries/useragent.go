@@ -0,0 +1,35 @@
+package registries
+
+import "net/http"
+
+// DefaultUserAgent is sent on package registry requests when no User-Agent is configured
+const DefaultUserAgent = "MCP-Registry-Validator/1.0"
+
+// userAgentTransport sets the User-Agent header on every request
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+// withUserAgent returns a copy of client that sends userAgent, or DefaultUserAgent if it is empty
+func withUserAgent(client *http.Client, userAgent string) *http.Client {
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	uaClient := *client
+	uaClient.Transport = &userAgentTransport{base: base, userAgent: userAgent}
+	return &uaClient
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Round trippers must not modify the caller's request
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
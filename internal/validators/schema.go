@@ -0,0 +1,80 @@
+package validators
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	jsonschema "github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// serverSchemaJSON is a copy of docs/reference/server-json/server.schema.json.
+// TestEmbeddedServerSchemaMatchesDocs keeps the two in sync.
+//
+//go:embed server.schema.json
+var serverSchemaJSON []byte
+
+const serverSchemaURL = "embedded://server.schema.json"
+
+// serverSchema is compiled once at startup so publishing does not pay the compilation cost per request
+var serverSchema = mustCompileServerSchema()
+
+func mustCompileServerSchema() *jsonschema.Schema {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+
+	if err := compiler.AddResource(serverSchemaURL, bytes.NewReader(serverSchemaJSON)); err != nil {
+		panic(fmt.Sprintf("failed to load embedded server.schema.json: %v", err))
+	}
+	schema, err := compiler.Compile(serverSchemaURL)
+	if err != nil {
+		panic(fmt.Sprintf("failed to compile embedded server.schema.json: %v", err))
+	}
+	return schema
+}
+
+// ValidateAgainstSchema validates the server JSON against the embedded server.schema.json, catching structural
+// issues that the hand-written checks do not cover. It is applied to both publishes and edits.
+//
+// The struct is re-marshaled for validation, so properties the schema does not define were already dropped when
+// the request was decoded and cannot be rejected here. The publish endpoint checks the request body as sent with
+// ValidateServerJSONDocument for that.
+func ValidateAgainstSchema(serverJSON *apiv0.ServerJSON) error {
+	data, err := json.Marshal(serverJSON)
+	if err != nil {
+		return fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+
+	// The repository is a value type, so an absent repository marshals as an empty object.
+	// Treat it as omitted, matching validateRepository.
+	if serverJSON.Repository.URL == "" && serverJSON.Repository.Source == "" {
+		delete(doc, "repository")
+	}
+
+	return validateSchemaDocument(doc)
+}
+
+// ValidateServerJSONDocument validates a raw server.json document against the embedded server.schema.json,
+// including properties the schema does not define, which are lost once the document is decoded into a struct
+func ValidateServerJSONDocument(data []byte) error {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%w: invalid JSON: %w", ErrSchemaValidation, err)
+	}
+	return validateSchemaDocument(doc)
+}
+
+// validateSchemaDocument validates a decoded JSON document against the embedded server.schema.json
+func validateSchemaDocument(doc any) error {
+	if err := serverSchema.Validate(doc); err != nil {
+		return fmt.Errorf("%w: %w", ErrSchemaValidation, err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package validators_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestEmbeddedServerSchemaMatchesDocs(t *testing.T) {
+	embedded, err := os.ReadFile("server.schema.json")
+	require.NoError(t, err)
+
+	docs, err := os.ReadFile(filepath.Join("..", "..", "docs", "reference", "server-json", "server.schema.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(docs), string(embedded), "internal/validators/server.schema.json must be a copy of docs/reference/server-json/server.schema.json")
+}
+
+func TestValidatePublishRequest_SchemaValidation(t *testing.T) {
+	validServer := func() apiv0.ServerJSON {
+		return apiv0.ServerJSON{
+			Name:        "com.example/test-server",
+			Description: "A test server",
+			Version:     "1.0.0",
+			Packages: []model.Package{
+				{
+					RegistryType: model.RegistryTypeNPM,
+					Identifier:   "test-server",
+					Version:      "1.0.0",
+					Transport:    model.Transport{Type: "stdio"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*apiv0.ServerJSON)
+	}{
+		{
+			name: "description longer than schema maximum",
+			modify: func(s *apiv0.ServerJSON) {
+				s.Description = strings.Repeat("a", 101)
+			},
+		},
+		{
+			name: "malformed file hash",
+			modify: func(s *apiv0.ServerJSON) {
+				s.Packages[0].FileSHA256 = "NOT-A-SHA256"
+			},
+		},
+		{
+			name: "positional argument without value or value hint",
+			modify: func(s *apiv0.ServerJSON) {
+				s.Packages[0].PackageArguments = []model.Argument{
+					{Type: model.ArgumentTypePositional},
+				}
+			},
+		},
+		{
+			name: "input format outside the allowed values",
+			modify: func(s *apiv0.ServerJSON) {
+				s.Packages[0].EnvironmentVariables = []model.KeyValueInput{
+					{
+						Name: "API_KEY",
+						InputWithVariables: model.InputWithVariables{
+							Input: model.Input{Format: "json"},
+						},
+					},
+				}
+			},
+		},
+	}
+
+	cfg := &config.Config{EnableRegistryValidation: false}
+
+	t.Run("valid server passes", func(t *testing.T) {
+		assert.NoError(t, validators.ValidatePublishRequest(context.Background(), validServer(), cfg))
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverJSON := validServer()
+			tt.modify(&serverJSON)
+
+			// The hand-written checks accept these payloads...
+			require.NoError(t, validators.ValidateServerJSON(&serverJSON))
+
+			// ...but the schema does not
+			err := validators.ValidatePublishRequest(context.Background(), serverJSON, cfg)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, validators.ErrSchemaValidation)
+		})
+	}
+}
+
+func TestValidateServerJSONDocument(t *testing.T) {
+	valid := `{
+		"name": "com.example/test-server",
+		"description": "A test server",
+		"version": "1.0.0",
+		"packages": [{"registryType": "npm", "identifier": "test-server", "version": "1.0.0", "transport": {"type": "stdio"}}]
+	}`
+	assert.NoError(t, validators.ValidateServerJSONDocument([]byte(valid)))
+
+	tests := []struct {
+		name     string
+		document string
+	}{
+		{
+			name: "additional package property",
+			document: `{
+				"name": "com.example/test-server",
+				"description": "A test server",
+				"version": "1.0.0",
+				"packages": [{"registryType": "npm", "identifier": "test-server", "version": "1.0.0", "transport": {"type": "stdio"}, "enviromentVariables": []}]
+			}`,
+		},
+		{
+			name: "additional maintainer property",
+			document: `{
+				"name": "com.example/test-server",
+				"description": "A test server",
+				"version": "1.0.0",
+				"maintainers": [{"name": "Jane", "email": "jane@example.com", "phone": "555-0100"}]
+			}`,
+		},
+		{name: "invalid JSON", document: `{"name": `},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, validators.ValidateServerJSONDocument([]byte(tt.document)), validators.ErrSchemaValidation)
+		})
+	}
+}
+
+func TestValidateAgainstSchema_Edit(t *testing.T) {
+	// Edits run the schema check directly, as they don't go through VerifyPublishRequest
+	serverJSON := apiv0.ServerJSON{
+		Name:        "com.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+		Packages: []model.Package{{
+			RegistryType:     model.RegistryTypeNPM,
+			Identifier:       "test-server",
+			Version:          "1.0.0",
+			Transport:        model.Transport{Type: "stdio"},
+			PackageArguments: []model.Argument{{Type: model.ArgumentTypePositional}},
+		}},
+	}
+	require.NoError(t, validators.ValidateServerJSON(&serverJSON))
+	assert.ErrorIs(t, validators.ValidateAgainstSchema(&serverJSON), validators.ErrSchemaValidation)
+}
@@ -0,0 +1,73 @@
+package validators
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+var (
+	// Prefixes of well-known credential formats, e.g. GitHub, GitLab, Slack, OpenAI and AWS keys
+	knownSecretRegex = regexp.MustCompile(`^(gh[pousr]_|github_pat_|glpat-|xox[abpr]-|sk-|sk_live_|AKIA)[A-Za-z0-9_-]{12,}`)
+
+	// Names of inputs that usually hold credentials
+	secretNameRegex = regexp.MustCompile(`(?i)(token|secret|password|passwd|api_?key|access_?key|private_?key|credential)`)
+
+	// Long opaque strings without whitespace or template variables, as generated keys tend to be
+	opaqueValueRegex = regexp.MustCompile(`^[A-Za-z0-9_\-+/=.]{20,}$`)
+)
+
+// SecretValueWarnings returns advisory warnings for package environment variables and arguments whose value or
+// default looks like a hardcoded secret. Anything published to the registry is public, so such values have most
+// likely leaked, but the heuristics can misfire, so these are warnings rather than errors.
+func SecretValueWarnings(serverJSON apiv0.ServerJSON) []string {
+	var warnings []string
+	for _, pkg := range serverJSON.Packages {
+		for _, env := range pkg.EnvironmentVariables {
+			warnings = appendSecretWarnings(warnings, pkg, "environment variable", env.Name, env.Input)
+		}
+		for _, arg := range pkg.RuntimeArguments {
+			warnings = appendSecretWarnings(warnings, pkg, "runtime argument", argumentLabel(arg), arg.Input)
+		}
+		for _, arg := range pkg.PackageArguments {
+			warnings = appendSecretWarnings(warnings, pkg, "package argument", argumentLabel(arg), arg.Input)
+		}
+	}
+	return warnings
+}
+
+// appendSecretWarnings appends a warning for each of the input's value and default that looks like a secret
+func appendSecretWarnings(warnings []string, pkg model.Package, kind, name string, input model.Input) []string {
+	fields := []struct{ field, value string }{{"value", input.Value}, {"default", input.Default}}
+	for _, f := range fields {
+		if looksLikeSecret(name, input, f.value) {
+			warnings = append(warnings, fmt.Sprintf(
+				"package %s %s %s has a %s that looks like a secret; published server.json files are public, so leave it for users to provide",
+				pkg.Identifier, kind, name, f.field,
+			))
+		}
+	}
+	return warnings
+}
+
+// looksLikeSecret reports whether a value looks like a hardcoded credential, given the name and input it is set on
+func looksLikeSecret(name string, input model.Input, value string) bool {
+	if value == "" || strings.Contains(value, "{") {
+		return false
+	}
+	if knownSecretRegex.MatchString(value) {
+		return true
+	}
+	return (input.IsSecret || secretNameRegex.MatchString(name)) && opaqueValueRegex.MatchString(value)
+}
+
+// argumentLabel names an argument in warnings, falling back to its value hint for positional arguments
+func argumentLabel(arg model.Argument) string {
+	if arg.Name != "" {
+		return arg.Name
+	}
+	return arg.ValueHint
+}
@@ -0,0 +1,41 @@
+package validators
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// TransportConsistencyWarnings returns advisory warnings when a server's remotes and packages declare transports
+// that have nothing in common, e.g. an sse remote alongside packages that only support stdio. Clients picking a
+// transport may be confused by such servers, but they are still valid, so these are warnings rather than errors.
+func TransportConsistencyWarnings(serverJSON apiv0.ServerJSON) []string {
+	if len(serverJSON.Packages) == 0 || len(serverJSON.Remotes) == 0 {
+		return nil
+	}
+
+	var packageTransports, remoteTransports []string
+	for _, pkg := range serverJSON.Packages {
+		if !slices.Contains(packageTransports, pkg.Transport.Type) {
+			packageTransports = append(packageTransports, pkg.Transport.Type)
+		}
+	}
+	for _, remote := range serverJSON.Remotes {
+		if !slices.Contains(remoteTransports, remote.Type) {
+			remoteTransports = append(remoteTransports, remote.Type)
+		}
+	}
+
+	for _, transport := range remoteTransports {
+		if slices.Contains(packageTransports, transport) {
+			return nil
+		}
+	}
+
+	return []string{fmt.Sprintf(
+		"remotes use the %s transport but packages only use %s; clients may not know which transport to pick",
+		strings.Join(remoteTransports, ", "), strings.Join(packageTransports, ", "),
+	)}
+}
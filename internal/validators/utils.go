@@ -59,6 +59,8 @@ func replaceTemplateVariables(rawURL string) string {
 	result := rawURL
 	for placeholder, replacement := range templateReplacements {
 		result = strings.ReplaceAll(result, placeholder, replacement)
+		// Environment variable names are uppercase, e.g. {PORT}
+		result = strings.ReplaceAll(result, strings.ToUpper(placeholder), replacement)
 	}
 
 	// Handle any remaining {variable} patterns with generic placeholder
@@ -147,6 +149,20 @@ func IsValidRemoteURL(rawURL string) bool {
 	return true
 }
 
+// NormalizeRemoteURL returns the form remote URLs are compared in to detect duplicates: the scheme and host
+// are case-insensitive and trailing slashes are ignored, so https://API.example.com/mcp/ and
+// https://api.example.com/mcp are the same remote. Paths and queries stay case-sensitive.
+func NormalizeRemoteURL(rawURL string) string {
+	trimmed := strings.TrimRight(rawURL, "/")
+	u, err := url.Parse(trimmed)
+	if err != nil || u.Host == "" {
+		return trimmed
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	return u.String()
+}
+
 // IsValidTemplatedURL validates a URL with template variables against available variables
 // For packages: validates that template variables reference package arguments or environment variables
 // For remotes: disallows template variables entirely
@@ -1,13 +1,18 @@
 package validators
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/mail"
 	"net/url"
+	"path"
 	"regexp"
 	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/modelcontextprotocol/registry/internal/config"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -20,10 +25,16 @@ var (
 	namespacePattern = `[a-zA-Z0-9][a-zA-Z0-9.-]*[a-zA-Z0-9]`
 	namePartPattern  = `[a-zA-Z0-9][a-zA-Z0-9._-]*[a-zA-Z0-9]`
 
+	// Each dot-separated namespace label must be a lowercase DNS label
+	dnsLabelRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
 	// Compiled regexes
 	namespaceRegex  = regexp.MustCompile(`^` + namespacePattern + `$`)
 	namePartRegex   = regexp.MustCompile(`^` + namePartPattern + `$`)
 	serverNameRegex = regexp.MustCompile(`^` + namespacePattern + `/` + namePartPattern + `$`)
+
+	// Environment variable names are uppercase and underscore-safe, so every shell and runtime accepts them
+	envVarNameRegex = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
 )
 
 // Regexes to detect semver range syntaxes
@@ -52,16 +63,54 @@ var (
 	dottedVersionLikeRe = regexp.MustCompile(`^\s*(?:v?\d+|x|X|\*)(?:\.(?:\d+|x|X|\*)){1,2}(?:-[0-9A-Za-z.-]+)?\s*$`)
 )
 
+// Default caps on a single server.json, used when ServerJSONLimits leaves a value unset
+const (
+	defaultMaxPackages      = 50
+	defaultMaxRemotes       = 50
+	defaultMaxDocumentBytes = 512 * 1024
+)
+
+// ServerJSONLimits caps the size of a server.json; zero values keep the defaults
+type ServerJSONLimits struct {
+	MaxPackages      int
+	MaxRemotes       int
+	MaxDocumentBytes int
+}
+
+// ServerJSONLimitsFromConfig returns the configured server.json limits, applied to both publishes and edits
+func ServerJSONLimitsFromConfig(cfg *config.Config) ServerJSONLimits {
+	return ServerJSONLimits{
+		MaxPackages:      cfg.MaxPackages,
+		MaxRemotes:       cfg.MaxRemotes,
+		MaxDocumentBytes: cfg.MaxServerJSONBytes,
+	}
+}
+
+// ValidateServerJSON validates a server.json, applying the default size limits
 func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
+	return ValidateServerJSONWithLimits(serverJSON, ServerJSONLimits{})
+}
+
+// ValidateServerJSONWithLimits validates a server.json like ValidateServerJSON, applying the given size limits
+func ValidateServerJSONWithLimits(serverJSON *apiv0.ServerJSON, limits ServerJSONLimits) error {
+	// Check the size caps first, so oversized documents are rejected before the rest of validation walks them
+	if err := validateServerJSONLimits(serverJSON, limits); err != nil {
+		return err
+	}
+
 	// Validate server name exists and format
 	if _, err := parseServerName(*serverJSON); err != nil {
 		return err
 	}
 
-	// Validate top-level server version is a specific version (not a range) & not "latest"
+	// Validate top-level server version is a specific version (not a range) & not "latest", and is semver so
+	// versions sort predictably
 	if err := validateVersion(serverJSON.Version); err != nil {
 		return err
 	}
+	if err := validateSemanticVersion(serverJSON.Version); err != nil {
+		return err
+	}
 
 	// Validate repository
 	if err := validateRepository(&serverJSON.Repository); err != nil {
@@ -73,6 +122,29 @@ func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
 		return err
 	}
 
+	// Validate documentation URL and long description if provided
+	if err := validateDocumentationURL(serverJSON.DocumentationURL); err != nil {
+		return err
+	}
+	if err := validateLongDescription(serverJSON.LongDescription); err != nil {
+		return err
+	}
+
+	// Validate icon URL if provided
+	if err := validateIconURL(serverJSON.IconURL); err != nil {
+		return err
+	}
+
+	// Validate categories if provided
+	if err := validateCategories(serverJSON.Categories); err != nil {
+		return err
+	}
+
+	// Validate maintainer contacts if provided
+	if err := validateMaintainers(serverJSON.Maintainers); err != nil {
+		return err
+	}
+
 	// Validate all packages (basic field validation)
 	// Detailed package validation (including registry checks) is done during publish
 	for _, pkg := range serverJSON.Packages {
@@ -87,6 +159,9 @@ func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
 			return err
 		}
 	}
+	if err := validateNoDuplicateRemotes(serverJSON.Remotes); err != nil {
+		return err
+	}
 
 	// Validate reverse-DNS namespace matching for remote URLs
 	if err := validateRemoteNamespaceMatch(*serverJSON); err != nil {
@@ -98,6 +173,33 @@ func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
 		return err
 	}
 
+	// A server must have at least one way to run it
+	if len(serverJSON.Packages) == 0 && len(serverJSON.Remotes) == 0 {
+		return ErrNoPackagesOrRemotes
+	}
+
+	return nil
+}
+
+// validateServerJSONLimits checks the number of packages and remotes and the serialized size of the document
+func validateServerJSONLimits(serverJSON *apiv0.ServerJSON, limits ServerJSONLimits) error {
+	maxPackages := cmp.Or(limits.MaxPackages, defaultMaxPackages)
+	if len(serverJSON.Packages) > maxPackages {
+		return fmt.Errorf("%w: %d packages exceed the limit of %d", ErrTooManyPackages, len(serverJSON.Packages), maxPackages)
+	}
+	maxRemotes := cmp.Or(limits.MaxRemotes, defaultMaxRemotes)
+	if len(serverJSON.Remotes) > maxRemotes {
+		return fmt.Errorf("%w: %d remotes exceed the limit of %d", ErrTooManyRemotes, len(serverJSON.Remotes), maxRemotes)
+	}
+
+	maxDocumentBytes := cmp.Or(limits.MaxDocumentBytes, defaultMaxDocumentBytes)
+	document, err := json.Marshal(serverJSON)
+	if err != nil {
+		return fmt.Errorf("failed to encode server.json: %w", err)
+	}
+	if len(document) > maxDocumentBytes {
+		return fmt.Errorf("%w: %d bytes exceed the limit of %d", ErrDocumentTooLarge, len(document), maxDocumentBytes)
+	}
 	return nil
 }
 
@@ -146,6 +248,36 @@ func validateWebsiteURL(websiteURL string) error {
 	return nil
 }
 
+func validateDocumentationURL(documentationURL string) error {
+	// Skip validation if documentation URL is not provided (optional field)
+	if documentationURL == "" {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(documentationURL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidDocumentationURL, err)
+	}
+
+	// Only allow absolute HTTP/HTTPS URLs, as clients link to the documentation
+	if !parsedURL.IsAbs() || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return fmt.Errorf("%w: must be an absolute http or https URL: %s", ErrInvalidDocumentationURL, documentationURL)
+	}
+
+	return nil
+}
+
+// maxLongDescriptionLength is the longest a server's long description may be, in characters
+const maxLongDescriptionLength = 10000
+
+// validateLongDescription checks that the markdown long description is within the length limit
+func validateLongDescription(longDescription string) error {
+	if length := utf8.RuneCountInString(longDescription); length > maxLongDescriptionLength {
+		return fmt.Errorf("%w: %d characters exceed the limit of %d", ErrLongDescriptionTooLong, length, maxLongDescriptionLength)
+	}
+	return nil
+}
+
 func validatePackageField(obj *model.Package) error {
 	if !HasNoSpaces(obj.Identifier) {
 		return ErrPackageNameHasSpaces
@@ -155,6 +287,9 @@ func validatePackageField(obj *model.Package) error {
 	if err := validateVersion(obj.Version); err != nil {
 		return err
 	}
+	if err := validatePackageVersionIsExact(obj); err != nil {
+		return err
+	}
 
 	// Validate runtime arguments
 	for _, arg := range obj.RuntimeArguments {
@@ -170,6 +305,11 @@ func validatePackageField(obj *model.Package) error {
 		}
 	}
 
+	// Validate environment variables
+	if err := validateEnvironmentVariables(obj.EnvironmentVariables); err != nil {
+		return err
+	}
+
 	// Validate transport with template variable support
 	availableVariables := collectAvailableVariables(obj)
 	if err := validatePackageTransport(&obj.Transport, availableVariables); err != nil {
@@ -179,8 +319,8 @@ func validatePackageField(obj *model.Package) error {
 	return nil
 }
 
-// validateVersion validates the version string.
-// NB: we decided that we would not enforce strict semver for version strings
+// validateVersion rejects version strings that do not name a specific version, for servers and packages alike.
+// Server versions must also be semantic versions; package versions follow their registry's own scheme.
 func validateVersion(version string) error {
 	if version == "latest" {
 		return ErrReservedVersionString
@@ -194,6 +334,57 @@ func validateVersion(version string) error {
 	return nil
 }
 
+// semverRegex is the regular expression suggested by semver.org for semantic versions, which have no "v" prefix
+var semverRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// validateSemanticVersion rejects server versions that are not semantic versions, such as "v1" or "2024-01",
+// which could only be ordered by publish time when choosing the latest version. Unlike semver, it also rejects a
+// leading "v".
+func validateSemanticVersion(version string) error {
+	if !semverRegex.MatchString(version) {
+		return fmt.Errorf("%w, got %q", ErrVersionNotSemver, version)
+	}
+	return nil
+}
+
+// validatePackageVersionIsExact rejects package versions that can resolve to different releases over time, such as
+// wildcards, npm ranges, PyPI version specifiers and NuGet version ranges, so the published entry is
+// reproducible. OCI tags are left to the OCI validator, which decides whether mutable tags are allowed.
+func validatePackageVersionIsExact(pkg *model.Package) error {
+	version := strings.TrimSpace(pkg.Version)
+	if version == "" {
+		return nil
+	}
+
+	notExact := func() error {
+		return fmt.Errorf("%w: %s package %s has version %q", ErrPackageVersionNotExact, pkg.RegistryType, pkg.Identifier, pkg.Version)
+	}
+	if version == "*" || strings.EqualFold(version, "x") {
+		return notExact()
+	}
+
+	switch pkg.RegistryType {
+	case model.RegistryTypeNPM:
+		// Space-separated comparators form an npm range, e.g. ">=1.0.0 <2.0.0"
+		if strings.ContainsAny(version, " <>") {
+			return notExact()
+		}
+	case model.RegistryTypePyPI:
+		// PEP 440 specifiers, e.g. "==1.0.0", "~=1.0", ">=1.0,<2.0" or "1.0.*"
+		if strings.ContainsAny(version, "=<>!~,*") {
+			return notExact()
+		}
+	case model.RegistryTypeNuGet:
+		// NuGet version ranges and floating versions, e.g. "[1.0,2.0)" or "1.*"
+		if strings.ContainsAny(version, "[]()*,") {
+			return notExact()
+		}
+	}
+	return nil
+}
+
 // looksLikeVersionRange detects common semver range syntaxes and wildcard patterns.
 // that indicate the value is not a single, specific version.
 // Examples that should return true:
@@ -272,6 +463,21 @@ func validateArgumentValueFields(name, value, defaultValue string) error {
 	return nil
 }
 
+// validateEnvironmentVariables checks that environment variable names are well-formed and declared once
+func validateEnvironmentVariables(envVars []model.KeyValueInput) error {
+	seen := make(map[string]bool, len(envVars))
+	for _, env := range envVars {
+		if !envVarNameRegex.MatchString(env.Name) {
+			return fmt.Errorf("%w: %q", ErrInvalidEnvironmentVariableName, env.Name)
+		}
+		if seen[env.Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicateEnvironmentVariable, env.Name)
+		}
+		seen[env.Name] = true
+	}
+	return nil
+}
+
 // collectAvailableVariables collects all available template variables from a package
 func collectAvailableVariables(pkg *model.Package) []string {
 	var variables []string
@@ -331,7 +537,7 @@ func validatePackageTransport(transport *model.Transport, availableVariables []s
 		}
 		return nil
 	default:
-		return fmt.Errorf("unsupported transport type: %s", transport.Type)
+		return fmt.Errorf("%w: %s", ErrUnsupportedTransportType, transport.Type)
 	}
 }
 
@@ -350,31 +556,224 @@ func validateRemoteTransport(obj *model.Transport) error {
 		}
 		return nil
 	default:
-		return fmt.Errorf("unsupported transport type for remotes: %s (only streamable-http and sse are supported)", obj.Type)
+		return fmt.Errorf("%w for remotes: %s (only streamable-http and sse are supported)", ErrUnsupportedTransportType, obj.Type)
 	}
 }
 
+// validateNoDuplicateRemotes rejects a server listing the same remote URL twice, comparing URLs as NormalizeRemoteURL does
+func validateNoDuplicateRemotes(remotes []model.Transport) error {
+	seen := make(map[string]bool, len(remotes))
+	for _, remote := range remotes {
+		normalized := NormalizeRemoteURL(remote.URL)
+		if seen[normalized] {
+			return fmt.Errorf("%w: %s", ErrDuplicateRemote, remote.URL)
+		}
+		seen[normalized] = true
+	}
+	return nil
+}
+
 // ValidatePublishRequest validates a complete publish request including extensions
 func ValidatePublishRequest(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config) error {
+	_, err := VerifyPublishRequest(ctx, req, cfg)
+	return err
+}
+
+// VerifyPublishRequest validates a publish request like ValidatePublishRequest, also reporting whether ownership
+// of every package was proven against its registry. It is not when registry validation is disabled or a check
+// was skipped because the registry is degraded.
+func VerifyPublishRequest(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config) (bool, error) {
 	// Validate publisher extensions in _meta
 	if err := validatePublisherExtensions(req); err != nil {
-		return err
+		return false, err
 	}
 
 	// Validate the server detail (includes all nested validation)
-	if err := ValidateServerJSON(&req); err != nil {
-		return err
+	if err := ValidateServerJSONWithLimits(&req, ServerJSONLimitsFromConfig(cfg)); err != nil {
+		return false, err
+	}
+
+	// Restrict publishing to allowlisted namespaces, e.g. while bootstrapping a private registry
+	if err := validatePublishNamespaceAllowlist(req, cfg); err != nil {
+		return false, err
+	}
+	if err := validateNameNotBlocked(req, cfg); err != nil {
+		return false, err
+	}
+
+	// Bound the overall size of the document
+	if err := ValidatePackagesAndRemotesLimit(req, cfg); err != nil {
+		return false, err
+	}
+
+	// Enforce any additional fields this deployment requires
+	if err := ValidateRequiredFields(req, cfg); err != nil {
+		return false, err
+	}
+	if err := ValidateKnownCategories(req, cfg); err != nil {
+		return false, err
+	}
+
+	// GitHub namespaces must use the owner's casing, as publish permissions are case-sensitive
+	if cfg.EnforceGitHubOwnerCase {
+		if err := validateGitHubOwnerCase(req); err != nil {
+			return false, err
+		}
+	}
+
+	// Status is set by the registry; publishers may only declare it as active
+	if cfg.EnforceActivePublishStatus {
+		if err := validatePublishStatus(req); err != nil {
+			return false, err
+		}
+	}
+
+	// Validate the structure against the published JSON schema
+	if err := ValidateAgainstSchema(&req); err != nil {
+		return false, err
+	}
+
+	// Check that the icon is actually served as an image
+	if err := ValidateIconContentType(ctx, req, cfg); err != nil {
+		return false, err
+	}
+
+	// Validate that packages only reference registries this deployment allows
+	if err := ValidateRegistryBaseURLPolicies(req.Packages, cfg); err != nil {
+		return false, err
 	}
 
 	// Validate registry ownership for all packages if validation is enabled
-	if cfg.EnableRegistryValidation {
-		for i, pkg := range req.Packages {
-			if err := ValidatePackage(ctx, pkg, req.Name); err != nil {
-				return fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
+	if !cfg.EnableRegistryValidation {
+		return false, nil
+	}
+	return VerifyPackages(ctx, req.Packages, req.Name, cfg)
+}
+
+// defaultMaxPackagesAndRemotes is used when the config does not set MaxPackagesAndRemotes
+const defaultMaxPackagesAndRemotes = 50
+
+// ValidatePackagesAndRemotesLimit checks that the combined number of packages and remotes is within the configured cap
+func ValidatePackagesAndRemotesLimit(serverJSON apiv0.ServerJSON, cfg *config.Config) error {
+	limit := defaultMaxPackagesAndRemotes
+	if cfg.MaxPackagesAndRemotes > 0 {
+		limit = cfg.MaxPackagesAndRemotes
+	}
+
+	if total := len(serverJSON.Packages) + len(serverJSON.Remotes); total > limit {
+		return fmt.Errorf("%w: %d packages and %d remotes exceed the combined limit of %d",
+			ErrTooManyPackagesAndRemotes, len(serverJSON.Packages), len(serverJSON.Remotes), limit)
+	}
+	return nil
+}
+
+const (
+	// maxCategories is the most categories a server may declare
+	maxCategories = 10
+	// maxCategoryLength is the longest a single category may be
+	maxCategoryLength = 50
+)
+
+// categoryRegex matches lowercase, hyphen-separated category slugs such as "databases" or "web-search"
+var categoryRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// validateCategories checks that categories are unique lowercase slugs within the length limits
+func validateCategories(categories []string) error {
+	if len(categories) > maxCategories {
+		return fmt.Errorf("%w: %d categories exceed the limit of %d", ErrInvalidCategory, len(categories), maxCategories)
+	}
+	seen := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		if len(category) > maxCategoryLength || !categoryRegex.MatchString(category) {
+			return fmt.Errorf("%w: '%s' must be a lowercase hyphen-separated slug of at most %d characters", ErrInvalidCategory, category, maxCategoryLength)
+		}
+		if seen[category] {
+			return fmt.Errorf("%w: '%s' is listed more than once", ErrInvalidCategory, category)
+		}
+		seen[category] = true
+	}
+	return nil
+}
+
+// ValidateKnownCategories checks that every category is in the configured known set; an empty set allows any category
+func ValidateKnownCategories(serverJSON apiv0.ServerJSON, cfg *config.Config) error {
+	if len(cfg.KnownCategories) == 0 {
+		return nil
+	}
+	for _, category := range serverJSON.Categories {
+		if !slices.Contains(cfg.KnownCategories, category) {
+			return fmt.Errorf("%w: '%s' is not a known category (known: %s)", ErrInvalidCategory, category, strings.Join(cfg.KnownCategories, ", "))
+		}
+	}
+	return nil
+}
+
+// maxMaintainers is the most maintainers a server may list
+const maxMaintainers = 10
+
+// validateMaintainers checks that each maintainer has a name and a way to reach them: a plain email address or
+// an https URL
+func validateMaintainers(maintainers []model.Maintainer) error {
+	if len(maintainers) > maxMaintainers {
+		return fmt.Errorf("%w: %d maintainers exceed the limit of %d", ErrInvalidMaintainer, len(maintainers), maxMaintainers)
+	}
+	for i, maintainer := range maintainers {
+		if strings.TrimSpace(maintainer.Name) == "" {
+			return fmt.Errorf("%w: maintainer %d must have a name", ErrInvalidMaintainer, i)
+		}
+		if maintainer.Email == "" && maintainer.URL == "" {
+			return fmt.Errorf("%w: maintainer %d must have an email or url", ErrInvalidMaintainer, i)
+		}
+		if maintainer.Email != "" {
+			// Only bare addresses, without a display name such as "Jane <jane@example.com>"
+			if address, err := mail.ParseAddress(maintainer.Email); err != nil || address.Address != maintainer.Email {
+				return fmt.Errorf("%w: maintainer %d has an invalid email address: %s", ErrInvalidMaintainer, i, maintainer.Email)
+			}
+		}
+		if maintainer.URL != "" {
+			if parsedURL, err := url.Parse(maintainer.URL); err != nil || parsedURL.Scheme != "https" || parsedURL.Host == "" {
+				return fmt.Errorf("%w: maintainer %d url must be an absolute https URL: %s", ErrInvalidMaintainer, i, maintainer.URL)
 			}
 		}
 	}
+	return nil
+}
+
+// requiredFieldChecks report whether a server sets each top-level field operators can require
+var requiredFieldChecks = map[string]func(apiv0.ServerJSON) bool{
+	"$schema":          func(s apiv0.ServerJSON) bool { return s.Schema != "" },
+	"repository":       func(s apiv0.ServerJSON) bool { return s.Repository.URL != "" },
+	"websiteUrl":       func(s apiv0.ServerJSON) bool { return s.WebsiteURL != "" },
+	"iconUrl":          func(s apiv0.ServerJSON) bool { return s.IconURL != "" },
+	"documentationUrl": func(s apiv0.ServerJSON) bool { return s.DocumentationURL != "" },
+	"longDescription":  func(s apiv0.ServerJSON) bool { return s.LongDescription != "" },
+	"categories":       func(s apiv0.ServerJSON) bool { return len(s.Categories) > 0 },
+	"maintainers":      func(s apiv0.ServerJSON) bool { return len(s.Maintainers) > 0 },
+	"packages":         func(s apiv0.ServerJSON) bool { return len(s.Packages) > 0 },
+	"remotes":          func(s apiv0.ServerJSON) bool { return len(s.Remotes) > 0 },
+}
+
+// ValidateRequiredFields checks that the server sets every top-level field the config requires, returning an
+// error for each missing field
+func ValidateRequiredFields(serverJSON apiv0.ServerJSON, cfg *config.Config) error {
+	var errs []error
+	for _, field := range cfg.RequiredServerFields {
+		if isSet, ok := requiredFieldChecks[field]; ok && !isSet(serverJSON) {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrMissingRequiredField, field))
+		}
+	}
+	return errors.Join(errs...)
+}
 
+// validatePublishStatus rejects publish requests that declare a status other than active
+func validatePublishStatus(req apiv0.ServerJSON) error {
+	if req.Meta == nil || req.Meta.Official == nil {
+		return nil
+	}
+	status := req.Meta.Official.Status
+	if status != "" && status != model.StatusActive {
+		return fmt.Errorf("%w: got '%s'", ErrNonActivePublishStatus, status)
+	}
 	return nil
 }
 
@@ -421,6 +820,12 @@ func parseServerName(serverJSON apiv0.ServerJSON) (string, error) {
 		return "", fmt.Errorf("server name must be in format 'dns-namespace/name' with non-empty namespace and name parts")
 	}
 
+	// Validate the namespace is a reversed domain before the combined format check, so malformed
+	// namespaces get a specific error
+	if err := validateReverseDNSNamespace(parts[0]); err != nil {
+		return "", err
+	}
+
 	// Validate name format using regex
 	if !serverNameRegex.MatchString(name) {
 		namespace := parts[0]
@@ -440,6 +845,106 @@ func parseServerName(serverJSON apiv0.ServerJSON) (string, error) {
 	return name, nil
 }
 
+// Reverse-DNS namespace limits, matching the limits on DNS names
+const (
+	maxNamespaceLength = 253
+	maxDNSLabelLength  = 63
+)
+
+// gitHostNamespaces are namespace prefixes whose next label is a GitHub or GitLab account name rather than
+// a domain label. Account names are display-cased, so that label may contain uppercase letters.
+var gitHostNamespaces = []string{"io.github.", "com.gitlab."}
+
+// validateReverseDNSNamespace checks that the namespace is a sane reversed domain name, e.g. com.example.api
+func validateReverseDNSNamespace(namespace string) error {
+	if len(namespace) > maxNamespaceLength {
+		return fmt.Errorf("%w: namespace '%s' is longer than %d characters", ErrInvalidServerNamespace, namespace, maxNamespaceLength)
+	}
+
+	accountLabel := -1
+	for _, prefix := range gitHostNamespaces {
+		if strings.HasPrefix(namespace, prefix) {
+			accountLabel = strings.Count(prefix, ".")
+			break
+		}
+	}
+
+	for i, label := range strings.Split(namespace, ".") {
+		switch {
+		case label == "":
+			return fmt.Errorf("%w: namespace '%s' contains an empty label", ErrInvalidServerNamespace, namespace)
+		case len(label) > maxDNSLabelLength:
+			return fmt.Errorf("%w: label '%s' in namespace '%s' is longer than %d characters", ErrInvalidServerNamespace, label, namespace, maxDNSLabelLength)
+		case i == accountLabel:
+			label = strings.ToLower(label)
+		}
+		if !dnsLabelRegex.MatchString(label) {
+			return fmt.Errorf("%w: label '%s' in namespace '%s' must contain only lowercase letters, digits and hyphens, and must not start or end with a hyphen", ErrInvalidServerNamespace, label, namespace)
+		}
+	}
+
+	return nil
+}
+
+// validateGitHubOwnerCase rejects io.github servers whose namespace owner differs only in case from the owner
+// of their GitHub repository. GitHub owners are case-insensitive, but publish permissions are granted for the
+// owner's canonical casing, so a differently cased namespace would not match them consistently.
+func validateGitHubOwnerCase(serverJSON apiv0.ServerJSON) error {
+	namespace, _, _ := strings.Cut(serverJSON.Name, "/")
+	namespaceOwner, ok := strings.CutPrefix(namespace, "io.github.")
+	if !ok || serverJSON.Repository.URL == "" {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(serverJSON.Repository.URL)
+	if err != nil || parsedURL.Host != "github.com" {
+		return nil
+	}
+	repoOwner, _, _ := strings.Cut(strings.TrimPrefix(parsedURL.Path, "/"), "/")
+
+	if namespaceOwner != repoOwner && strings.EqualFold(namespaceOwner, repoOwner) {
+		return fmt.Errorf("%w: namespace '%s' should be 'io.github.%s' to match the repository owner",
+			ErrGitHubOwnerCaseMismatch, namespace, repoOwner)
+	}
+	return nil
+}
+
+// validatePublishNamespaceAllowlist checks the server's namespace is an allowlisted namespace or one of its
+// subdomains, when an allowlist is configured
+func validatePublishNamespaceAllowlist(serverJSON apiv0.ServerJSON, cfg *config.Config) error {
+	if len(cfg.PublishNamespaceAllowlist) == 0 {
+		return nil
+	}
+
+	namespace, _, _ := strings.Cut(serverJSON.Name, "/")
+	allowed := func(entry string) bool {
+		entry = strings.TrimSpace(entry)
+		return entry != "" && (namespace == entry || strings.HasPrefix(namespace, entry+"."))
+	}
+	if !slices.ContainsFunc(cfg.PublishNamespaceAllowlist, allowed) {
+		return fmt.Errorf("%w: namespace '%s' may not publish yet (allowed: %s)",
+			ErrNamespaceNotAllowed, namespace, strings.Join(cfg.PublishNamespaceAllowlist, ", "))
+	}
+	return nil
+}
+
+// validateNameNotBlocked rejects server names matching one of the configured blocked name patterns. Names are
+// matched case-insensitively, so blocked names can't be published by changing their casing. The error does not
+// say which pattern matched, so the denylist isn't revealed to publishers probing it.
+func validateNameNotBlocked(serverJSON apiv0.ServerJSON, cfg *config.Config) error {
+	name := strings.ToLower(serverJSON.Name)
+	for _, pattern := range cfg.BlockedNamePatterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return fmt.Errorf("%w: %s", ErrNameBlocked, serverJSON.Name)
+		}
+	}
+	return nil
+}
+
 // validateRemoteNamespaceMatch validates that remote URLs match the reverse-DNS namespace
 func validateRemoteNamespaceMatch(serverJSON apiv0.ServerJSON) error {
 	namespace := serverJSON.Name
@@ -1,6 +1,7 @@
 package v0
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
@@ -12,6 +13,19 @@ type RegistryExtensions struct {
 	PublishedAt time.Time    `json:"publishedAt"`
 	UpdatedAt   time.Time    `json:"updatedAt,omitempty"`
 	IsLatest    bool         `json:"isLatest"`
+	Unlisted    bool         `json:"unlisted,omitempty"`
+	// OriginRegistry is the URL of the registry the server was imported from; empty if published to this registry
+	OriginRegistry string `json:"originRegistry,omitempty"`
+	// PublisherSubject is the subject of the token the version was published with, e.g. a GitHub username
+	PublisherSubject string `json:"publisherSubject,omitempty"`
+	// Verified reports that the registry proved the server owns all its packages when the version was last
+	// published or edited, at VerifiedAt. It is false when ownership validation was disabled or skipped.
+	Verified   bool       `json:"verified"`
+	VerifiedAt *time.Time `json:"verifiedAt,omitempty"`
+	// DeprecationMessage explains why a deprecated version was deprecated
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+	// SupersededBy names the server that replaces a deprecated version
+	SupersededBy string `json:"supersededBy,omitempty"`
 }
 
 // ResponseMeta represents the top-level metadata in API responses
@@ -25,6 +39,11 @@ type ServerResponse struct {
 	Meta   ResponseMeta `json:"_meta"`
 }
 
+// PackagesResponse lists the packages of a server version, for clients that only need install information
+type PackagesResponse struct {
+	Packages []model.Package `json:"packages"`
+}
+
 // ServerListResponse represents the paginated server list response
 type ServerListResponse struct {
 	Servers  []ServerResponse `json:"servers"`
@@ -34,23 +53,127 @@ type ServerListResponse struct {
 // ServerMeta represents the structured metadata with known extension fields
 type ServerMeta struct {
 	PublisherProvided map[string]interface{} `json:"io.modelcontextprotocol.registry/publisher-provided,omitempty"`
+	// Official is registry-managed metadata. It is only read from publish requests to check the declared
+	// status, and is never stored with the server.
+	Official *RegistryExtensions `json:"io.modelcontextprotocol.registry/official,omitempty"`
 }
 
 // ServerJSON represents complete server information as defined in the MCP spec, with extension support
 type ServerJSON struct {
-	Schema      string            `json:"$schema,omitempty"`
-	Name        string            `json:"name" minLength:"1" maxLength:"200"`
-	Description string            `json:"description" minLength:"1" maxLength:"100"`
-	Repository  model.Repository  `json:"repository,omitempty"`
-	Version     string            `json:"version"`
-	WebsiteURL  string            `json:"websiteUrl,omitempty"`
-	Packages    []model.Package   `json:"packages,omitempty"`
-	Remotes     []model.Transport `json:"remotes,omitempty"`
-	Meta        *ServerMeta       `json:"_meta,omitempty"`
+	Schema           string             `json:"$schema,omitempty"`
+	Name             string             `json:"name" minLength:"1" maxLength:"200"`
+	Description      string             `json:"description" minLength:"1" maxLength:"100"`
+	Repository       model.Repository   `json:"repository,omitempty"`
+	Version          string             `json:"version"`
+	WebsiteURL       string             `json:"websiteUrl,omitempty"`
+	IconURL          string             `json:"iconUrl,omitempty" doc:"HTTPS URL of an icon or logo for the server" maxLength:"2048"`
+	DocumentationURL string             `json:"documentationUrl,omitempty" doc:"URL of the server's documentation, such as its README"`
+	LongDescription  string             `json:"longDescription,omitempty" doc:"Longer description of the server, in markdown" maxLength:"10000"`
+	Categories       []string           `json:"categories,omitempty" doc:"Categories for browsing, such as 'databases' or 'filesystem'" maxItems:"10"`
+	Maintainers      []model.Maintainer `json:"maintainers,omitempty" doc:"Contacts responsible for the server, for reporting incidents" maxItems:"10"`
+	Packages         []model.Package    `json:"packages,omitempty"`
+	Remotes          []model.Transport  `json:"remotes,omitempty"`
+	Meta             *ServerMeta        `json:"_meta,omitempty"`
+}
+
+// AuditEntry records a mutating operation on a server version: who performed it, and whether it succeeded
+type AuditEntry struct {
+	ID         int64     `json:"id"`
+	Actor      string    `json:"actor"`
+	Action     string    `json:"action"`
+	ServerName string    `json:"serverName"`
+	Version    string    `json:"version,omitempty"`
+	Result     string    `json:"result"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// ServerAlias resolves an old server name to the server it was renamed to
+type ServerAlias struct {
+	Alias     string    `json:"alias" minLength:"1" doc:"Old server name that should resolve to the target"`
+	Target    string    `json:"target" minLength:"1" doc:"Name of the server the alias resolves to"`
+	CreatedAt time.Time `json:"createdAt,omitempty" readOnly:"true"`
+}
+
+// AuditListResponse represents a page of audit log entries, newest first
+type AuditListResponse struct {
+	Entries  []AuditEntry `json:"entries"`
+	Metadata Metadata     `json:"metadata"`
+}
+
+// ServerStats represents counts of servers by the status of their latest version
+type ServerStats struct {
+	Active        int `json:"active"`
+	Deprecated    int `json:"deprecated"`
+	Deleted       int `json:"deleted"`
+	TotalServers  int `json:"total_servers"`
+	TotalVersions int `json:"total_versions"`
+}
+
+// ReindexLatestResult reports the outcome of recomputing the latest version of every server
+type ReindexLatestResult struct {
+	ServersChecked   int `json:"serversChecked"`
+	ServersCorrected int `json:"serversCorrected"`
+}
+
+// RawServer is a server version as stored, for debugging data issues: the value column exactly as the database
+// returns it, alongside the metadata columns the registry keeps outside of it
+type RawServer struct {
+	Columns RawServerColumns `json:"columns"`
+	Value   json.RawMessage  `json:"value"`
+}
+
+// RawServerColumns are the metadata columns of a stored server version
+type RawServerColumns struct {
+	ServerName         string     `json:"serverName"`
+	Version            string     `json:"version"`
+	Status             string     `json:"status"`
+	PublishedAt        time.Time  `json:"publishedAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+	IsLatest           bool       `json:"isLatest"`
+	Unlisted           bool       `json:"unlisted"`
+	OriginRegistry     string     `json:"originRegistry"`
+	PublisherSubject   string     `json:"publisherSubject"`
+	VerifiedAt         *time.Time `json:"verifiedAt"`
+	DeprecationMessage string     `json:"deprecationMessage"`
+	SupersededBy       string     `json:"supersededBy"`
+}
+
+// PurgeDeletedResult reports how many deleted versions were permanently removed
+type PurgeDeletedResult struct {
+	VersionsPurged int `json:"versionsPurged"`
 }
 
 // Metadata represents pagination metadata
 type Metadata struct {
-	NextCursor string `json:"nextCursor,omitempty"`
-	Count      int    `json:"count"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+	PrevCursor string      `json:"prevCursor,omitempty"`
+	Count      int         `json:"count"`
+	Filter     *ListFilter `json:"filter,omitempty"`
+}
+
+// ListFilter echoes the normalized filter a list request was served with, including server-side defaults
+type ListFilter struct {
+	Search            string     `json:"search,omitempty"`
+	OriginRegistry    string     `json:"originRegistry,omitempty"`
+	PublishedBy       string     `json:"publishedBy,omitempty"`
+	RegistryBaseURL   string     `json:"registryBaseUrl,omitempty"`
+	PackageIdentifier string     `json:"packageIdentifier,omitempty"`
+	Verified          *bool      `json:"verified,omitempty"`
+	HasRepository     *bool      `json:"hasRepository,omitempty"`
+	Category          string     `json:"category,omitempty"`
+	MaintainerEmail   string     `json:"maintainerEmail,omitempty"`
+	VersionMode       string     `json:"versionMode"`
+	Version           string     `json:"version,omitempty"`
+	UpdatedSince      *time.Time `json:"updatedSince,omitempty"`
+	UpdatedBefore     *time.Time `json:"updatedBefore,omitempty"`
+	Status            string     `json:"status,omitempty"`
+	Sort              string     `json:"sort"`
+	Limit             int        `json:"limit"`
 }
+
+// Version modes reported in ListFilter
+const (
+	VersionModeAll    = "all"
+	VersionModeLatest = "latest"
+	VersionModeExact  = "exact"
+)
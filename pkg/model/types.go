@@ -7,6 +7,8 @@ const (
 	StatusActive     Status = "active"
 	StatusDeprecated Status = "deprecated"
 	StatusDeleted    Status = "deleted"
+	// StatusPending marks a version held for admin review before it goes live
+	StatusPending Status = "pending"
 )
 
 // Transport represents transport configuration with optional URL templating
@@ -41,6 +43,13 @@ type Repository struct {
 	Subfolder string `json:"subfolder,omitempty"`
 }
 
+// Maintainer is a contact responsible for a server, for reporting incidents
+type Maintainer struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	URL   string `json:"url,omitempty"`
+}
+
 // Format represents the input format type
 type Format string
 